@@ -0,0 +1,52 @@
+package automation
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock은 자동화 실행에 사용되는 시간 소스입니다
+// 테스트에서는 ManualClock으로 대체하여 실제로 기다리지 않고 타이밍을 검증할 수 있습니다
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock은 실제 시간을 사용하는 기본 Clock입니다
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// ManualClock은 대기 요청 시 즉시 가상 시간을 진행시키는 Clock입니다
+type ManualClock struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+// NewManualClock은 지정된 시각에서 시작하는 ManualClock을 생성합니다
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now는 현재 가상 시각을 반환합니다
+func (c *ManualClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// After는 가상 시간을 d만큼 진행시키고 즉시 신호를 보내는 채널을 반환합니다
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+	now := c.now
+	c.mutex.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- now
+	return ch
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package automation
+
+import "os/exec"
+
+// hideConsoleWindow는 Windows 이외의 환경에서는 아무 작업도 하지 않습니다
+func hideConsoleWindow(cmd *exec.Cmd) {}
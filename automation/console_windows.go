@@ -0,0 +1,19 @@
+//go:build windows
+
+package automation
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// CREATE_NO_WINDOW
+const createNoWindow = 0x08000000
+
+// hideConsoleWindow는 외부 명령 실행 시 콘솔 창이 깜빡이지 않도록 합니다
+func hideConsoleWindow(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: createNoWindow,
+	}
+}
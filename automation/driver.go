@@ -0,0 +1,21 @@
+package automation
+
+import "fmt"
+
+// InputDriver는 키보드/마우스 입력을 실제로 발생시키는 백엔드입니다
+// 기본값은 robotgo 드라이버이며, 테스트에서는 RecordingDriver를 사용합니다
+type InputDriver interface {
+	KeyTap(key string) error
+	KeyDown(key string) error
+	KeyUp(key string) error
+	TypeString(text string) error
+	MouseMove(x, y int) error
+	MouseClick(button string, double bool) error
+}
+
+// recoverDriverPanic은 드라이버 호출 중 발생한 패닉을 오류로 변환합니다
+func recoverDriverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("입력 드라이버 오류: %v", r)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build headless
+
+package automation
+
+// defaultInputDriver는 headless 빌드에서 실제 입력 대신 기록 드라이버를 사용합니다
+// (go test -tags headless ./automation 처럼 디스플레이 없는 환경에서 사용)
+func defaultInputDriver() InputDriver {
+	return NewRecordingDriver(realClock{})
+}
+
+// defaultScreenCapturer는 headless 빌드에서 화면 캡처기를 제공하지 않습니다
+// (테스트에서는 ImageCapturer를 KeyboardManager.Screen에 지정)
+func defaultScreenCapturer() ScreenCapturer {
+	return nil
+}
@@ -0,0 +1,124 @@
+package automation
+
+import (
+	"sync"
+	"time"
+)
+
+// InputEventType은 기록된 입력 이벤트의 종류입니다
+type InputEventType string
+
+const (
+	EventKeyTap     InputEventType = "key_tap"
+	EventKeyDown    InputEventType = "key_down"
+	EventKeyUp      InputEventType = "key_up"
+	EventTypeString InputEventType = "type_string"
+	EventMouseMove  InputEventType = "mouse_move"
+	EventMouseClick InputEventType = "mouse_click"
+)
+
+// InputEvent는 RecordingDriver가 기록한 입력 이벤트입니다
+type InputEvent struct {
+	Time   time.Time
+	Type   InputEventType
+	Key    string
+	Text   string
+	X      int
+	Y      int
+	Button string
+	Double bool
+}
+
+// RecordingDriver는 실제 입력 대신 이벤트를 시각과 함께 메모리에 기록하는 드라이버입니다
+type RecordingDriver struct {
+	Clock Clock
+	// FailOn에 포함된 키는 KeyTap/KeyDown/KeyUp 호출 시 오류를 반환합니다 (오류 처리 테스트용)
+	FailOn map[string]error
+	events []InputEvent
+	mutex  sync.Mutex
+}
+
+// NewRecordingDriver는 새로운 기록 드라이버를 생성합니다
+func NewRecordingDriver(clock Clock) *RecordingDriver {
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &RecordingDriver{
+		Clock:  clock,
+		FailOn: make(map[string]error),
+	}
+}
+
+// record는 이벤트를 현재 시각과 함께 저장합니다
+func (d *RecordingDriver) record(event InputEvent) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if event.Key != "" {
+		if err, ok := d.FailOn[event.Key]; ok {
+			return err
+		}
+	}
+
+	event.Time = d.Clock.Now()
+	d.events = append(d.events, event)
+	return nil
+}
+
+// Events는 지금까지 기록된 이벤트의 복사본을 반환합니다
+func (d *RecordingDriver) Events() []InputEvent {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	result := make([]InputEvent, len(d.events))
+	copy(result, d.events)
+	return result
+}
+
+// Keys는 기록된 KeyTap 이벤트의 키 목록을 순서대로 반환합니다
+func (d *RecordingDriver) Keys() []string {
+	var keys []string
+	for _, event := range d.Events() {
+		if event.Type == EventKeyTap {
+			keys = append(keys, event.Key)
+		}
+	}
+	return keys
+}
+
+// Reset은 기록된 이벤트를 모두 지웁니다
+func (d *RecordingDriver) Reset() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.events = nil
+}
+
+// KeyTap은 키 입력 이벤트를 기록합니다
+func (d *RecordingDriver) KeyTap(key string) error {
+	return d.record(InputEvent{Type: EventKeyTap, Key: key})
+}
+
+// KeyDown은 키 누름 이벤트를 기록합니다
+func (d *RecordingDriver) KeyDown(key string) error {
+	return d.record(InputEvent{Type: EventKeyDown, Key: key})
+}
+
+// KeyUp은 키 뗌 이벤트를 기록합니다
+func (d *RecordingDriver) KeyUp(key string) error {
+	return d.record(InputEvent{Type: EventKeyUp, Key: key})
+}
+
+// TypeString은 문자열 입력 이벤트를 기록합니다
+func (d *RecordingDriver) TypeString(text string) error {
+	return d.record(InputEvent{Type: EventTypeString, Text: text})
+}
+
+// MouseMove는 마우스 이동 이벤트를 기록합니다
+func (d *RecordingDriver) MouseMove(x, y int) error {
+	return d.record(InputEvent{Type: EventMouseMove, X: x, Y: y})
+}
+
+// MouseClick은 마우스 클릭 이벤트를 기록합니다
+func (d *RecordingDriver) MouseClick(button string, double bool) error {
+	return d.record(InputEvent{Type: EventMouseClick, Button: button, Double: double})
+}
@@ -0,0 +1,79 @@
+//go:build !headless
+
+package automation
+
+import (
+	"image"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// RobotgoDriver는 robotgo를 사용하는 기본 입력 드라이버입니다
+type RobotgoDriver struct{}
+
+// NewRobotgoDriver는 새로운 robotgo 입력 드라이버를 생성합니다
+func NewRobotgoDriver() *RobotgoDriver {
+	return &RobotgoDriver{}
+}
+
+// defaultInputDriver는 빌드 환경의 기본 입력 드라이버를 반환합니다
+func defaultInputDriver() InputDriver {
+	return NewRobotgoDriver()
+}
+
+// KeyTap은 키를 한 번 누릅니다
+func (d *RobotgoDriver) KeyTap(key string) (err error) {
+	defer recoverDriverPanic(&err)
+	return robotgo.KeyTap(key)
+}
+
+// KeyDown은 키를 누른 상태로 유지합니다
+func (d *RobotgoDriver) KeyDown(key string) (err error) {
+	defer recoverDriverPanic(&err)
+	return robotgo.KeyToggle(key, "down")
+}
+
+// KeyUp은 누르고 있던 키를 뗍니다
+func (d *RobotgoDriver) KeyUp(key string) (err error) {
+	defer recoverDriverPanic(&err)
+	return robotgo.KeyToggle(key, "up")
+}
+
+// TypeString은 문자열을 입력합니다
+func (d *RobotgoDriver) TypeString(text string) (err error) {
+	defer recoverDriverPanic(&err)
+	robotgo.TypeStr(text)
+	return nil
+}
+
+// MouseMove는 마우스 커서를 이동합니다
+func (d *RobotgoDriver) MouseMove(x, y int) (err error) {
+	defer recoverDriverPanic(&err)
+	robotgo.Move(x, y)
+	return nil
+}
+
+// MouseClick은 마우스 버튼을 클릭합니다 (button: left, right, center)
+func (d *RobotgoDriver) MouseClick(button string, double bool) (err error) {
+	defer recoverDriverPanic(&err)
+	robotgo.Click(button, double)
+	return nil
+}
+
+// RobotgoScreen은 robotgo로 실제 화면을 캡처하는 ScreenCapturer입니다
+type RobotgoScreen struct{}
+
+// defaultScreenCapturer는 빌드 환경의 기본 화면 캡처기를 반환합니다
+func defaultScreenCapturer() ScreenCapturer {
+	return RobotgoScreen{}
+}
+
+// Capture는 화면의 지정된 영역을 캡처합니다 (빈 영역이면 전체 화면)
+func (RobotgoScreen) Capture(rect image.Rectangle) (img image.Image, err error) {
+	defer recoverDriverPanic(&err)
+	if rect.Empty() {
+		width, height := robotgo.GetScreenSize()
+		rect = image.Rect(0, 0, width, height)
+	}
+	return robotgo.CaptureImg(rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy())
+}
@@ -0,0 +1,159 @@
+package automation
+
+import (
+	"sync"
+	"time"
+)
+
+// KeyHookEvent는 전역 키보드 후크에서 받은 키 이벤트입니다
+type KeyHookEvent struct {
+	Key      string
+	Down     bool
+	Injected bool // 프로그램(자동화)이 보낸 입력 여부
+	Time     time.Time
+}
+
+// KeyHook은 전역 키 입력을 구독하는 인터페이스입니다
+// 테스트에서는 ManualKeyHook으로 이벤트를 직접 주입할 수 있습니다
+type KeyHook interface {
+	Start(handler func(KeyHookEvent)) error
+	Stop()
+}
+
+// 후크 이벤트 대기열 크기 (OS 후크 콜백이 막히지 않도록 버퍼 사용)
+const hookEventBuffer = 256
+
+// hookHub는 OS 키보드 후크 하나를 여러 구독자가 공유하도록 관리합니다
+type hookHub struct {
+	subscribers map[int]func(KeyHookEvent)
+	nextID      int
+	started     bool
+	events      chan KeyHookEvent
+	mutex       sync.Mutex
+}
+
+var globalHookHub = &hookHub{
+	subscribers: make(map[int]func(KeyHookEvent)),
+	events:      make(chan KeyHookEvent, hookEventBuffer),
+}
+
+// subscribe는 구독자를 등록하고, 처음 등록될 때 OS 후크를 설치합니다
+func (h *hookHub) subscribe(handler func(KeyHookEvent)) (int, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.started {
+		if err := startPlatformHook(h.post); err != nil {
+			return 0, err
+		}
+		h.started = true
+		go h.loop()
+	}
+
+	h.nextID++
+	h.subscribers[h.nextID] = handler
+	return h.nextID, nil
+}
+
+// unsubscribe는 구독자를 제거합니다 (OS 후크는 프로그램 종료까지 유지)
+func (h *hookHub) unsubscribe(id int) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.subscribers, id)
+}
+
+// post는 OS 후크 콜백에서 호출되며, 대기열이 가득 차면 이벤트를 버립니다
+func (h *hookHub) post(event KeyHookEvent) {
+	select {
+	case h.events <- event:
+	default:
+	}
+}
+
+// loop는 대기열의 이벤트를 구독자에게 전달합니다
+func (h *hookHub) loop() {
+	for event := range h.events {
+		h.mutex.Lock()
+		handlers := make([]func(KeyHookEvent), 0, len(h.subscribers))
+		for _, handler := range h.subscribers {
+			handlers = append(handlers, handler)
+		}
+		h.mutex.Unlock()
+
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+// globalKeyHook은 OS 전역 키보드 후크를 사용하는 KeyHook입니다
+type globalKeyHook struct {
+	id    int
+	mutex sync.Mutex
+}
+
+// NewGlobalKeyHook은 OS 전역 키보드 후크를 사용하는 KeyHook을 생성합니다
+func NewGlobalKeyHook() KeyHook {
+	return &globalKeyHook{}
+}
+
+// Start는 키 이벤트 구독을 시작합니다
+func (g *globalKeyHook) Start(handler func(KeyHookEvent)) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.id != 0 {
+		globalHookHub.unsubscribe(g.id)
+		g.id = 0
+	}
+
+	id, err := globalHookHub.subscribe(handler)
+	if err != nil {
+		return err
+	}
+	g.id = id
+	return nil
+}
+
+// Stop은 키 이벤트 구독을 중지합니다
+func (g *globalKeyHook) Stop() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.id != 0 {
+		globalHookHub.unsubscribe(g.id)
+		g.id = 0
+	}
+}
+
+// ManualKeyHook은 Emit으로 이벤트를 직접 주입하는 KeyHook입니다 (테스트용)
+type ManualKeyHook struct {
+	handler func(KeyHookEvent)
+	mutex   sync.Mutex
+}
+
+// Start는 이벤트 처리기를 등록합니다
+func (m *ManualKeyHook) Start(handler func(KeyHookEvent)) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.handler = handler
+	return nil
+}
+
+// Stop은 이벤트 처리기를 해제합니다
+func (m *ManualKeyHook) Stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.handler = nil
+}
+
+// Emit은 등록된 처리기에 이벤트를 전달합니다
+func (m *ManualKeyHook) Emit(event KeyHookEvent) {
+	m.mutex.Lock()
+	handler := m.handler
+	m.mutex.Unlock()
+
+	if handler != nil {
+		handler(event)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package automation
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// startPlatformHook은 전역 키보드 후크를 지원하지 않는 플랫폼에서 오류를 반환합니다
+func startPlatformHook(post func(KeyHookEvent)) error {
+	return fmt.Errorf("전역 키보드 후크는 %s에서 지원되지 않습니다", runtime.GOOS)
+}
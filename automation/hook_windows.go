@@ -0,0 +1,135 @@
+//go:build windows
+
+package automation
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	user32                  = windows.NewLazySystemDLL("user32.dll")
+	procSetWindowsHookExW   = user32.NewProc("SetWindowsHookExW")
+	procCallNextHookEx      = user32.NewProc("CallNextHookEx")
+	procUnhookWindowsHookEx = user32.NewProc("UnhookWindowsHookEx")
+	procGetMessageW         = user32.NewProc("GetMessageW")
+)
+
+const (
+	whKeyboardLL  = 13
+	wmKeyDown     = 0x0100
+	wmKeyUp       = 0x0101
+	wmSysKeyDown  = 0x0104
+	wmSysKeyUp    = 0x0105
+	llkhfInjected = 0x10
+)
+
+// kbdLLHookStruct는 WH_KEYBOARD_LL 콜백으로 전달되는 KBDLLHOOKSTRUCT입니다
+type kbdLLHookStruct struct {
+	VkCode      uint32
+	ScanCode    uint32
+	Flags       uint32
+	Time        uint32
+	DwExtraInfo uintptr
+}
+
+// winMsg는 GetMessageW에 사용하는 MSG 구조체입니다
+type winMsg struct {
+	Hwnd    uintptr
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	PtX     int32
+	PtY     int32
+}
+
+var (
+	hookHandle uintptr
+	hookPost   func(KeyHookEvent)
+)
+
+// startPlatformHook은 전용 스레드에 저수준 키보드 후크를 설치하고 메시지 루프를 실행합니다
+func startPlatformHook(post func(KeyHookEvent)) error {
+	result := make(chan error, 1)
+
+	go func() {
+		// 후크는 설치한 스레드의 메시지 루프에서만 호출되므로 스레드 고정
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		hookPost = post
+		callback := windows.NewCallback(lowLevelKeyboardProc)
+		handle, _, err := procSetWindowsHookExW.Call(whKeyboardLL, callback, 0, 0)
+		if handle == 0 {
+			result <- fmt.Errorf("키보드 후크 설치 실패: %v", err)
+			return
+		}
+		hookHandle = handle
+		result <- nil
+
+		var msg winMsg
+		for {
+			ret, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+			if int32(ret) <= 0 {
+				break
+			}
+		}
+		procUnhookWindowsHookEx.Call(handle)
+	}()
+
+	return <-result
+}
+
+// lowLevelKeyboardProc은 OS가 호출하는 후크 콜백입니다 (빠르게 반환해야 함)
+func lowLevelKeyboardProc(nCode int, wParam uintptr, lParam *kbdLLHookStruct) uintptr {
+	if nCode >= 0 && lParam != nil {
+		down := wParam == wmKeyDown || wParam == wmSysKeyDown
+		up := wParam == wmKeyUp || wParam == wmSysKeyUp
+		if key := virtualKeyName(lParam.VkCode); key != "" && (down || up) {
+			hookPost(KeyHookEvent{
+				Key:      key,
+				Down:     down,
+				Injected: lParam.Flags&llkhfInjected != 0,
+				Time:     time.Now(),
+			})
+		}
+	}
+
+	ret, _, _ := procCallNextHookEx.Call(hookHandle, uintptr(nCode), wParam, uintptr(unsafe.Pointer(lParam)))
+	return ret
+}
+
+// 가상 키 코드 → robotgo 키 이름
+var virtualKeyNames = map[uint32]string{
+	0x08: "backspace", 0x09: "tab", 0x0D: "enter", 0x14: "capslock",
+	0x1B: "esc", 0x20: "space", 0x21: "pageup", 0x22: "pagedown", 0x23: "end",
+	0x24: "home", 0x25: "left", 0x26: "up", 0x27: "right", 0x28: "down",
+	0x2C: "printscreen", 0x2D: "insert", 0x2E: "delete",
+	0x5B: "lcmd", 0x5C: "rcmd", 0x5D: "menu",
+	0x6A: "num*", 0x6B: "num+", 0x6D: "num-", 0x6E: "num.", 0x6F: "num/",
+	0x90: "num_lock",
+	0x10: "shift", 0x11: "ctrl", 0x12: "alt",
+	0xA0: "lshift", 0xA1: "rshift", 0xA2: "lctrl", 0xA3: "rctrl", 0xA4: "lalt", 0xA5: "ralt",
+	0xBA: ";", 0xBB: "=", 0xBC: ",", 0xBD: "-", 0xBE: ".", 0xBF: "/", 0xC0: "`",
+	0xDB: "[", 0xDC: "\\", 0xDD: "]", 0xDE: "'",
+}
+
+// virtualKeyName은 가상 키 코드를 robotgo 키 이름으로 변환합니다
+func virtualKeyName(vk uint32) string {
+	switch {
+	case vk >= 0x30 && vk <= 0x39: // 0-9
+		return string(rune('0' + vk - 0x30))
+	case vk >= 0x41 && vk <= 0x5A: // A-Z
+		return string(rune('a' + vk - 0x41))
+	case vk >= 0x60 && vk <= 0x69: // 숫자 패드 0-9
+		return fmt.Sprintf("num%d", vk-0x60)
+	case vk >= 0x70 && vk <= 0x87: // F1-F24
+		return fmt.Sprintf("f%d", vk-0x70+1)
+	}
+	return virtualKeyNames[vk]
+}
@@ -0,0 +1,234 @@
+package automation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// HotkeyAction은 전역 단축키로 실행할 동작입니다
+type HotkeyAction string
+
+// 단축키 동작 상수
+const (
+	HotkeyStart HotkeyAction = "start"
+	HotkeyStop  HotkeyAction = "stop"
+	HotkeyPause HotkeyAction = "pause"
+	HotkeyAbort HotkeyAction = "abort"
+)
+
+// HotkeyActions는 지원하는 모든 단축키 동작입니다
+var HotkeyActions = []HotkeyAction{HotkeyStart, HotkeyStop, HotkeyPause, HotkeyAbort}
+
+// DefaultHotkeys는 기본 단축키 설정을 반환합니다
+func DefaultHotkeys() map[string]string {
+	return map[string]string{
+		string(HotkeyStart): "f9",
+		string(HotkeyStop):  "f10",
+		string(HotkeyPause): "f11",
+		string(HotkeyAbort): "ctrl+shift+f12",
+	}
+}
+
+// 수정 키 이름 (좌/우 구분 없이 하나로 취급)
+var modifierNames = map[string]string{
+	"ctrl": "ctrl", "lctrl": "ctrl", "rctrl": "ctrl", "control": "ctrl",
+	"shift": "shift", "lshift": "shift", "rshift": "shift",
+	"alt": "alt", "lalt": "alt", "ralt": "alt",
+	"cmd": "cmd", "lcmd": "cmd", "rcmd": "cmd",
+}
+
+// 문자열 표시 순서
+var modifierOrder = []string{"ctrl", "shift", "alt", "cmd"}
+
+// Hotkey는 수정 키 조합과 키 하나로 이루어진 단축키입니다
+type Hotkey struct {
+	Modifiers map[string]bool
+	Key       string
+}
+
+// ParseHotkey는 "ctrl+shift+f12" 형식의 단축키를 해석합니다
+func ParseHotkey(value string) (Hotkey, error) {
+	hotkey := Hotkey{Modifiers: make(map[string]bool)}
+	rest := strings.ToLower(strings.TrimSpace(value))
+
+	// 앞에서부터 "수정키+"를 떼어냄 (num+ 같은 키 이름을 위해 split 대신 사용)
+	for {
+		idx := strings.Index(rest, "+")
+		if idx <= 0 {
+			break
+		}
+		modifier, ok := modifierNames[rest[:idx]]
+		if !ok {
+			break
+		}
+		hotkey.Modifiers[modifier] = true
+		rest = rest[idx+1:]
+	}
+
+	if !IsValidKey(rest) {
+		return Hotkey{}, fmt.Errorf("잘못된 단축키 '%s'", value)
+	}
+	if _, ok := modifierNames[rest]; ok {
+		return Hotkey{}, fmt.Errorf("잘못된 단축키 '%s' (수정 키만으로는 사용할 수 없습니다)", value)
+	}
+
+	hotkey.Key = rest
+	return hotkey, nil
+}
+
+// String은 단축키를 "ctrl+shift+f12" 형식으로 반환합니다
+func (h Hotkey) String() string {
+	var parts []string
+	for _, modifier := range modifierOrder {
+		if h.Modifiers[modifier] {
+			parts = append(parts, modifier)
+		}
+	}
+	return strings.Join(append(parts, h.Key), "+")
+}
+
+// matches는 눌린 키와 현재 눌려 있는 수정 키가 단축키와 정확히 일치하는지 확인합니다
+func (h Hotkey) matches(key string, modifiers map[string]bool) bool {
+	if h.Key != key {
+		return false
+	}
+	for _, modifier := range modifierOrder {
+		if h.Modifiers[modifier] != modifiers[modifier] {
+			return false
+		}
+	}
+	return true
+}
+
+// HotkeyManager는 전역 키보드 후크로 단축키를 감지해 동작을 실행합니다
+type HotkeyManager struct {
+	Hook KeyHook
+	// OnAction은 단축키가 눌렸을 때 호출됩니다 (후크 이벤트 처리 중이므로 빠르게 반환해야 함)
+	OnAction  func(action HotkeyAction, binding string)
+	bindings  map[HotkeyAction]Hotkey
+	modifiers map[string]bool
+	pressed   map[string]bool
+	mutex     sync.Mutex
+}
+
+// NewHotkeyManager는 지정된 후크를 사용하는 단축키 관리자를 생성합니다
+func NewHotkeyManager(hook KeyHook) *HotkeyManager {
+	return &HotkeyManager{
+		Hook:      hook,
+		bindings:  make(map[HotkeyAction]Hotkey),
+		modifiers: make(map[string]bool),
+		pressed:   make(map[string]bool),
+	}
+}
+
+// SetBindings는 동작별 단축키를 설정합니다
+// 빈 값은 해당 동작의 단축키를 사용하지 않음을 뜻하며, 잘못된 설정이 있으면 아무것도 바꾸지 않습니다
+func (m *HotkeyManager) SetBindings(values map[string]string) error {
+	bindings := make(map[HotkeyAction]Hotkey)
+	used := make(map[string]HotkeyAction)
+
+	for name, value := range values {
+		action := HotkeyAction(name)
+		if !isHotkeyAction(action) {
+			return fmt.Errorf("알 수 없는 단축키 동작 '%s'", name)
+		}
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		hotkey, err := ParseHotkey(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		if other, exists := used[hotkey.String()]; exists {
+			return fmt.Errorf("단축키 '%s'가 %s와 %s에 중복 지정되었습니다", hotkey, other, action)
+		}
+		used[hotkey.String()] = action
+		bindings[action] = hotkey
+	}
+
+	m.mutex.Lock()
+	m.bindings = bindings
+	m.mutex.Unlock()
+	return nil
+}
+
+// Bindings는 현재 단축키 설정을 반환합니다
+func (m *HotkeyManager) Bindings() map[string]string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	result := make(map[string]string)
+	for _, action := range HotkeyActions {
+		result[string(action)] = ""
+		if hotkey, ok := m.bindings[action]; ok {
+			result[string(action)] = hotkey.String()
+		}
+	}
+	return result
+}
+
+// Start는 단축키 감지를 시작합니다
+func (m *HotkeyManager) Start() error {
+	return m.Hook.Start(m.handle)
+}
+
+// Stop은 단축키 감지를 중지합니다
+func (m *HotkeyManager) Stop() {
+	m.Hook.Stop()
+}
+
+// handle은 후크 이벤트에서 단축키를 찾아 동작을 실행합니다
+func (m *HotkeyManager) handle(event KeyHookEvent) {
+	// 자동화가 보낸 입력으로는 단축키가 동작하지 않음
+	if event.Injected {
+		return
+	}
+
+	m.mutex.Lock()
+	if modifier, ok := modifierNames[event.Key]; ok {
+		m.modifiers[modifier] = event.Down
+		m.mutex.Unlock()
+		return
+	}
+
+	if !event.Down {
+		delete(m.pressed, event.Key)
+		m.mutex.Unlock()
+		return
+	}
+
+	// 키를 누르고 있을 때 반복되는 입력은 무시
+	if m.pressed[event.Key] {
+		m.mutex.Unlock()
+		return
+	}
+	m.pressed[event.Key] = true
+
+	// 같은 단축키는 한 동작에만 지정될 수 있으므로 처음 일치하는 동작만 실행
+	var matched HotkeyAction
+	var binding string
+	for action, hotkey := range m.bindings {
+		if hotkey.matches(event.Key, m.modifiers) {
+			matched, binding = action, hotkey.String()
+			break
+		}
+	}
+	handler := m.OnAction
+	m.mutex.Unlock()
+
+	if matched != "" && handler != nil {
+		handler(matched, binding)
+	}
+}
+
+// isHotkeyAction은 지원하는 단축키 동작인지 확인합니다
+func isHotkeyAction(action HotkeyAction) bool {
+	for _, known := range HotkeyActions {
+		if action == known {
+			return true
+		}
+	}
+	return false
+}
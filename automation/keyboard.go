@@ -1,67 +1,156 @@
 package automation
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
-	"github.com/go-vgo/robotgo"
 )
 
+// 각 키 입력 전에 두는 지연 (녹화기는 녹화된 간격에서 이 값을 뺌)
+const keyPressDelay = 300 * time.Millisecond
+
 // KeyboardManager는 키보드 자동화 기능을 관리합니다
+// 한 번에 하나의 실행(Run)만 허용합니다
 type KeyboardManager struct {
-	Running    bool
-	Mutex      sync.Mutex
-	StopReason string
+	Mutex  sync.Mutex
+	Driver InputDriver
+	Clock  Clock
+	Screen ScreenCapturer // 화면 대상 인식에 사용 (nil이면 wait until/abort if/read가 오류로 중지)
+	OCR    OCREngine      // 텍스트 대상 인식에 사용
+
+	// OnError는 실행이 오류(키 입력 실패 등)로 끝나면 호출됩니다
+	// 실행 고루틴에서 실행이 완전히 정리된 뒤 호출되므로 run.Result를 사용할 수 있습니다
+	OnError func(run *Run, err error)
+
+	current *Run
 }
 
-// NewKeyboardManager는 새로운 키보드 관리자를 생성합니다
+// NewKeyboardManager는 기본 입력 드라이버를 사용하는 키보드 관리자를 생성합니다
 func NewKeyboardManager() *KeyboardManager {
+	km := NewKeyboardManagerWithDriver(defaultInputDriver(), realClock{})
+	km.Screen = defaultScreenCapturer()
+	km.OCR = defaultOCREngine()
+	return km
+}
+
+// NewKeyboardManagerWithDriver는 지정된 입력 드라이버와 시간 소스를 사용하는 키보드 관리자를 생성합니다
+func NewKeyboardManagerWithDriver(driver InputDriver, clock Clock) *KeyboardManager {
+	if clock == nil {
+		clock = realClock{}
+	}
 	return &KeyboardManager{
-		Running:    false,
-		Mutex:      sync.Mutex{},
-		StopReason: "",
+		Mutex:  sync.Mutex{},
+		Driver: driver,
+		Clock:  clock,
+	}
+}
+
+// Start는 시퀀스 실행을 시작합니다
+// 이미 실행 중인 작업이 있으면 ErrAlreadyRunning을 반환합니다
+func (km *KeyboardManager) Start(sequence KeySequence, options RunOptions) (*Run, error) {
+	km.Mutex.Lock()
+	defer km.Mutex.Unlock()
+
+	if km.current != nil {
+		return nil, ErrAlreadyRunning
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	now := km.Clock.Now()
+	run := &Run{
+		Sequence:    sequence,
+		km:          km,
+		ctx:         ctx,
+		cancel:      cancel,
+		timeout:     options.Timeout,
+		started:     now,
+		done:        make(chan struct{}),
+		pauseCh:     make(chan struct{}),
+		activeSince: now,
+
+		maxIterations: options.MaxIterations,
+		onIteration:   options.OnIteration,
 	}
+	km.current = run
+
+	go func() {
+		run.exec()
+		if result := run.Result(); result.Status == RunStoppedByError && km.OnError != nil {
+			km.OnError(run, result.Err)
+		}
+	}()
+	return run, nil
+}
+
+// Current는 실행 중인 작업을 반환합니다 (없으면 nil)
+func (km *KeyboardManager) Current() *Run {
+	km.Mutex.Lock()
+	defer km.Mutex.Unlock()
+	return km.current
 }
 
 // SendKeyPress는 키 입력을 시뮬레이션합니다
 func (km *KeyboardManager) SendKeyPress(key string) error {
 	// 키 입력 전에 짧은 지연 추가
-	time.Sleep(300 * time.Millisecond)
-
-	// robotgo를 사용하여 키 입력
-	func() {
-		defer func() {
-			if r := recover(); r != nil {
-				km.StopOperation(fmt.Sprintf("키 입력 중 오류 발생: %v", r))
-			}
-		}()
-		robotgo.KeyTap(key)
-	}()
+	km.sleep(keyPressDelay)
+
+	return km.tapKey(key)
+}
+
+// tapKey는 지연 없이 키 입력을 보냅니다
+func (km *KeyboardManager) tapKey(key string) error {
+	// 입력 드라이버를 사용하여 키 입력
+	if err := km.Driver.KeyTap(key); err != nil {
+		return fmt.Errorf("키 입력 중 오류 발생: %v", err)
+	}
 
 	return nil
 }
 
-// StopOperation은 작업을 중지하고 이유를 기록합니다
-func (km *KeyboardManager) StopOperation(reason string) {
-	km.Mutex.Lock()
-	defer km.Mutex.Unlock()
+// sleep은 관리자의 시간 소스를 사용해 대기합니다
+func (km *KeyboardManager) sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-km.Clock.After(d)
+}
 
-	if km.Running {
-		km.Running = false
-		km.StopReason = reason
+// StopOperation은 실행 중인 작업을 중지하고 이유를 기록합니다
+// 대기 중이던 작업도 즉시 끝나며, 결과는 Run.Result로 확인할 수 있습니다
+func (km *KeyboardManager) StopOperation(reason string) {
+	if run := km.Current(); run != nil {
+		run.Stop(reason)
 	}
 }
 
-// IsRunning은 키보드 관리자가 실행 중인지 확인합니다
+// IsRunning은 실행 중인 작업이 있는지 확인합니다 (일시정지 상태 포함)
 func (km *KeyboardManager) IsRunning() bool {
-	km.Mutex.Lock()
-	defer km.Mutex.Unlock()
-	return km.Running
+	return km.Current() != nil
 }
 
-// SetRunning은 실행 상태를 설정합니다
-func (km *KeyboardManager) SetRunning(running bool) {
-	km.Mutex.Lock()
-	defer km.Mutex.Unlock()
-	km.Running = running
-}
\ No newline at end of file
+// Pause는 실행 중인 작업을 현재 단계에서 일시정지합니다
+// 실행 중이 아니거나 이미 일시정지 상태면 false를 반환합니다
+func (km *KeyboardManager) Pause() bool {
+	if run := km.Current(); run != nil {
+		return run.Pause()
+	}
+	return false
+}
+
+// Resume은 일시정지된 작업을 멈춘 단계부터 이어서 실행합니다
+// 일시정지 상태가 아니면 false를 반환합니다
+func (km *KeyboardManager) Resume() bool {
+	if run := km.Current(); run != nil {
+		return run.Resume()
+	}
+	return false
+}
+
+// IsPaused는 일시정지 상태인지 확인합니다
+func (km *KeyboardManager) IsPaused() bool {
+	if run := km.Current(); run != nil {
+		return run.IsPaused()
+	}
+	return false
+}
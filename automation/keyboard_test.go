@@ -0,0 +1,94 @@
+//go:build headless
+
+package automation
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestKeyboard는 기록 드라이버와 가상 시계를 사용하는 키보드 관리자를 만듭니다
+func newTestKeyboard() (*KeyboardManager, *RecordingDriver, *ManualClock, time.Time) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	driver := NewRecordingDriver(clock)
+	return NewKeyboardManagerWithDriver(driver, clock), driver, clock, start
+}
+
+func TestRunKeySequenceTiming(t *testing.T) {
+	km, driver, _, start := newTestKeyboard()
+	seq := KeySequence{
+		ID:         "test",
+		Name:       "테스트",
+		KeyPresses: []string{"a", "b", "c"},
+		Delays:     []time.Duration{time.Second, 0},
+	}
+
+	result, err := km.RunKeySequence(seq, RunOptions{MaxIterations: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != RunCompleted || result.Iterations != 2 || result.KeysSent != 6 {
+		t.Fatalf("결과가 다릅니다: %+v", result)
+	}
+
+	// 시작 직후 300ms 쉬고, 키마다 keyPressDelay 뒤에 입력하며, 한 바퀴가 끝나면 1초 쉰 뒤 다시 시작
+	want := []struct {
+		key string
+		at  time.Duration
+	}{
+		{"a", 600 * time.Millisecond},
+		{"b", 1900 * time.Millisecond},
+		{"c", 2200 * time.Millisecond},
+		{"a", 3500 * time.Millisecond},
+		{"b", 4800 * time.Millisecond},
+		{"c", 5100 * time.Millisecond},
+	}
+	events := driver.Events()
+	if len(events) != len(want) {
+		t.Fatalf("이벤트 %d개, 기대 %d개: %+v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if event.Type != EventKeyTap || event.Key != want[i].key || event.Time.Sub(start) != want[i].at {
+			t.Errorf("%d번째 이벤트: %s %s @%v, 기대 %s @%v", i, event.Type, event.Key, event.Time.Sub(start), want[i].key, want[i].at)
+		}
+	}
+	if result.Active != 6100*time.Millisecond {
+		t.Errorf("실행 시간 %v, 기대 6.1s", result.Active)
+	}
+}
+
+func TestRunKeySequenceTimeout(t *testing.T) {
+	km, driver, _, _ := newTestKeyboard()
+	seq := KeySequence{ID: "test", KeyPresses: []string{"a", "b"}, Delays: []time.Duration{10 * time.Second}}
+
+	result, err := km.RunKeySequence(seq, RunOptions{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != RunTimedOut {
+		t.Fatalf("상태 %s, 기대 %s", result.Status, RunTimedOut)
+	}
+	if keys := driver.Keys(); !slices.Equal(keys, []string{"a"}) {
+		t.Fatalf("시간 제한 뒤에 키를 보냈습니다: %v", keys)
+	}
+}
+
+func TestRunKeySequenceKeyError(t *testing.T) {
+	km, driver, _, _ := newTestKeyboard()
+	driver.FailOn["b"] = errors.New("입력 실패")
+	seq := KeySequence{ID: "test", KeyPresses: []string{"a", "b", "c"}}
+
+	result, err := km.RunKeySequence(seq, RunOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != RunStoppedByError || result.Err == nil {
+		t.Fatalf("오류로 중지되어야 합니다: %+v", result)
+	}
+	if keys := driver.Keys(); !slices.Equal(keys, []string{"a"}) {
+		t.Fatalf("실패한 뒤에 키를 보냈습니다: %v", keys)
+	}
+}
@@ -0,0 +1,41 @@
+package automation
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// namedKeys는 robotgo가 인식하는 이름 있는 키 목록입니다
+var namedKeys = map[string]bool{
+	"backspace": true, "delete": true, "enter": true, "tab": true,
+	"esc": true, "escape": true, "space": true, "insert": true, "menu": true,
+	"up": true, "down": true, "left": true, "right": true,
+	"home": true, "end": true, "pageup": true, "pagedown": true,
+	"cmd": true, "lcmd": true, "rcmd": true,
+	"alt": true, "lalt": true, "ralt": true,
+	"ctrl": true, "lctrl": true, "rctrl": true, "control": true,
+	"shift": true, "lshift": true, "rshift": true,
+	"capslock": true, "print": true, "printscreen": true,
+	"f1": true, "f2": true, "f3": true, "f4": true, "f5": true, "f6": true,
+	"f7": true, "f8": true, "f9": true, "f10": true, "f11": true, "f12": true,
+	"f13": true, "f14": true, "f15": true, "f16": true, "f17": true, "f18": true,
+	"f19": true, "f20": true, "f21": true, "f22": true, "f23": true, "f24": true,
+	"num0": true, "num1": true, "num2": true, "num3": true, "num4": true,
+	"num5": true, "num6": true, "num7": true, "num8": true, "num9": true,
+	"num_lock": true, "num.": true, "num+": true, "num-": true, "num*": true,
+	"num/": true, "num_clear": true, "num_enter": true, "num_equal": true,
+}
+
+// IsValidKey는 키 이름이 입력 가능한 키인지 확인합니다
+func IsValidKey(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	// 한 글자 키 (a, 5, / 등)
+	if utf8.RuneCountInString(key) == 1 {
+		return strings.TrimSpace(key) != ""
+	}
+
+	return namedKeys[strings.ToLower(key)]
+}
@@ -0,0 +1,369 @@
+package automation
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 기본 제공 시퀀스 파일 (앱 데이터 디렉토리에 복사되어 사용자가 수정할 수 있음)
+//
+//go:embed sequences/*.json
+var defaultSequenceFiles embed.FS
+
+// 시퀀스 ID 형식 (파일 이름과 /api/start의 mode 값으로 사용됨)
+var sequenceIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// 한 키 뒤에 둘 수 있는 최대 대기 시간
+const maxSequenceDelay = time.Hour
+
+// sequenceFile은 시퀀스 파일(JSON/YAML)의 구조체입니다
+type sequenceFile struct {
+	ID       string   `json:"id" yaml:"id"`
+	Name     string   `json:"name" yaml:"name"`
+	StartKey string   `json:"start_key" yaml:"start_key"`
+	Keys     []string `json:"keys" yaml:"keys"`
+	Delays   []string `json:"delays" yaml:"delays"`
+	Script   string   `json:"script,omitempty" yaml:"script"`
+
+	Targets map[string]targetFile `json:"targets,omitempty" yaml:"targets"`
+}
+
+// SequenceLibrary는 파일에서 불러온 키 시퀀스를 ID별로 관리합니다
+type SequenceLibrary struct {
+	Dir       string
+	sequences map[string]KeySequence
+	files     map[string]string // ID별 사용자 디렉토리의 원본 파일 경로 (기본 제공 시퀀스는 없음)
+	mutex     sync.RWMutex
+}
+
+// NewSequenceLibrary는 지정된 디렉토리를 사용하는 시퀀스 라이브러리를 생성합니다
+func NewSequenceLibrary(dir string) *SequenceLibrary {
+	return &SequenceLibrary{
+		Dir:       dir,
+		sequences: make(map[string]KeySequence),
+		files:     make(map[string]string),
+	}
+}
+
+// InstallDefaults는 기본 제공 시퀀스 파일 중 디렉토리에 없는 파일을 복사합니다
+func (lib *SequenceLibrary) InstallDefaults() error {
+	if err := os.MkdirAll(lib.Dir, 0755); err != nil {
+		return fmt.Errorf("시퀀스 디렉토리 생성 실패: %v", err)
+	}
+
+	entries, err := defaultSequenceFiles.ReadDir("sequences")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		target := filepath.Join(lib.Dir, entry.Name())
+		if _, err := os.Stat(target); err == nil {
+			// 사용자가 수정했을 수 있으므로 덮어쓰지 않음
+			continue
+		}
+
+		data, err := defaultSequenceFiles.ReadFile("sequences/" + entry.Name())
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return fmt.Errorf("기본 시퀀스 파일 저장 실패 (%s): %v", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Load는 기본 제공 시퀀스와 디렉토리의 시퀀스 파일을 불러옵니다
+// 잘못된 파일은 건너뛰고, 해당 오류를 모두 모아 반환합니다
+func (lib *SequenceLibrary) Load() error {
+	sequences := make(map[string]KeySequence)
+
+	// 기본 제공 시퀀스를 먼저 등록 (파일이 없거나 잘못되어도 기본 모드는 동작하도록)
+	entries, err := defaultSequenceFiles.ReadDir("sequences")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		data, err := defaultSequenceFiles.ReadFile("sequences/" + entry.Name())
+		if err != nil {
+			return err
+		}
+		seq, err := ParseSequence(data, entry.Name())
+		if err != nil {
+			return err
+		}
+		sequences[seq.ID] = seq
+	}
+
+	// 사용자 디렉토리의 파일로 덮어쓰기
+	var errs []error
+	files, err := os.ReadDir(lib.Dir)
+	if err != nil && !os.IsNotExist(err) {
+		errs = append(errs, fmt.Errorf("시퀀스 디렉토리 읽기 실패: %v", err))
+	}
+
+	loaded := make(map[string]string)
+	paths := make(map[string]string)
+	for _, file := range files {
+		if file.IsDir() || !isSequenceFile(file.Name()) {
+			continue
+		}
+
+		path := filepath.Join(lib.Dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", file.Name(), err))
+			continue
+		}
+
+		seq, err := ParseSequence(data, path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", file.Name(), err))
+			continue
+		}
+
+		if other, exists := loaded[seq.ID]; exists {
+			errs = append(errs, fmt.Errorf("%s: ID '%s'가 %s와 중복됩니다", file.Name(), seq.ID, other))
+			continue
+		}
+
+		loaded[seq.ID] = file.Name()
+		paths[seq.ID] = path
+		sequences[seq.ID] = seq
+	}
+
+	lib.mutex.Lock()
+	lib.sequences = sequences
+	lib.files = paths
+	lib.mutex.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// Get은 ID에 해당하는 시퀀스를 반환합니다
+func (lib *SequenceLibrary) Get(id string) (KeySequence, bool) {
+	lib.mutex.RLock()
+	defer lib.mutex.RUnlock()
+	seq, ok := lib.sequences[id]
+	return seq, ok
+}
+
+// List는 등록된 모든 시퀀스를 ID 순으로 반환합니다
+func (lib *SequenceLibrary) List() []KeySequence {
+	lib.mutex.RLock()
+	defer lib.mutex.RUnlock()
+
+	result := make([]KeySequence, 0, len(lib.sequences))
+	for _, seq := range lib.sequences {
+		result = append(result, seq)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// Save는 키 목록으로 정의된 시퀀스를 <ID>.json 파일로 저장하고 라이브러리에 등록합니다
+// overwrite가 false이면 이미 있는 ID는 저장하지 않습니다
+// 같은 ID를 다른 이름의 파일(foo.yaml 등)에서 불러왔다면 다음 Load에서 ID가 중복되지 않도록 그 파일을 지웁니다
+// 같은 ID의 스크립트 시퀀스는 덮어쓰거나 지우지 않습니다
+func (lib *SequenceLibrary) Save(seq KeySequence, overwrite bool) error {
+	if seq.Script != nil {
+		return fmt.Errorf("스크립트 시퀀스는 파일을 직접 편집해야 합니다")
+	}
+	if err := seq.Validate(); err != nil {
+		return err
+	}
+
+	lib.mutex.Lock()
+	defer lib.mutex.Unlock()
+
+	if existing, exists := lib.sequences[seq.ID]; exists {
+		if !overwrite {
+			return fmt.Errorf("ID '%s' 시퀀스가 이미 있습니다", seq.ID)
+		}
+		if existing.Script != nil {
+			return fmt.Errorf("ID '%s' 시퀀스는 스크립트 시퀀스이므로 덮어쓸 수 없습니다 (파일을 직접 편집하세요)", seq.ID)
+		}
+	}
+
+	file := sequenceFile{
+		ID:       seq.ID,
+		Name:     seq.Name,
+		StartKey: seq.StartKey,
+		Keys:     seq.KeyPresses,
+	}
+	for _, delay := range seq.Delays {
+		file.Delays = append(file.Delays, delay.String())
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(lib.Dir, 0755); err != nil {
+		return fmt.Errorf("시퀀스 디렉토리 생성 실패: %v", err)
+	}
+	path := filepath.Join(lib.Dir, seq.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("시퀀스 파일 저장 실패: %v", err)
+	}
+	if old, ok := lib.files[seq.ID]; ok && old != path {
+		if err := os.Remove(old); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("이전 시퀀스 파일 삭제 실패 (%s): %v", filepath.Base(old), err)
+		}
+	}
+
+	if lib.files == nil {
+		lib.files = make(map[string]string)
+	}
+	lib.files[seq.ID] = path
+	lib.sequences[seq.ID] = seq
+	return nil
+}
+
+// ParseSequence는 시퀀스 파일 내용을 해석하고 검증합니다
+// 파일 확장자로 형식(JSON/YAML)을 판단하며, ID가 없으면 파일 이름을 ID로 사용합니다
+// 화면 대상의 템플릿 이미지 경로는 시퀀스 파일이 있는 디렉토리 기준입니다
+func ParseSequence(data []byte, fileName string) (KeySequence, error) {
+	var file sequenceFile
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return KeySequence{}, fmt.Errorf("YAML 파싱 실패: %v", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &file); err != nil {
+			return KeySequence{}, fmt.Errorf("JSON 파싱 실패: %v", err)
+		}
+	}
+
+	if file.ID == "" {
+		file.ID = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+	if file.Name == "" {
+		file.Name = file.ID
+	}
+
+	seq := KeySequence{
+		ID:         file.ID,
+		Name:       file.Name,
+		StartKey:   file.StartKey,
+		KeyPresses: file.Keys,
+	}
+
+	if file.Script != "" {
+		if len(file.Keys) > 0 || len(file.Delays) > 0 {
+			return KeySequence{}, fmt.Errorf("script와 keys/delays는 함께 사용할 수 없습니다")
+		}
+		script, err := ParseScript(file.Script)
+		if err != nil {
+			return KeySequence{}, fmt.Errorf("스크립트 오류:\n%v", err)
+		}
+		seq.Script = script
+	}
+
+	if len(file.Targets) > 0 {
+		if seq.Script == nil {
+			return KeySequence{}, fmt.Errorf("targets는 script와 함께 사용해야 합니다")
+		}
+		seq.Targets = make(map[string]*Target, len(file.Targets))
+		for name, def := range file.Targets {
+			target, err := parseTarget(name, def, filepath.Dir(fileName))
+			if err != nil {
+				return KeySequence{}, err
+			}
+			seq.Targets[name] = target
+		}
+	}
+
+	for i, value := range file.Delays {
+		delay, err := time.ParseDuration(value)
+		if err != nil {
+			return KeySequence{}, fmt.Errorf("%d번째 대기 시간 '%s'를 해석할 수 없습니다", i+1, value)
+		}
+		seq.Delays = append(seq.Delays, delay)
+	}
+
+	if err := seq.Validate(); err != nil {
+		return KeySequence{}, err
+	}
+
+	return seq, nil
+}
+
+// Validate는 시퀀스 정의가 실행 가능한지 검증합니다
+func (seq KeySequence) Validate() error {
+	if !sequenceIDPattern.MatchString(seq.ID) {
+		return fmt.Errorf("잘못된 ID '%s' (영문 소문자, 숫자, -, _만 사용 가능)", seq.ID)
+	}
+
+	if seq.StartKey != "" && !IsValidKey(seq.StartKey) {
+		return fmt.Errorf("알 수 없는 시작 키 '%s'", seq.StartKey)
+	}
+
+	// 스크립트는 파싱 단계에서 이미 검증되었으므로 참조하는 화면 대상만 확인
+	if seq.Script != nil {
+		for _, name := range seq.Script.Targets {
+			if _, ok := seq.Targets[name]; !ok {
+				return fmt.Errorf("스크립트에서 사용한 대상 '%s'가 targets에 없습니다", name)
+			}
+		}
+		for _, name := range seq.Script.TextTargets {
+			if seq.Targets[name].Pattern == nil {
+				return fmt.Errorf("read에는 텍스트 대상만 사용할 수 있습니다: '%s'", name)
+			}
+		}
+		return nil
+	}
+
+	if len(seq.KeyPresses) == 0 {
+		return fmt.Errorf("키 목록 또는 스크립트가 필요합니다")
+	}
+
+	for i, key := range seq.KeyPresses {
+		if !IsValidKey(key) {
+			return fmt.Errorf("%d번째 키 '%s'를 알 수 없습니다", i+1, key)
+		}
+	}
+
+	// 대기 시간은 각 키 뒤에 적용되며, 마지막 키의 대기 시간은 생략할 수 있음
+	if len(seq.Delays) > len(seq.KeyPresses) {
+		return fmt.Errorf("대기 시간(%d개)이 키(%d개)보다 많습니다", len(seq.Delays), len(seq.KeyPresses))
+	}
+	if len(seq.Delays) < len(seq.KeyPresses)-1 {
+		return fmt.Errorf("대기 시간(%d개)이 부족합니다 (최소 %d개)", len(seq.Delays), len(seq.KeyPresses)-1)
+	}
+
+	for i, delay := range seq.Delays {
+		if delay < 0 || delay > maxSequenceDelay {
+			return fmt.Errorf("%d번째 대기 시간 %v가 허용 범위(0 ~ %v)를 벗어났습니다", i+1, delay, maxSequenceDelay)
+		}
+	}
+
+	return nil
+}
+
+// isSequenceFile은 시퀀스 파일 확장자인지 확인합니다
+func isSequenceFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+//go:build headless
+
+package automation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveReplacesFileWithOtherName(t *testing.T) {
+	dir := t.TempDir()
+	yaml := "id: farm\nname: 사냥\nkeys: [a, b]\ndelays: [1s]\n"
+	if err := os.WriteFile(filepath.Join(dir, "foo.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lib := NewSequenceLibrary(dir)
+	if err := lib.Load(); err != nil {
+		t.Fatal(err)
+	}
+	seq, ok := lib.Get("farm")
+	if !ok {
+		t.Fatal("farm 시퀀스를 불러오지 못했습니다")
+	}
+
+	seq.Delays = []time.Duration{2 * time.Second}
+	if err := lib.Save(seq, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "foo.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("이전 파일이 남아 있습니다: %v", err)
+	}
+
+	if err := lib.Load(); err != nil {
+		t.Fatalf("다시 불러오기 실패: %v", err)
+	}
+	seq, _ = lib.Get("farm")
+	if len(seq.Delays) != 1 || seq.Delays[0] != 2*time.Second {
+		t.Fatalf("저장한 내용이 아닙니다: %v", seq.Delays)
+	}
+}
+
+func TestSaveKeepsScriptSequence(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"farm.yaml": "id: farm\nname: 스크립트\nscript: |\n  tap a\n  wait 1s\n",
+		"boss.json": `{"id": "boss", "script": "tap b\nwait 1s\n"}`,
+	}
+	for name, data := range cases {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lib := NewSequenceLibrary(dir)
+	if err := lib.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range cases {
+		id := strings.TrimSuffix(name, filepath.Ext(name))
+		seq := KeySequence{ID: id, Name: "키 목록", KeyPresses: []string{"c"}}
+		if err := lib.Save(seq, true); err == nil || !strings.Contains(err.Error(), "스크립트 시퀀스") {
+			t.Errorf("%s: 스크립트 시퀀스를 덮어썼습니다: %v", id, err)
+		}
+
+		// 원본 파일이 그대로 남아 있어야 함
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != data {
+			t.Errorf("%s 파일이 바뀌었습니다: %q, %v", name, got, err)
+		}
+		if loaded, _ := lib.Get(id); loaded.Script == nil {
+			t.Errorf("%s: 라이브러리의 스크립트 시퀀스가 바뀌었습니다", id)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "farm.json")); !os.IsNotExist(err) {
+		t.Errorf("스크립트 시퀀스와 같은 ID의 파일이 만들어졌습니다: %v", err)
+	}
+}
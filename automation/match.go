@@ -0,0 +1,281 @@
+package automation
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 기본 인식 기준
+const (
+	defaultMatchThreshold = 0.95 // 템플릿 유사도
+	defaultColorTolerance = 10   // 색상 채널별 허용 오차
+)
+
+// Target은 화면에서 찾을 대상(템플릿 이미지, 특정 위치의 색상 또는 영역의 텍스트)입니다
+type Target struct {
+	Name string
+
+	// 템플릿 이미지 대상
+	Template  image.Image
+	Region    image.Rectangle // 검색/인식 영역 (화면 좌표, 비어 있으면 전체 화면)
+	Threshold float64         // 최소 유사도 (0~1)
+
+	// 색상 대상
+	Point     image.Point
+	Color     color.RGBA
+	Tolerance int
+
+	// 텍스트 대상 (Region을 OCR로 인식한 결과를 정규식과 비교)
+	Pattern  *regexp.Regexp
+	Language string
+	PageMode int
+	Scale    int
+}
+
+// targetFile은 시퀀스 파일의 대상 정의입니다
+type targetFile struct {
+	Image     string  `json:"image,omitempty" yaml:"image"`
+	Region    []int   `json:"region,omitempty" yaml:"region"`
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold"`
+	Pixel     []int   `json:"pixel,omitempty" yaml:"pixel"`
+	Color     string  `json:"color,omitempty" yaml:"color"`
+	Tolerance int     `json:"tolerance,omitempty" yaml:"tolerance"`
+	Text      string  `json:"text,omitempty" yaml:"text"`
+	Lang      string  `json:"lang,omitempty" yaml:"lang"`
+	PSM       int     `json:"psm,omitempty" yaml:"psm"`
+	Scale     int     `json:"scale,omitempty" yaml:"scale"`
+}
+
+// parseTarget은 대상 정의를 해석합니다. 템플릿 이미지 경로는 baseDir 기준입니다
+func parseTarget(name string, file targetFile, baseDir string) (*Target, error) {
+	if !isIdentifier(name) {
+		return nil, fmt.Errorf("잘못된 대상 이름 '%s'", name)
+	}
+
+	target := &Target{Name: name}
+
+	kinds := 0
+	for _, set := range []bool{file.Image != "", file.Color != "", file.Text != ""} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return nil, fmt.Errorf("대상 '%s': image, color, text 중 하나만 지정해야 합니다", name)
+	}
+
+	if len(file.Region) > 0 {
+		if len(file.Region) != 4 || file.Region[2] <= 0 || file.Region[3] <= 0 {
+			return nil, fmt.Errorf("대상 '%s': region은 [x, y, 너비, 높이] 형식이어야 합니다", name)
+		}
+		target.Region = image.Rect(file.Region[0], file.Region[1], file.Region[0]+file.Region[2], file.Region[1]+file.Region[3])
+	}
+
+	switch {
+	case file.Image != "":
+		path := file.Image
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		img, err := loadPNG(path)
+		if err != nil {
+			return nil, fmt.Errorf("대상 '%s': %v", name, err)
+		}
+		target.Template = img
+
+		if !target.Region.Empty() {
+			size := img.Bounds().Size()
+			if target.Region.Dx() < size.X || target.Region.Dy() < size.Y {
+				return nil, fmt.Errorf("대상 '%s': 검색 영역이 이미지(%dx%d)보다 작습니다", name, size.X, size.Y)
+			}
+		}
+
+		target.Threshold = file.Threshold
+		if target.Threshold == 0 {
+			target.Threshold = defaultMatchThreshold
+		}
+		if target.Threshold < 0 || target.Threshold > 1 {
+			return nil, fmt.Errorf("대상 '%s': threshold는 0~1 사이여야 합니다", name)
+		}
+
+	case file.Color != "":
+		if len(file.Pixel) != 2 {
+			return nil, fmt.Errorf("대상 '%s': 색상 대상에는 pixel [x, y]가 필요합니다", name)
+		}
+		c, err := parseHexColor(file.Color)
+		if err != nil {
+			return nil, fmt.Errorf("대상 '%s': %v", name, err)
+		}
+		target.Point = image.Pt(file.Pixel[0], file.Pixel[1])
+		target.Color = c
+
+		target.Tolerance = file.Tolerance
+		if target.Tolerance == 0 {
+			target.Tolerance = defaultColorTolerance
+		}
+		if target.Tolerance < 0 || target.Tolerance > 255 {
+			return nil, fmt.Errorf("대상 '%s': tolerance는 0~255 사이여야 합니다", name)
+		}
+
+	default:
+		if target.Region.Empty() {
+			return nil, fmt.Errorf("대상 '%s': 텍스트 대상에는 region이 필요합니다", name)
+		}
+		pattern, err := compileTextPattern(file.Text)
+		if err != nil {
+			return nil, fmt.Errorf("대상 '%s': %v", name, err)
+		}
+		target.Pattern = pattern
+
+		target.Language = file.Lang
+		if target.Language == "" {
+			target.Language = defaultOCRLanguage
+		}
+		target.PageMode = file.PSM
+		if target.PageMode == 0 {
+			target.PageMode = defaultOCRPageMode
+		}
+		target.Scale = file.Scale
+		if target.Scale == 0 {
+			target.Scale = defaultOCRScale
+		}
+		if target.PageMode < 0 || target.PageMode > 13 || target.Scale < 1 || target.Scale > 8 {
+			return nil, fmt.Errorf("대상 '%s': psm은 0~13, scale은 1~8 사이여야 합니다", name)
+		}
+	}
+
+	return target, nil
+}
+
+// parseHexColor는 "#rrggbb" 형식의 색상을 해석합니다
+func parseHexColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 6 || err != nil {
+		return color.RGBA{}, fmt.Errorf("잘못된 색상 '%s' (#rrggbb 형식)", value)
+	}
+	return color.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 255}, nil
+}
+
+// Visible은 대상이 현재 화면에 보이는지 확인합니다 (텍스트 대상은 인식 결과가 정규식과 일치하는지)
+func (t *Target) Visible(screen ScreenCapturer, engine OCREngine) (bool, error) {
+	if t.Pattern != nil {
+		text, err := t.ReadText(screen, engine)
+		if err != nil {
+			return false, err
+		}
+		return t.Pattern.MatchString(text), nil
+	}
+	_, visible, err := t.Locate(screen)
+	return visible, err
+}
+
+// Locate는 화면에서 이미지/색상 대상을 찾아 위치(화면 좌표)를 반환합니다
+func (t *Target) Locate(screen ScreenCapturer) (image.Point, bool, error) {
+	if t.Pattern != nil {
+		return image.Point{}, false, fmt.Errorf("텍스트 대상 '%s'는 위치를 찾을 수 없습니다", t.Name)
+	}
+	if t.Template == nil {
+		img, err := screen.Capture(image.Rectangle{Min: t.Point, Max: t.Point.Add(image.Pt(1, 1))})
+		if err != nil {
+			return image.Point{}, false, fmt.Errorf("화면 캡처 실패: %v", err)
+		}
+		b := img.Bounds()
+		return t.Point, colorMatches(img.At(b.Min.X, b.Min.Y), t.Color, t.Tolerance), nil
+	}
+
+	img, err := screen.Capture(t.Region)
+	if err != nil {
+		return image.Point{}, false, fmt.Errorf("화면 캡처 실패: %v", err)
+	}
+	pos, ok := matchTemplate(img, t.Template, t.Threshold)
+	return pos.Add(t.Region.Min), ok, nil
+}
+
+// colorMatches는 색상이 채널별 허용 오차 안에 있는지 확인합니다
+func colorMatches(c color.Color, want color.RGBA, tolerance int) bool {
+	got := color.RGBAModel.Convert(c).(color.RGBA)
+	return absDiff(got.R, want.R) <= tolerance &&
+		absDiff(got.G, want.G) <= tolerance &&
+		absDiff(got.B, want.B) <= tolerance
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+// matchTemplate은 이미지에서 템플릿과 가장 비슷한 위치를 찾습니다 (유사도가 threshold 미만이면 false)
+// 유사도는 1 - (RGB 차이 합 / 최대 차이)이며, 템플릿의 투명한 픽셀은 비교하지 않습니다
+// 반환되는 위치는 이미지 왼쪽 위 기준입니다
+func matchTemplate(img, tmpl image.Image, threshold float64) (image.Point, bool) {
+	src := toRGBA(img)
+	pat := toRGBA(tmpl)
+
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	tw, th := pat.Rect.Dx(), pat.Rect.Dy()
+	if tw == 0 || th == 0 || tw > sw || th > sh {
+		return image.Point{}, false
+	}
+
+	// 비교할 (불투명한) 픽셀 목록
+	var offsets []int
+	for y := 0; y < th; y++ {
+		for x := 0; x < tw; x++ {
+			if pat.Pix[y*pat.Stride+x*4+3] >= 128 {
+				offsets = append(offsets, y*pat.Stride+x*4)
+			}
+		}
+	}
+	if len(offsets) == 0 {
+		return image.Point{}, false
+	}
+
+	// 허용되는 차이 합 (넘으면 해당 위치는 바로 건너뜀, 더 비슷한 위치를 찾을수록 줄어듦)
+	budget := int((1 - threshold) * float64(len(offsets)*3*255))
+	best, found := image.Point{}, false
+
+	for y := 0; y+th <= sh; y++ {
+		for x := 0; x+tw <= sw; x++ {
+			base := y*src.Stride + x*4
+			diff := 0
+			for _, off := range offsets {
+				sy, sx := off/pat.Stride, off%pat.Stride
+				s := src.Pix[base+sy*src.Stride+sx:]
+				p := pat.Pix[off:]
+				diff += absDiff(s[0], p[0]) + absDiff(s[1], p[1]) + absDiff(s[2], p[2])
+				if diff > budget {
+					break
+				}
+			}
+			if diff <= budget {
+				best, found = image.Pt(x, y), true
+				if diff == 0 {
+					return best, true
+				}
+				budget = diff - 1
+			}
+		}
+	}
+
+	return best, found
+}
+
+// toRGBA는 이미지를 (0, 0)에서 시작하는 RGBA 이미지로 변환합니다
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Rect, img, b.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,91 @@
+//go:build headless
+
+package automation
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// testdata/needle.png는 haystack.png의 (41, 23) 위치를 잘라낸 16x12 이미지입니다
+var needlePos = image.Pt(41, 23)
+
+func loadTestImage(t *testing.T, name string) image.Image {
+	t.Helper()
+	img, err := loadPNG("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func TestMatchTemplateFound(t *testing.T) {
+	haystack := loadTestImage(t, "haystack.png")
+	needle := loadTestImage(t, "needle.png")
+
+	pos, ok := matchTemplate(haystack, needle, defaultMatchThreshold)
+	if !ok || pos != needlePos {
+		t.Fatalf("위치 %v (찾음 %v), 기대 %v", pos, ok, needlePos)
+	}
+
+	// 검색 영역을 지정하면 화면 좌표로 반환
+	target := &Target{Name: "needle", Template: needle, Region: image.Rect(30, 20, 70, 50), Threshold: defaultMatchThreshold}
+	pos, ok, err := target.Locate(NewImageCapturer(haystack))
+	if err != nil || !ok || pos != needlePos {
+		t.Fatalf("Locate %v (찾음 %v, 오류 %v), 기대 %v", pos, ok, err, needlePos)
+	}
+}
+
+func TestMatchTemplateThreshold(t *testing.T) {
+	haystack := loadTestImage(t, "haystack.png")
+
+	// 파란 채널을 조금 바꾼 템플릿은 유사도가 0.95 이상 0.99 미만
+	needle := toRGBA(loadTestImage(t, "needle.png"))
+	shifted := image.NewRGBA(needle.Rect)
+	for y := 0; y < needle.Rect.Dy(); y++ {
+		for x := 0; x < needle.Rect.Dx(); x++ {
+			c := needle.RGBAAt(x, y)
+			if c.B < 128 {
+				c.B += 25
+			} else {
+				c.B -= 25
+			}
+			shifted.SetRGBA(x, y, c)
+		}
+	}
+
+	if pos, ok := matchTemplate(haystack, shifted, 0.95); !ok || pos != needlePos {
+		t.Fatalf("기준 0.95: 위치 %v (찾음 %v), 기대 %v", pos, ok, needlePos)
+	}
+	if pos, ok := matchTemplate(haystack, shifted, 0.99); ok {
+		t.Fatalf("기준 0.99에서 %v 위치를 찾으면 안 됩니다", pos)
+	}
+
+	// 투명한 픽셀은 비교하지 않음
+	for x := 0; x < shifted.Rect.Dx(); x++ {
+		shifted.SetRGBA(x, 0, color.RGBA{})
+	}
+	if _, ok := matchTemplate(haystack, shifted, 0.95); !ok {
+		t.Fatal("투명한 픽셀이 비교에 포함되었습니다")
+	}
+}
+
+func TestMatchTemplateNotFound(t *testing.T) {
+	haystack := loadTestImage(t, "haystack.png")
+	missing := loadTestImage(t, "needle_missing.png")
+
+	if pos, ok := matchTemplate(haystack, missing, defaultMatchThreshold); ok {
+		t.Fatalf("없는 이미지를 %v 위치에서 찾았습니다", pos)
+	}
+
+	target := &Target{Name: "missing", Template: missing, Threshold: defaultMatchThreshold}
+	if visible, err := target.Visible(NewImageCapturer(haystack), nil); err != nil || visible {
+		t.Fatalf("Visible %v, 오류 %v", visible, err)
+	}
+
+	// 템플릿이 검색 영역보다 크면 찾지 못함
+	if _, ok := matchTemplate(missing, haystack, 0); ok {
+		t.Fatal("더 큰 템플릿을 찾았습니다")
+	}
+}
@@ -0,0 +1,152 @@
+package automation
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 기본 OCR 설정
+const (
+	defaultOCRLanguage = "kor+eng"
+	defaultOCRScale    = 2 // 작은 게임 글자를 인식하기 쉽도록 확대
+	defaultOCRPageMode = 7 // tesseract 페이지 분할 모드 (7: 한 줄)
+)
+
+// OCROptions는 텍스트 인식 옵션입니다
+type OCROptions struct {
+	Language string // tesseract 언어 (예: kor+eng)
+	PageMode int    // tesseract 페이지 분할 모드 (--psm)
+}
+
+// OCREngine은 이미지에서 텍스트를 인식하는 인터페이스입니다
+// 기본은 tesseract 명령을 실행하며, ocr 빌드 태그를 사용하면 gosseract 라이브러리를 사용합니다
+type OCREngine interface {
+	Recognize(img image.Image, options OCROptions) (string, error)
+}
+
+// ReadText는 텍스트 대상 영역을 캡처해 인식한 텍스트를 반환합니다
+func (t *Target) ReadText(screen ScreenCapturer, engine OCREngine) (string, error) {
+	if t.Pattern == nil {
+		return "", fmt.Errorf("'%s'는 텍스트 대상이 아닙니다", t.Name)
+	}
+	if engine == nil {
+		return "", fmt.Errorf("OCR을 사용할 수 없습니다")
+	}
+
+	img, err := screen.Capture(t.Region)
+	if err != nil {
+		return "", fmt.Errorf("화면 캡처 실패: %v", err)
+	}
+
+	text, err := engine.Recognize(prepareOCRImage(img, t.Scale), OCROptions{
+		Language: t.Language,
+		PageMode: t.PageMode,
+	})
+	if err != nil {
+		return "", fmt.Errorf("텍스트 인식 실패: %v", err)
+	}
+	return normalizeOCRText(text), nil
+}
+
+// TextValue는 인식한 텍스트를 스크립트 변수 값으로 변환합니다
+// 정규식에 캡처 그룹이 있으면 첫 그룹의 숫자(일치하지 않거나 숫자가 아니면 -1),
+// 캡처 그룹이 없으면 일치 여부(1 또는 0)를 반환합니다
+func (t *Target) TextValue(text string) int {
+	match := t.Pattern.FindStringSubmatch(text)
+	if t.Pattern.NumSubexp() == 0 {
+		if match != nil {
+			return 1
+		}
+		return 0
+	}
+	if match == nil {
+		return -1
+	}
+
+	// OCR 결과의 천 단위 구분 기호나 공백은 무시
+	digits := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, match[1])
+	value, err := strconv.Atoi(digits)
+	if err != nil {
+		return -1
+	}
+	return value
+}
+
+// OCRResult는 텍스트 대상을 이미지 파일로 확인한 결과입니다
+type OCRResult struct {
+	File    string `json:"file"`
+	Text    string `json:"text"`
+	Matched bool   `json:"matched"`
+	Value   int    `json:"value"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PreviewTextTarget은 실제 화면 대신 PNG 이미지(전체 화면 스크린샷)로 텍스트 대상을 확인합니다
+// 실행 중과 같은 영역 캡처, 전처리, 인식, 정규식 과정을 거치며 name은 결과에 표시할 이미지 이름입니다
+func PreviewTextTarget(target *Target, engine OCREngine, name string, data io.Reader) OCRResult {
+	result := OCRResult{File: name}
+
+	img, err := png.Decode(data)
+	if err != nil {
+		err = fmt.Errorf("PNG 파싱 실패 (%s): %v", name, err)
+	} else {
+		result.Text, err = target.ReadText(NewImageCapturer(img), engine)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Matched = target.Pattern.MatchString(result.Text)
+	result.Value = target.TextValue(result.Text)
+	return result
+}
+
+// prepareOCRImage는 인식률을 높이기 위해 이미지를 흑백으로 바꾸고 확대합니다
+func prepareOCRImage(img image.Image, scale int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	b := img.Bounds()
+	gray := image.NewGray(image.Rect(0, 0, b.Dx()*scale, b.Dy()*scale))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			c := color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray)
+			for dy := 0; dy < scale; dy++ {
+				row := (y*scale + dy) * gray.Stride
+				for dx := 0; dx < scale; dx++ {
+					gray.Pix[row+x*scale+dx] = c.Y
+				}
+			}
+		}
+	}
+	return gray
+}
+
+// normalizeOCRText는 인식 결과의 줄바꿈과 연속 공백을 공백 하나로 합칩니다
+func normalizeOCRText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// compileTextPattern은 텍스트 대상의 정규식을 컴파일합니다
+func compileTextPattern(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 정규식 '%s': %v", pattern, err)
+	}
+	if re.NumSubexp() > 1 {
+		return nil, fmt.Errorf("정규식에는 캡처 그룹을 하나만 사용할 수 있습니다: '%s'", pattern)
+	}
+	return re, nil
+}
@@ -0,0 +1,47 @@
+//go:build ocr
+
+package automation
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/otiai10/gosseract"
+)
+
+// GosseractEngine은 gosseract(tesseract 라이브러리)로 텍스트를 인식하는 OCREngine입니다
+// ocr 빌드 태그를 사용하면 tesseract 명령 대신 사용되며, 빌드 시 tesseract 개발 라이브러리가 필요합니다
+type GosseractEngine struct{}
+
+// defaultOCREngine은 빌드 환경의 기본 OCR 엔진을 반환합니다
+func defaultOCREngine() OCREngine {
+	return GosseractEngine{}
+}
+
+// Recognize는 이미지에서 텍스트를 인식합니다
+func (GosseractEngine) Recognize(img image.Image, options OCROptions) (string, error) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		return "", fmt.Errorf("이미지 변환 실패: %v", err)
+	}
+
+	client := gosseract.NewClient()
+	defer client.Close()
+
+	if options.Language != "" {
+		if err := client.SetLanguage(options.Language); err != nil {
+			return "", err
+		}
+	}
+	if options.PageMode > 0 {
+		if err := client.SetPageSegMode(gosseract.PageSegMode(options.PageMode)); err != nil {
+			return "", err
+		}
+	}
+	if err := client.SetImageFromBytes(data.Bytes()); err != nil {
+		return "", err
+	}
+	return client.Text()
+}
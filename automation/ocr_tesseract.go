@@ -0,0 +1,83 @@
+//go:build !ocr
+
+package automation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tesseract 실행 제한 시간
+const tesseractTimeout = 10 * time.Second
+
+// TesseractCLI는 설치된 tesseract 명령으로 텍스트를 인식하는 OCREngine입니다
+type TesseractCLI struct {
+	Path string // tesseract 실행 파일 경로 (비어 있으면 PATH와 기본 설치 위치에서 찾음)
+}
+
+// defaultOCREngine은 빌드 환경의 기본 OCR 엔진을 반환합니다
+func defaultOCREngine() OCREngine {
+	return &TesseractCLI{}
+}
+
+// Recognize는 이미지를 PNG로 tesseract 표준 입력에 전달해 텍스트를 인식합니다
+func (t *TesseractCLI) Recognize(img image.Image, options OCROptions) (string, error) {
+	path, err := t.executable()
+	if err != nil {
+		return "", err
+	}
+
+	var input bytes.Buffer
+	if err := png.Encode(&input, img); err != nil {
+		return "", fmt.Errorf("이미지 변환 실패: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tesseractTimeout)
+	defer cancel()
+
+	args := []string{"stdin", "stdout"}
+	if options.Language != "" {
+		args = append(args, "-l", options.Language)
+	}
+	if options.PageMode > 0 {
+		args = append(args, "--psm", strconv.Itoa(options.PageMode))
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = &input
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	hideConsoleWindow(cmd)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("tesseract 실행 실패: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
+
+// executable은 tesseract 실행 파일 경로를 찾습니다
+func (t *TesseractCLI) executable() (string, error) {
+	if t.Path != "" {
+		return t.Path, nil
+	}
+	if path, err := exec.LookPath("tesseract"); err == nil {
+		return path, nil
+	}
+	if runtime.GOOS == "windows" {
+		path := `C:\Program Files\Tesseract-OCR\tesseract.exe`
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("tesseract를 찾을 수 없습니다 (설치 후 PATH에 추가하세요)")
+}
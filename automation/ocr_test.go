@@ -0,0 +1,153 @@
+//go:build headless
+
+package automation
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// fakeOCREngine은 정해진 텍스트를 돌려주고 받은 이미지와 옵션을 기록하는 OCREngine입니다
+type fakeOCREngine struct {
+	text    string
+	err     error
+	image   image.Image
+	options OCROptions
+}
+
+func (f *fakeOCREngine) Recognize(img image.Image, options OCROptions) (string, error) {
+	f.image, f.options = img, options
+	return f.text, f.err
+}
+
+// testdata/ocr_screen.png는 120x60 어두운 화면의 (20, 10)-(60, 30) 영역만 흰색인 이미지입니다
+func newOCRTestTarget(t *testing.T, pattern string) *Target {
+	t.Helper()
+	target, err := parseTarget("count", targetFile{Text: pattern, Region: []int{20, 10, 40, 20}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+func TestReadTextCapturesRegion(t *testing.T) {
+	screen, err := LoadImageCapturer("testdata/ocr_screen.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := newOCRTestTarget(t, `남은 횟수\s*([\d,]+)`)
+	engine := &fakeOCREngine{text: "  남은 횟수\n 1,234 \n"}
+
+	text, err := target.ReadText(screen, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "남은 횟수 1,234" {
+		t.Errorf("정리된 텍스트 %q", text)
+	}
+	if engine.options != (OCROptions{Language: defaultOCRLanguage, PageMode: defaultOCRPageMode}) {
+		t.Errorf("옵션 %+v", engine.options)
+	}
+
+	// 영역만 잘라 흑백으로 바꾸고 기본 배율로 확대
+	gray, ok := engine.image.(*image.Gray)
+	if !ok {
+		t.Fatalf("이미지 형식 %T", engine.image)
+	}
+	if gray.Rect != image.Rect(0, 0, 40*defaultOCRScale, 20*defaultOCRScale) {
+		t.Fatalf("이미지 크기 %v", gray.Rect)
+	}
+	for _, value := range gray.Pix {
+		if value != 255 {
+			t.Fatalf("영역 밖의 픽셀(%d)이 포함되었습니다", value)
+		}
+	}
+}
+
+func TestReadTextErrors(t *testing.T) {
+	screen := NewImageCapturer(image.NewRGBA(image.Rect(0, 0, 120, 60)))
+	target := newOCRTestTarget(t, `입장`)
+
+	if _, err := target.ReadText(screen, nil); err == nil {
+		t.Error("OCR 엔진 없이 인식했습니다")
+	}
+	if _, err := target.ReadText(screen, &fakeOCREngine{err: errors.New("tesseract 없음")}); err == nil || !strings.Contains(err.Error(), "텍스트 인식 실패: tesseract 없음") {
+		t.Errorf("인식 오류 %v", err)
+	}
+	colorTarget := &Target{Name: "dot", Color: color.RGBA{255, 255, 255, 255}}
+	if _, err := colorTarget.ReadText(screen, &fakeOCREngine{}); err == nil {
+		t.Error("색상 대상의 텍스트를 읽었습니다")
+	}
+}
+
+func TestTextValue(t *testing.T) {
+	cases := []struct {
+		pattern string
+		text    string
+		want    int
+	}{
+		{`입장`, "[입장] 버튼", 1},
+		{`입장`, "퇴장", 0},
+		{`남은 횟수\s*([\d,]+)`, "남은 횟수 1,234", 1234},
+		{`남은 횟수\s*([\d,. ]+)`, "남은 횟수 1. 234", 1234},
+		{`골드\s*(-?\d+)`, "골드 -15", -15},
+		{`남은 횟수\s*(\d+)`, "남은 횟수 없음", -1},
+		{`남은 횟수\s*(\S+)`, "남은 횟수 OO", -1},
+	}
+	for _, c := range cases {
+		target := &Target{Name: "t", Pattern: mustCompileTextPattern(t, c.pattern)}
+		if got := target.TextValue(c.text); got != c.want {
+			t.Errorf("%q / %q: %d, 기대 %d", c.pattern, c.text, got, c.want)
+		}
+	}
+}
+
+func TestCompileTextPatternErrors(t *testing.T) {
+	for _, pattern := range []string{`(`, `(\d+) (\d+)`} {
+		if _, err := compileTextPattern(pattern); err == nil {
+			t.Errorf("%q: 오류가 나야 합니다", pattern)
+		}
+	}
+	if _, err := compileTextPattern(`(?:남은|잔여) (\d+)`); err != nil {
+		t.Errorf("비캡처 그룹: %v", err)
+	}
+}
+
+func TestPreviewTextTarget(t *testing.T) {
+	data, err := os.ReadFile("testdata/ocr_screen.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := newOCRTestTarget(t, `남은 횟수\s*(\d+)`)
+
+	result := PreviewTextTarget(target, &fakeOCREngine{text: "남은 횟수\n7"}, "shot.png", bytes.NewReader(data))
+	want := OCRResult{File: "shot.png", Text: "남은 횟수 7", Matched: true, Value: 7}
+	if result != want {
+		t.Errorf("결과 %+v, 기대 %+v", result, want)
+	}
+
+	result = PreviewTextTarget(target, &fakeOCREngine{text: "다른 글자"}, "shot.png", bytes.NewReader(data))
+	if result.Matched || result.Value != -1 || result.Error != "" {
+		t.Errorf("일치하지 않는 결과 %+v", result)
+	}
+
+	result = PreviewTextTarget(target, &fakeOCREngine{}, "notes.txt", strings.NewReader("PNG가 아님"))
+	if result.Error == "" || !strings.Contains(result.Error, "notes.txt") {
+		t.Errorf("잘못된 이미지 결과 %+v", result)
+	}
+}
+
+func mustCompileTextPattern(t *testing.T, pattern string) *regexp.Regexp {
+	t.Helper()
+	re, err := compileTextPattern(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return re
+}
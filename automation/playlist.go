@@ -0,0 +1,288 @@
+package automation
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"example.com/m/utils"
+)
+
+// PlaylistState는 재생 목록의 진행 상태입니다
+type PlaylistState string
+
+// 재생 목록 상태 상수
+const (
+	PlaylistRunning   PlaylistState = "running"   // 단계 실행 중
+	PlaylistWaiting   PlaylistState = "waiting"   // 다음 단계 전 대기 중
+	PlaylistCompleted PlaylistState = "completed" // 모든 단계 완료
+	PlaylistStopped   PlaylistState = "stopped"   // 사용자가 중지함
+	PlaylistFailed    PlaylistState = "failed"    // 단계를 시작하지 못했거나 오류로 중지됨
+)
+
+// PlaylistStep은 재생 목록의 한 단계입니다
+// 실행 시간과 반복 횟수 중 하나 이상이 필요하며, 먼저 도달한 조건에서 다음 단계로 넘어갑니다
+type PlaylistStep struct {
+	Sequence   string `json:"sequence"`             // 실행할 시퀀스 ID
+	Duration   string `json:"duration,omitempty"`   // 실행 시간 ("1h10m", "1.5", 종료 시각 "06:00")
+	Iterations int    `json:"iterations,omitempty"` // 반복 횟수
+	Pause      string `json:"pause,omitempty"`      // 다음 단계 전에 쉬는 시간 ("30s")
+}
+
+// RunTime은 실행 시간 설정을 반환합니다 (0 값이면 제한 없음)
+func (s PlaylistStep) RunTime() utils.RunTime {
+	runTime, _ := utils.ParseRunTime(s.Duration)
+	return runTime
+}
+
+// PauseDuration은 다음 단계 전에 쉬는 시간을 반환합니다
+func (s PlaylistStep) PauseDuration() time.Duration {
+	d, _ := time.ParseDuration(s.Pause)
+	return d
+}
+
+// Validate는 단계 설정을 검사합니다
+func (s PlaylistStep) Validate() error {
+	if s.Sequence == "" {
+		return fmt.Errorf("시퀀스가 지정되지 않았습니다")
+	}
+	runTime, err := utils.ParseRunTime(s.Duration)
+	if err != nil {
+		return err
+	}
+	if s.Pause != "" {
+		if d, err := time.ParseDuration(s.Pause); err != nil || d < 0 || d > utils.MaxRunDuration {
+			return fmt.Errorf("잘못된 쉬는 시간 '%s' (0에서 %v 사이)", s.Pause, utils.MaxRunDuration)
+		}
+	}
+	if s.Iterations < 0 {
+		return fmt.Errorf("반복 횟수는 0 이상이어야 합니다")
+	}
+	if runTime.IsZero() && s.Iterations == 0 {
+		return fmt.Errorf("%s: 실행 시간이나 반복 횟수가 필요합니다", s.Sequence)
+	}
+	return nil
+}
+
+// PlaylistStepResult는 끝난 단계 하나의 결과입니다
+type PlaylistStepResult struct {
+	Sequence      string        `json:"sequence"`
+	Name          string        `json:"name"`
+	Status        RunStatus     `json:"status,omitempty"` // 시작하지 못한 경우 비어 있음
+	Reason        string        `json:"reason,omitempty"`
+	Active        time.Duration `json:"-"`
+	ActiveSeconds int64         `json:"active_seconds"`
+	Iterations    int           `json:"iterations"`
+}
+
+// PlaylistStatus는 재생 목록의 현재 진행 상황입니다
+type PlaylistStatus struct {
+	State     PlaylistState        `json:"state"`
+	Step      int                  `json:"step"` // 현재 단계 (1부터 시작, 대기 중이면 다음 단계)
+	Total     int                  `json:"total"`
+	Sequence  string               `json:"sequence"`
+	Started   time.Time            `json:"started"`
+	Finished  time.Time            `json:"finished,omitzero"`
+	WaitUntil time.Time            `json:"wait_until,omitzero"` // 대기 중이면 다음 단계 시작 예정 시각
+	Results   []PlaylistStepResult `json:"results"`
+	Reason    string               `json:"reason,omitempty"` // 중지 또는 실패 이유
+}
+
+// Active는 결과에 기록된 실행 시간의 합을 반환합니다
+func (s PlaylistStatus) Active() time.Duration {
+	var total time.Duration
+	for _, result := range s.Results {
+		total += result.Active
+	}
+	return total
+}
+
+// PlaylistStepRunner는 단계 하나를 실행하고 끝날 때까지 기다립니다
+// 시퀀스 이름과 실행 결과를 반환하며, 시작하지 못하면 오류를 반환합니다
+type PlaylistStepRunner func(step PlaylistStep) (name string, result RunResult, err error)
+
+// PlaylistRun은 실행 중인 재생 목록입니다
+// 단계가 오류로 끝나거나 사용자가 중지하면 남은 단계는 실행하지 않습니다
+type PlaylistRun struct {
+	Steps []PlaylistStep
+
+	clock   Clock
+	runStep PlaylistStepRunner
+	status  PlaylistStatus
+	quit    chan struct{}
+	done    chan struct{}
+	stopped bool
+	mutex   sync.Mutex
+}
+
+// NewPlaylistRun은 단계 설정을 검사하고 재생 목록을 생성합니다 (clock이 nil이면 실제 시간 사용)
+func NewPlaylistRun(steps []PlaylistStep, clock Clock) (*PlaylistRun, error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("재생 목록이 비어 있습니다")
+	}
+	for i, step := range steps {
+		if err := step.Validate(); err != nil {
+			return nil, fmt.Errorf("%d번째 단계: %v", i+1, err)
+		}
+	}
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	return &PlaylistRun{
+		Steps: steps,
+		clock: clock,
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+		status: PlaylistStatus{
+			State:   PlaylistRunning,
+			Total:   len(steps),
+			Results: []PlaylistStepResult{},
+		},
+	}, nil
+}
+
+// Start는 runStep으로 단계를 차례로 실행하는 고루틴을 시작합니다
+// onFinish가 nil이 아니면 마지막 단계가 끝난 뒤 최종 상태로 호출됩니다
+func (p *PlaylistRun) Start(runStep PlaylistStepRunner, onFinish func(PlaylistStatus)) {
+	p.mutex.Lock()
+	p.runStep = runStep
+	p.status.Started = p.clock.Now()
+	p.mutex.Unlock()
+
+	go func() {
+		p.exec()
+		close(p.done)
+		if onFinish != nil {
+			onFinish(p.Status())
+		}
+	}()
+}
+
+// exec는 단계를 차례로 실행합니다
+func (p *PlaylistRun) exec() {
+	for i, step := range p.Steps {
+		// 첫 단계가 아니면 이전 단계에서 지정한 시간만큼 대기
+		if i > 0 {
+			if pause := p.Steps[i-1].PauseDuration(); pause > 0 {
+				p.setStep(i, step, PlaylistWaiting, p.clock.Now().Add(pause))
+				select {
+				case <-p.clock.After(pause):
+				case <-p.quit:
+				}
+			}
+		}
+		if p.Stopped() {
+			p.finish(PlaylistStopped, p.stopReason())
+			return
+		}
+
+		p.setStep(i, step, PlaylistRunning, time.Time{})
+		name, result, err := p.runStep(step)
+		if err != nil {
+			p.addResult(PlaylistStepResult{Sequence: step.Sequence, Name: name, Reason: err.Error()})
+			p.finish(PlaylistFailed, fmt.Sprintf("%d단계(%s)를 시작할 수 없습니다: %v", i+1, name, err))
+			return
+		}
+
+		p.addResult(PlaylistStepResult{
+			Sequence:      step.Sequence,
+			Name:          name,
+			Status:        result.Status,
+			Reason:        result.Reason,
+			Active:        result.Active,
+			ActiveSeconds: int64(result.Active / time.Second),
+			Iterations:    result.Iterations,
+		})
+
+		switch result.Status {
+		case RunStoppedByUser:
+			p.finish(PlaylistStopped, result.Reason)
+			return
+		case RunStoppedByError:
+			p.finish(PlaylistFailed, fmt.Sprintf("%d단계(%s) 오류: %s", i+1, name, result.Reason))
+			return
+		}
+	}
+
+	p.finish(PlaylistCompleted, "")
+}
+
+// Stop은 남은 단계를 취소합니다 (실행 중인 단계는 호출한 쪽에서 중지해야 함)
+func (p *PlaylistRun) Stop(reason string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.stopped {
+		return
+	}
+	p.stopped = true
+	p.status.Reason = reason
+	close(p.quit)
+}
+
+// Done은 재생 목록이 끝나면 닫히는 채널을 반환합니다
+func (p *PlaylistRun) Done() <-chan struct{} {
+	return p.done
+}
+
+// Finished는 재생 목록이 끝났는지 확인합니다
+func (p *PlaylistRun) Finished() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Status는 현재 진행 상황을 반환합니다
+func (p *PlaylistRun) Status() PlaylistStatus {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	status := p.status
+	status.Results = append([]PlaylistStepResult{}, p.status.Results...)
+	return status
+}
+
+// setStep은 현재 단계를 변경합니다
+func (p *PlaylistRun) setStep(index int, step PlaylistStep, state PlaylistState, waitUntil time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.status.Step = index + 1
+	p.status.Sequence = step.Sequence
+	p.status.State = state
+	p.status.WaitUntil = waitUntil
+}
+
+// addResult는 끝난 단계의 결과를 기록합니다
+func (p *PlaylistRun) addResult(result PlaylistStepResult) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.status.Results = append(p.status.Results, result)
+}
+
+// finish는 최종 상태를 기록합니다
+func (p *PlaylistRun) finish(state PlaylistState, reason string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.status.State = state
+	p.status.WaitUntil = time.Time{}
+	p.status.Finished = p.clock.Now()
+	if reason != "" {
+		p.status.Reason = reason
+	}
+}
+
+// Stopped는 Stop이 호출되었는지 확인합니다
+func (p *PlaylistRun) Stopped() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.stopped
+}
+
+// stopReason은 중지 이유를 반환합니다
+func (p *PlaylistRun) stopReason() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.status.Reason
+}
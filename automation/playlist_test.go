@@ -0,0 +1,214 @@
+//go:build headless
+
+package automation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/m/utils"
+)
+
+func TestPlaylistStepValidate(t *testing.T) {
+	cases := []struct {
+		step PlaylistStep
+		want utils.RunTime
+		ok   bool
+	}{
+		{PlaylistStep{Sequence: "a", Duration: "1h10m"}, utils.RunTime{Duration: 70 * time.Minute}, true},
+		{PlaylistStep{Sequence: "a", Duration: "1.5", Pause: "30s"}, utils.RunTime{Duration: 90 * time.Minute}, true},
+		{PlaylistStep{Sequence: "a", Duration: "06:00"}, utils.RunTime{EndAt: "06:00"}, true},
+		{PlaylistStep{Sequence: "a", Iterations: 3}, utils.RunTime{}, true},
+		{PlaylistStep{Sequence: "a", Duration: "1s"}, utils.RunTime{}, false},
+		{PlaylistStep{Sequence: "a", Duration: "999h"}, utils.RunTime{}, false},
+		{PlaylistStep{Sequence: "a", Duration: "-1h", Iterations: 1}, utils.RunTime{}, false},
+		{PlaylistStep{Sequence: "a", Iterations: 1, Pause: "-1s"}, utils.RunTime{}, false},
+		{PlaylistStep{Sequence: "a", Iterations: 1, Pause: "25h"}, utils.RunTime{}, false},
+		{PlaylistStep{Sequence: "a"}, utils.RunTime{}, false},
+		{PlaylistStep{Duration: "1h"}, utils.RunTime{}, false},
+		{PlaylistStep{Sequence: "a", Iterations: -1}, utils.RunTime{}, false},
+	}
+	for _, c := range cases {
+		err := c.step.Validate()
+		if (err == nil) != c.ok {
+			t.Errorf("%+v: 오류 %v", c.step, err)
+			continue
+		}
+		if c.ok && c.step.RunTime() != c.want {
+			t.Errorf("%+v: 실행 시간 %+v, 기대 %+v", c.step, c.step.RunTime(), c.want)
+		}
+	}
+}
+
+// playlistStepLog는 단계를 실행한 순서와 시각입니다
+type playlistStepLog struct {
+	sequence string
+	started  time.Time
+	finished time.Time
+}
+
+// runTestPlaylist는 RecordingDriver로 단계를 실행하고 재생 목록이 끝날 때까지 기다립니다
+// 단계의 시퀀스 ID를 이름과 키로 사용하며, beforeStep이 있으면 단계를 시작하기 전에 호출합니다
+func runTestPlaylist(t *testing.T, steps []PlaylistStep, setup func(*RecordingDriver), beforeStep func(*PlaylistRun, int)) (PlaylistStatus, []playlistStepLog, time.Time) {
+	t.Helper()
+	km, driver, clock, _ := newTestKeyboard()
+	if setup != nil {
+		setup(driver)
+	}
+
+	playlist, err := NewPlaylistRun(steps, clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log []playlistStepLog
+	var final PlaylistStatus
+	finished := make(chan struct{})
+	playlist.Start(func(step PlaylistStep) (string, RunResult, error) {
+		if beforeStep != nil {
+			beforeStep(playlist, len(log))
+		}
+		entry := playlistStepLog{sequence: step.Sequence, started: clock.Now()}
+		sequence := KeySequence{ID: step.Sequence, Name: strings.ToUpper(step.Sequence), KeyPresses: []string{step.Sequence}}
+		result, err := km.RunKeySequence(sequence, RunOptions{Timeout: step.RunTime().Duration, MaxIterations: step.Iterations})
+		entry.finished = clock.Now()
+		log = append(log, entry)
+		return sequence.Name, result, err
+	}, func(status PlaylistStatus) {
+		final = status
+		close(finished)
+	})
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("재생 목록이 끝나지 않았습니다")
+	}
+	if !playlist.Finished() {
+		t.Error("onFinish 뒤에도 재생 목록이 끝나지 않은 상태입니다")
+	}
+	return final, log, clock.Now()
+}
+
+func TestPlaylistRunsStepsInOrder(t *testing.T) {
+	steps := []PlaylistStep{
+		{Sequence: "a", Duration: "10m", Pause: "2m"},
+		{Sequence: "b", Iterations: 2, Pause: "30s"},
+		{Sequence: "c", Iterations: 1},
+	}
+	status, log, now := runTestPlaylist(t, steps, nil, nil)
+
+	if len(log) != 3 || log[0].sequence != "a" || log[1].sequence != "b" || log[2].sequence != "c" {
+		t.Fatalf("실행 순서 %+v", log)
+	}
+	// 이전 단계의 쉬는 시간만큼 기다린 뒤 다음 단계 시작
+	if gap := log[1].started.Sub(log[0].finished); gap != 2*time.Minute {
+		t.Errorf("1단계 뒤 대기 %v, 기대 2m", gap)
+	}
+	if gap := log[2].started.Sub(log[1].finished); gap != 30*time.Second {
+		t.Errorf("2단계 뒤 대기 %v, 기대 30s", gap)
+	}
+
+	// 최종 보고
+	if status.State != PlaylistCompleted || status.Reason != "" || status.Step != 3 || status.Total != 3 {
+		t.Errorf("최종 상태 %+v", status)
+	}
+	if !status.Finished.Equal(now) || !status.WaitUntil.IsZero() {
+		t.Errorf("종료 시각 %v, 대기 시각 %v", status.Finished, status.WaitUntil)
+	}
+	want := []struct {
+		name       string
+		status     RunStatus
+		iterations int
+	}{
+		{"A", RunTimedOut, -1},
+		{"B", RunCompleted, 2},
+		{"C", RunCompleted, 1},
+	}
+	if len(status.Results) != len(want) {
+		t.Fatalf("결과 %+v", status.Results)
+	}
+	for i, w := range want {
+		result := status.Results[i]
+		if result.Name != w.name || result.Status != w.status || (w.iterations >= 0 && result.Iterations != w.iterations) {
+			t.Errorf("%d단계 결과 %+v", i+1, result)
+		}
+	}
+	if status.Results[0].Active != 10*time.Minute || status.Results[0].ActiveSeconds != 600 {
+		t.Errorf("1단계 실행 시간 %v", status.Results[0].Active)
+	}
+	if total := status.Active(); total != status.Results[0].Active+status.Results[1].Active+status.Results[2].Active {
+		t.Errorf("전체 실행 시간 %v", total)
+	}
+}
+
+func TestPlaylistStopsAfterError(t *testing.T) {
+	steps := []PlaylistStep{
+		{Sequence: "a", Iterations: 1},
+		{Sequence: "b", Iterations: 1},
+		{Sequence: "c", Iterations: 1},
+	}
+	status, log, _ := runTestPlaylist(t, steps, func(driver *RecordingDriver) {
+		driver.FailOn["b"] = errors.New("입력 실패")
+	}, nil)
+
+	if len(log) != 2 {
+		t.Fatalf("오류 뒤에도 단계를 실행했습니다: %+v", log)
+	}
+	if status.State != PlaylistFailed || !strings.HasPrefix(status.Reason, "2단계(B) 오류") {
+		t.Errorf("최종 상태 %s: %q", status.State, status.Reason)
+	}
+	if len(status.Results) != 2 || status.Results[1].Status != RunStoppedByError {
+		t.Errorf("결과 %+v", status.Results)
+	}
+}
+
+func TestPlaylistStopsBeforeNextStep(t *testing.T) {
+	steps := []PlaylistStep{
+		{Sequence: "a", Iterations: 1, Pause: "5m"},
+		{Sequence: "b", Iterations: 1},
+	}
+	// 1단계가 실행되는 동안 재생 목록 중지 (실행 중인 단계는 끝까지 진행)
+	status, log, _ := runTestPlaylist(t, steps, nil, func(playlist *PlaylistRun, index int) {
+		if index == 0 {
+			playlist.Stop("사용자 중지")
+		}
+	})
+
+	if len(log) != 1 {
+		t.Fatalf("중지 뒤에도 단계를 실행했습니다: %+v", log)
+	}
+	if status.State != PlaylistStopped || status.Reason != "사용자 중지" || len(status.Results) != 1 {
+		t.Errorf("최종 상태 %+v", status)
+	}
+}
+
+func TestPlaylistStepStartFailure(t *testing.T) {
+	clock := NewManualClock(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	playlist, err := NewPlaylistRun([]PlaylistStep{{Sequence: "a", Iterations: 1}, {Sequence: "b", Iterations: 1}}, clock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	playlist.Start(func(step PlaylistStep) (string, RunResult, error) {
+		calls++
+		return "A", RunResult{}, errors.New("알 수 없는 시퀀스")
+	}, nil)
+	<-playlist.Done()
+
+	status := playlist.Status()
+	if calls != 1 || status.State != PlaylistFailed || !strings.Contains(status.Reason, "1단계(A)를 시작할 수 없습니다") {
+		t.Errorf("%d번 실행, 최종 상태 %+v", calls, status)
+	}
+	if len(status.Results) != 1 || status.Results[0].Status != "" || status.Results[0].Reason != "알 수 없는 시퀀스" {
+		t.Errorf("결과 %+v", status.Results)
+	}
+
+	if _, err := NewPlaylistRun(nil, clock); err == nil {
+		t.Error("빈 재생 목록이 허용되었습니다")
+	}
+	if _, err := NewPlaylistRun([]PlaylistStep{{Sequence: "a", Iterations: 1}, {Sequence: "b"}}, clock); err == nil || !strings.HasPrefix(err.Error(), "2번째 단계") {
+		t.Errorf("잘못된 단계 오류 %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 기본 녹화 중지 키
+const DefaultRecordStopKey = "f8"
+
+// ErrAlreadyRecording은 이미 녹화 중일 때 반환됩니다
+var ErrAlreadyRecording = errors.New("이미 녹화 중입니다")
+
+// RecordedKey는 녹화된 키 입력과 녹화 시작 후 경과 시간입니다
+type RecordedKey struct {
+	Key    string        `json:"key"`
+	Offset time.Duration `json:"offset"`
+}
+
+// Recorder는 전역 키보드 후크로 실제 키 입력과 간격을 녹화합니다
+type Recorder struct {
+	Hook KeyHook
+	// OnStop은 중지 키로 녹화가 끝났을 때 호출됩니다
+	OnStop    func(keys []RecordedKey)
+	recording bool
+	stopKey   string
+	startedAt time.Time
+	keys      []RecordedKey
+	pressed   map[string]bool
+	mutex     sync.Mutex
+}
+
+// NewRecorder는 지정된 후크를 사용하는 녹화기를 생성합니다
+func NewRecorder(hook KeyHook) *Recorder {
+	return &Recorder{
+		Hook:    hook,
+		pressed: make(map[string]bool),
+	}
+}
+
+// Start는 녹화를 시작합니다. stopKey를 누르면 녹화가 자동으로 중지됩니다
+func (r *Recorder) Start(stopKey string) error {
+	// 후킹된 키 이름은 소문자이므로 "Esc", " f12 " 같은 입력도 같은 키로 맞춥니다
+	stopKey = strings.ToLower(strings.TrimSpace(stopKey))
+	if stopKey == "" {
+		stopKey = DefaultRecordStopKey
+	}
+	if !IsValidKey(stopKey) {
+		return fmt.Errorf("알 수 없는 중지 키 '%s'", stopKey)
+	}
+
+	r.mutex.Lock()
+	if r.recording {
+		r.mutex.Unlock()
+		return ErrAlreadyRecording
+	}
+	r.recording = true
+	r.stopKey = stopKey
+	r.startedAt = time.Time{}
+	r.keys = nil
+	r.pressed = make(map[string]bool)
+	r.mutex.Unlock()
+
+	if err := r.Hook.Start(r.handle); err != nil {
+		r.mutex.Lock()
+		r.recording = false
+		r.mutex.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Stop은 녹화를 중지하고 녹화된 키 목록을 반환합니다
+func (r *Recorder) Stop() []RecordedKey {
+	r.Hook.Stop()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.recording = false
+	return r.copyKeys()
+}
+
+// IsRecording은 녹화 중인지 확인합니다
+func (r *Recorder) IsRecording() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.recording
+}
+
+// StopKey는 현재(또는 마지막) 녹화의 중지 키를 반환합니다
+func (r *Recorder) StopKey() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.stopKey
+}
+
+// Keys는 현재(또는 마지막) 녹화의 키 목록을 반환합니다
+func (r *Recorder) Keys() []RecordedKey {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.copyKeys()
+}
+
+func (r *Recorder) copyKeys() []RecordedKey {
+	result := make([]RecordedKey, len(r.keys))
+	copy(result, r.keys)
+	return result
+}
+
+// handle은 후크 이벤트를 녹화합니다
+func (r *Recorder) handle(event KeyHookEvent) {
+	// 자동화가 보낸 입력은 녹화하지 않음
+	if event.Injected {
+		return
+	}
+
+	r.mutex.Lock()
+	if !r.recording {
+		r.mutex.Unlock()
+		return
+	}
+
+	if !event.Down {
+		delete(r.pressed, event.Key)
+		r.mutex.Unlock()
+		return
+	}
+
+	// 키를 누르고 있을 때 반복되는 입력은 무시
+	if r.pressed[event.Key] {
+		r.mutex.Unlock()
+		return
+	}
+	r.pressed[event.Key] = true
+
+	if event.Key == r.stopKey {
+		r.mutex.Unlock()
+
+		keys := r.Stop()
+		if r.OnStop != nil {
+			r.OnStop(keys)
+		}
+		return
+	}
+
+	// 첫 키 입력 시점을 0으로 기준
+	if r.startedAt.IsZero() {
+		r.startedAt = event.Time
+	}
+	r.keys = append(r.keys, RecordedKey{Key: event.Key, Offset: event.Time.Sub(r.startedAt)})
+	r.mutex.Unlock()
+}
+
+// BuildRecordedSequence는 녹화된 키로 시퀀스를 만듭니다
+// 각 대기 시간에서 키 입력 전 지연(300ms)을 빼고, quantize가 지정되면 그 단위로 반올림합니다
+func BuildRecordedSequence(id, name string, keys []RecordedKey, quantize time.Duration) (KeySequence, error) {
+	if len(keys) == 0 {
+		return KeySequence{}, fmt.Errorf("녹화된 키가 없습니다")
+	}
+
+	seq := KeySequence{
+		ID:       id,
+		Name:     name,
+		StartKey: keys[0].Key,
+	}
+	if seq.Name == "" {
+		seq.Name = id
+	}
+
+	for i, key := range keys {
+		seq.KeyPresses = append(seq.KeyPresses, key.Key)
+		if i == len(keys)-1 {
+			break
+		}
+
+		delay := keys[i+1].Offset - key.Offset - keyPressDelay
+		if quantize > 0 {
+			delay = delay.Round(quantize)
+		}
+		if delay < 0 {
+			delay = 0
+		}
+		seq.Delays = append(seq.Delays, delay)
+	}
+
+	if err := seq.Validate(); err != nil {
+		return KeySequence{}, err
+	}
+	return seq, nil
+}
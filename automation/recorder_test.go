@@ -0,0 +1,33 @@
+//go:build headless
+
+package automation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecorderStopKeyNormalized(t *testing.T) {
+	hook := &ManualKeyHook{}
+	recorder := NewRecorder(hook)
+	if err := recorder.Start("  F9 "); err != nil {
+		t.Fatal(err)
+	}
+	if recorder.StopKey() != "f9" {
+		t.Fatalf("중지 키 %q, 기대 f9", recorder.StopKey())
+	}
+
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	hook.Emit(KeyHookEvent{Key: "a", Down: true, Time: start})
+	hook.Emit(KeyHookEvent{Key: "f9", Down: true, Time: start.Add(time.Second)})
+	if recorder.IsRecording() {
+		t.Fatal("중지 키를 눌렀는데 녹화가 계속됩니다")
+	}
+	if keys := recorder.Keys(); len(keys) != 1 || keys[0].Key != "a" {
+		t.Fatalf("녹화된 키: %+v", keys)
+	}
+
+	if err := recorder.Start("Nope"); err == nil {
+		t.Fatal("알 수 없는 중지 키가 허용되었습니다")
+	}
+}
@@ -0,0 +1,358 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrAlreadyRunning은 이미 실행 중인 시퀀스가 있을 때 반환됩니다
+var ErrAlreadyRunning = errors.New("이미 실행 중인 작업이 있습니다")
+
+// RunStatus는 실행이 끝난 이유입니다
+type RunStatus string
+
+// 실행 결과 상수
+const (
+	RunCompleted      RunStatus = "completed"       // 스크립트가 끝까지 실행됨
+	RunStoppedByUser  RunStatus = "stopped_by_user" // 사용자가 중지함
+	RunStoppedByError RunStatus = "stopped_by_error"
+	RunTimedOut       RunStatus = "timed_out" // 실행 시간 제한 도달
+)
+
+// RunOptions는 실행 옵션입니다
+type RunOptions struct {
+	// Timeout은 실행 시간 제한입니다 (일시정지된 시간은 제외, 0이면 제한 없음)
+	Timeout time.Duration
+
+	// MaxIterations는 가장 바깥쪽 반복 횟수 제한입니다 (0이면 제한 없음)
+	// 제한에 도달하면 RunCompleted로 끝납니다
+	MaxIterations int
+
+	// OnIteration은 가장 바깥쪽 반복 한 바퀴가 끝날 때마다 실행 고루틴에서 호출됩니다
+	OnIteration func(stats IterationStats)
+}
+
+// IterationStats는 가장 바깥쪽 반복의 횟수와 한 바퀴 시간(일시정지 제외) 통계입니다
+type IterationStats struct {
+	Count int
+	Last  time.Duration
+	Min   time.Duration
+	Max   time.Duration
+	Total time.Duration
+}
+
+// Average는 한 바퀴 평균 시간을 반환합니다
+func (s IterationStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// RunResult는 끝난 실행의 결과입니다
+type RunResult struct {
+	Status     RunStatus
+	Reason     string // 사용자 중지 이유 또는 오류 메시지
+	Err        error  // RunStoppedByError인 경우의 오류
+	Started    time.Time
+	Finished   time.Time
+	Active     time.Duration // 일시정지를 제외한 실행 시간
+	Iterations int           // 가장 바깥쪽 반복을 완료한 횟수
+	KeysSent   int           // 보낸 키 입력 수 (tap, hold)
+}
+
+// stopCause는 실행 컨텍스트를 취소한 이유입니다
+type stopCause struct {
+	status RunStatus
+	reason string
+}
+
+func (c *stopCause) Error() string {
+	if c.reason != "" {
+		return c.reason
+	}
+	return string(c.status)
+}
+
+// Run은 시퀀스 실행 하나를 나타냅니다
+// 실행마다 고루틴 하나가 스크립트를 실행하며, 중지되면 대기 중이던 작업도 즉시 끝납니다
+type Run struct {
+	Sequence KeySequence
+	km       *KeyboardManager
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
+	timeout  time.Duration
+	started  time.Time
+	done     chan struct{}
+	result   RunResult
+
+	mutex       sync.Mutex
+	paused      bool
+	pauseCh     chan struct{} // 일시정지되면 닫힘
+	resumeCh    chan struct{} // 일시정지 중에만 존재하며, 재개되면 닫힘
+	activeSince time.Time
+	used        time.Duration
+	keysSent    int
+
+	maxIterations int
+	onIteration   func(IterationStats)
+	iteration     IterationStats
+	iterationMark time.Duration // 현재 바퀴를 시작한 시점의 실행 시간
+}
+
+// Done은 실행이 끝나면 닫히는 채널을 반환합니다
+func (r *Run) Done() <-chan struct{} {
+	return r.done
+}
+
+// Result는 실행 결과를 반환합니다 (Done이 닫힌 뒤에만 유효)
+func (r *Run) Result() RunResult {
+	<-r.done
+	return r.result
+}
+
+// Stop은 실행을 중지합니다
+func (r *Run) Stop(reason string) {
+	r.cancel(&stopCause{status: RunStoppedByUser, reason: reason})
+}
+
+// Pause는 실행을 현재 단계에서 일시정지합니다
+// 이미 일시정지 상태이거나 끝난 실행이면 false를 반환합니다
+func (r *Run) Pause() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.paused || r.ctx.Err() != nil {
+		return false
+	}
+	r.paused = true
+	r.used += r.km.Clock.Now().Sub(r.activeSince)
+	r.resumeCh = make(chan struct{})
+	close(r.pauseCh)
+	return true
+}
+
+// Resume은 일시정지된 실행을 멈춘 단계부터 이어서 실행합니다
+func (r *Run) Resume() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.paused {
+		return false
+	}
+	r.paused = false
+	r.activeSince = r.km.Clock.Now()
+	r.pauseCh = make(chan struct{})
+	close(r.resumeCh)
+	r.resumeCh = nil
+	return true
+}
+
+// IsPaused는 일시정지 상태인지 확인합니다
+func (r *Run) IsPaused() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.paused
+}
+
+// Active는 일시정지를 제외한 실행 시간을 반환합니다
+func (r *Run) Active() time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.activeLocked()
+}
+
+func (r *Run) activeLocked() time.Duration {
+	if r.paused {
+		return r.used
+	}
+	return r.used + r.km.Clock.Now().Sub(r.activeSince)
+}
+
+// Iterations는 가장 바깥쪽 반복(repeat/loop)을 완료한 횟수를 반환합니다
+func (r *Run) Iterations() int {
+	return r.IterationStats().Count
+}
+
+// IterationStats는 반복 횟수와 한 바퀴 시간 통계를 반환합니다
+func (r *Run) IterationStats() IterationStats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.iteration
+}
+
+// MaxIterations는 반복 횟수 제한을 반환합니다 (0이면 제한 없음)
+func (r *Run) MaxIterations() int {
+	return r.maxIterations
+}
+
+// startIteration은 현재 시점을 새 바퀴의 시작으로 기록합니다
+func (r *Run) startIteration() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.iterationMark = r.activeLocked()
+}
+
+// addIteration은 한 바퀴를 기록하고, 반복 횟수 제한에 도달하면 실행을 끝냅니다
+func (r *Run) addIteration() {
+	r.mutex.Lock()
+	active := r.activeLocked()
+	d := active - r.iterationMark
+	r.iterationMark = active
+
+	stats := &r.iteration
+	stats.Count++
+	stats.Last = d
+	stats.Total += d
+	if stats.Count == 1 || d < stats.Min {
+		stats.Min = d
+	}
+	if d > stats.Max {
+		stats.Max = d
+	}
+	snapshot := *stats
+	r.mutex.Unlock()
+
+	if r.onIteration != nil {
+		r.onIteration(snapshot)
+	}
+	if r.maxIterations > 0 && snapshot.Count >= r.maxIterations {
+		r.cancel(&stopCause{status: RunCompleted, reason: fmt.Sprintf("%d회 반복 완료", snapshot.Count)})
+	}
+}
+
+// KeysSent는 지금까지 보낸 키 입력 수를 반환합니다
+func (r *Run) KeysSent() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.keysSent
+}
+
+func (r *Run) addKey() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.keysSent++
+}
+
+// remaining은 시간 제한까지 남은 실행 시간을 반환합니다 (제한이 없으면 false)
+func (r *Run) remaining() (time.Duration, bool) {
+	if r.timeout <= 0 {
+		return 0, false
+	}
+	return r.timeout - r.Active(), true
+}
+
+// exec는 실행 고루틴의 본문입니다
+func (r *Run) exec() {
+	defer close(r.done)
+	defer r.cancel(nil)
+
+	// 매크로 실행 중 실수로 버튼을 누를 수 없도록 간단한 딜레이
+	err := r.sleep(300*time.Millisecond, nil)
+	if err == nil {
+		script := r.Sequence.Script
+		if script == nil {
+			script = r.Sequence.ToScript()
+		}
+		err = r.runScript(script)
+	}
+
+	r.result = RunResult{
+		Status:     RunCompleted,
+		Started:    r.started,
+		Finished:   r.km.Clock.Now(),
+		Active:     r.Active(),
+		Iterations: r.Iterations(),
+		KeysSent:   r.KeysSent(),
+	}
+
+	var cause *stopCause
+	switch {
+	case errors.As(context.Cause(r.ctx), &cause):
+		r.result.Status = cause.status
+		r.result.Reason = cause.reason
+	case err != nil:
+		r.result.Status = RunStoppedByError
+		r.result.Reason = err.Error()
+		r.result.Err = err
+	}
+
+	// 다음 실행을 시작할 수 있도록 현재 실행에서 해제
+	r.km.Mutex.Lock()
+	if r.km.current == r {
+		r.km.current = nil
+	}
+	r.km.Mutex.Unlock()
+}
+
+// checkpoint는 중지/시간 제한을 확인하고, 일시정지 상태면 누르고 있던 키를 뗀 뒤 재개될 때까지 대기합니다
+// 재개되면 떼었던 키를 다시 누르며, 실행을 계속할 수 없으면 중지 이유를 오류로 반환합니다
+func (r *Run) checkpoint(held map[string]bool) error {
+	if r.ctx.Err() != nil {
+		return context.Cause(r.ctx)
+	}
+	if remaining, limited := r.remaining(); limited && remaining <= 0 {
+		r.cancel(&stopCause{status: RunTimedOut})
+		return context.Cause(r.ctx)
+	}
+
+	r.mutex.Lock()
+	paused, resumeCh := r.paused, r.resumeCh
+	r.mutex.Unlock()
+	if !paused {
+		return nil
+	}
+
+	for key := range held {
+		r.km.Driver.KeyUp(key)
+	}
+
+	select {
+	case <-r.ctx.Done():
+		return context.Cause(r.ctx)
+	case <-resumeCh:
+	}
+
+	for key := range held {
+		if err := r.km.Driver.KeyDown(key); err != nil {
+			return errors.New("재개 중 키 입력 오류 발생: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// sleep은 d만큼 대기합니다
+// 중지되면 즉시 반환하고, 일시정지되면 재개될 때까지 기다린 뒤 남은 시간만큼 이어서 대기합니다
+func (r *Run) sleep(d time.Duration, held map[string]bool) error {
+	for {
+		if err := r.checkpoint(held); err != nil {
+			return err
+		}
+		if d <= 0 {
+			return nil
+		}
+
+		// 시간 제한을 넘겨서 대기하지 않음
+		step := d
+		if remaining, limited := r.remaining(); limited && step > remaining {
+			step = remaining
+		}
+
+		r.mutex.Lock()
+		pauseCh := r.pauseCh
+		r.mutex.Unlock()
+
+		start := r.km.Clock.Now()
+		select {
+		case <-r.ctx.Done():
+			return context.Cause(r.ctx)
+		case <-pauseCh:
+			d -= r.km.Clock.Now().Sub(start)
+		case <-r.km.Clock.After(step):
+			d -= step
+		}
+	}
+}
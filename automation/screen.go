@@ -0,0 +1,126 @@
+package automation
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"sync"
+)
+
+// ScreenCapturer는 화면의 지정된 영역을 캡처하는 인터페이스입니다
+// 빈 영역을 요청하면 전체 화면을 캡처합니다
+type ScreenCapturer interface {
+	Capture(rect image.Rectangle) (image.Image, error)
+}
+
+// ImageCapturer는 고정된 이미지를 화면으로 사용하는 ScreenCapturer입니다
+// 실제 화면 없이 PNG 파일로 대상 인식을 확인할 때 사용합니다
+type ImageCapturer struct {
+	image image.Image
+	mutex sync.RWMutex
+}
+
+// NewImageCapturer는 지정된 이미지를 화면으로 사용하는 캡처기를 생성합니다
+func NewImageCapturer(img image.Image) *ImageCapturer {
+	return &ImageCapturer{image: img}
+}
+
+// LoadImageCapturer는 PNG 파일을 화면으로 사용하는 캡처기를 생성합니다
+func LoadImageCapturer(path string) (*ImageCapturer, error) {
+	img, err := loadPNG(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewImageCapturer(img), nil
+}
+
+// SetImage는 화면 이미지를 바꿉니다
+func (c *ImageCapturer) SetImage(img image.Image) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.image = img
+}
+
+// Capture는 이미지에서 지정된 영역을 잘라 반환합니다
+func (c *ImageCapturer) Capture(rect image.Rectangle) (image.Image, error) {
+	c.mutex.RLock()
+	img := c.image
+	c.mutex.RUnlock()
+
+	if img == nil {
+		return nil, fmt.Errorf("화면 이미지가 없습니다")
+	}
+	if rect.Empty() {
+		return img, nil
+	}
+
+	// 화면 좌표는 이미지의 왼쪽 위를 (0, 0)으로 봄
+	rect = rect.Add(img.Bounds().Min)
+	if !rect.In(img.Bounds()) {
+		return nil, fmt.Errorf("캡처 영역 %v가 화면(%v)을 벗어났습니다", rect, img.Bounds())
+	}
+
+	sub, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	})
+	if !ok {
+		return nil, fmt.Errorf("이미지 영역을 잘라낼 수 없습니다")
+	}
+	return sub.SubImage(rect), nil
+}
+
+// ScaleToWidth는 이미지가 maxWidth보다 넓으면 비율을 유지해 줄입니다 (영역 평균)
+// maxWidth가 0 이하이거나 이미 충분히 작으면 원본을 그대로 반환합니다
+func ScaleToWidth(img image.Image, maxWidth int) image.Image {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if maxWidth <= 0 || width <= maxWidth || height == 0 {
+		return img
+	}
+	src := toRGBA(img)
+
+	dstWidth := maxWidth
+	dstHeight := max(1, height*maxWidth/width)
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+
+			// 대상 픽셀 하나에 해당하는 원본 영역의 평균 색
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// loadPNG는 PNG 파일을 읽습니다
+func loadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("이미지 파일을 열 수 없습니다: %v", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("PNG 파싱 실패 (%s): %v", path, err)
+	}
+	return img, nil
+}
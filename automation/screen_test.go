@@ -0,0 +1,66 @@
+//go:build headless
+
+package automation
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// stripedImage는 짝수 열은 밝고 홀수 열은 어두운 이미지를 만듭니다
+func stripedImage(rect image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			c := uint8(0)
+			if x%2 == 0 {
+				c = 200
+			}
+			img.Set(x, y, color.RGBA{c, c, c, 255})
+		}
+	}
+	return img
+}
+
+func TestScaleToWidth(t *testing.T) {
+	cases := []struct {
+		name     string
+		bounds   image.Rectangle
+		maxWidth int
+		want     image.Rectangle // 비어 있으면 원본 그대로
+	}{
+		{"절반으로 축소", image.Rect(0, 0, 100, 50), 50, image.Rect(0, 0, 50, 25)},
+		{"원점이 아닌 이미지", image.Rect(10, 10, 110, 60), 50, image.Rect(0, 0, 50, 25)},
+		{"나누어떨어지지 않는 비율", image.Rect(0, 0, 300, 200), 100, image.Rect(0, 0, 100, 66)},
+		{"아주 납작한 이미지", image.Rect(0, 0, 400, 1), 100, image.Rect(0, 0, 100, 1)},
+		{"최대 너비 0", image.Rect(0, 0, 100, 50), 0, image.Rectangle{}},
+		{"음수 최대 너비", image.Rect(0, 0, 100, 50), -1, image.Rectangle{}},
+		{"최대 너비와 같음", image.Rect(0, 0, 100, 50), 100, image.Rectangle{}},
+		{"이미 좁은 이미지", image.Rect(0, 0, 100, 50), 1920, image.Rectangle{}},
+	}
+	for _, tc := range cases {
+		img := stripedImage(tc.bounds)
+		got := ScaleToWidth(img, tc.maxWidth)
+		if tc.want.Empty() {
+			if got != image.Image(img) {
+				t.Errorf("%s: 원본 대신 %v 크기의 새 이미지", tc.name, got.Bounds())
+			}
+			continue
+		}
+		if got.Bounds() != tc.want {
+			t.Errorf("%s: 크기 %v, 기대 %v", tc.name, got.Bounds(), tc.want)
+		}
+	}
+}
+
+func TestScaleToWidthAveragesPixels(t *testing.T) {
+	// 밝은 열과 어두운 열을 두 칸씩 합치면 중간 밝기
+	got := ScaleToWidth(stripedImage(image.Rect(0, 0, 100, 50)), 50)
+	for _, p := range []image.Point{{0, 0}, {25, 12}, {49, 24}} {
+		r, g, b, a := got.At(p.X, p.Y).RGBA()
+		if r>>8 != 100 || g>>8 != 100 || b>>8 != 100 || a>>8 != 255 {
+			t.Errorf("%v 색 (%d, %d, %d, %d), 기대 (100, 100, 100, 255)", p, r>>8, g>>8, b>>8, a>>8)
+		}
+	}
+}
@@ -0,0 +1,712 @@
+package automation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 매크로 스크립트 문법 (한 줄에 한 명령, # 이후는 주석)
+//
+//	tap <키>                   키를 한 번 누름
+//	hold <키> / release <키>   키를 누른 상태로 유지 / 뗌
+//	wait <시간> [~<편차>]       대기 (예: wait 1s ~200ms → 0.8초 ~ 1.2초)
+//	wait until <대상> [<제한>]  화면에 대상이 보일 때까지 대기 (제한 시간을 넘기면 오류로 중지)
+//	abort if <대상>            화면에 대상이 보이면 오류로 중지
+//	read <변수> from <대상>     텍스트 대상을 OCR로 인식해 변수에 저장
+//	                           (정규식의 캡처 그룹 숫자, 그룹이 없으면 일치 여부 1/0)
+//	repeat <N> [<이름>] ... end 본문을 N번 반복
+//	loop [<이름>] ... end       본문을 중지될 때까지 반복
+//	break [<이름>] / continue [<이름>]
+//	label <이름> / goto <이름>
+//	set <변수> = <값> [+|- <값>]
+//	inc <변수> [N] / dec <변수> [N]
+//	if <값> <연산자> <값> ... [else ...] end   (연산자: > < >= <= == !=)
+//	stop                       매크로 종료
+//
+// 값은 정수 또는 변수 이름이며, 대상은 시퀀스 파일의 targets에 정의된 이미지/색상입니다.
+
+// 대기 시간 상한
+const maxScriptWait = time.Hour
+
+// ScriptError는 줄 번호가 포함된 스크립트 오류입니다
+type ScriptError struct {
+	Line    int
+	Message string
+}
+
+func (e ScriptError) Error() string {
+	return fmt.Sprintf("%d번째 줄: %s", e.Line, e.Message)
+}
+
+// ScriptErrors는 스크립트 검증에서 발견된 모든 오류입니다
+type ScriptErrors []ScriptError
+
+func (errs ScriptErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Script는 파싱과 검증을 마친 매크로 스크립트입니다
+type Script struct {
+	Source      string
+	Body        []Stmt
+	Vars        []string
+	Targets     []string // 참조하는 화면 대상 이름
+	TextTargets []string // read에서 참조하는 텍스트 대상 이름
+	code        []instruction
+	slots       int
+}
+
+// Stmt는 스크립트 AST의 문장입니다
+type Stmt interface {
+	Line() int
+}
+
+type stmtLine int
+
+func (l stmtLine) Line() int { return int(l) }
+
+// Operand는 정수 상수 또는 변수입니다
+type Operand struct {
+	Var   string
+	Value int
+}
+
+func (o Operand) String() string {
+	if o.Var != "" {
+		return o.Var
+	}
+	return strconv.Itoa(o.Value)
+}
+
+// Condition은 if 문의 비교식입니다
+type Condition struct {
+	Left  Operand
+	Op    string
+	Right Operand
+}
+
+// 스크립트 문장 종류
+type (
+	TapStmt struct {
+		stmtLine
+		Key string
+	}
+	HoldStmt struct {
+		stmtLine
+		Key string
+	}
+	ReleaseStmt struct {
+		stmtLine
+		Key string
+	}
+	WaitStmt struct {
+		stmtLine
+		Duration time.Duration
+		Jitter   time.Duration
+	}
+	RepeatStmt struct {
+		stmtLine
+		Count int
+		Name  string
+		Body  []Stmt
+	}
+	LoopStmt struct {
+		stmtLine
+		Name string
+		Body []Stmt
+	}
+	BreakStmt struct {
+		stmtLine
+		Name string
+	}
+	ContinueStmt struct {
+		stmtLine
+		Name string
+	}
+	LabelStmt struct {
+		stmtLine
+		Name string
+	}
+	GotoStmt struct {
+		stmtLine
+		Name string
+	}
+	SetStmt struct {
+		stmtLine
+		Var   string
+		Left  Operand
+		Op    string // "", "+", "-"
+		Right Operand
+	}
+	IncStmt struct {
+		stmtLine
+		Var   string
+		Delta int
+	}
+	IfStmt struct {
+		stmtLine
+		Cond Condition
+		Then []Stmt
+		Else []Stmt
+	}
+	StopStmt struct {
+		stmtLine
+	}
+	WaitUntilStmt struct {
+		stmtLine
+		Target  string
+		Timeout time.Duration // 0이면 제한 없음
+	}
+	AbortIfStmt struct {
+		stmtLine
+		Target string
+	}
+	ReadStmt struct {
+		stmtLine
+		Var    string
+		Target string
+	}
+)
+
+// 열린 블록 (repeat, loop, if)
+type openBlock struct {
+	stmt   Stmt
+	inElse bool
+}
+
+// ParseScript는 스크립트를 AST로 파싱하고 검증합니다
+// 오류가 있으면 줄 번호가 포함된 ScriptErrors를 반환합니다
+func ParseScript(source string) (*Script, error) {
+	var errs ScriptErrors
+	var stack []*openBlock
+	root := &[]Stmt{}
+
+	// 현재 문장을 추가할 위치
+	current := func() *[]Stmt {
+		if len(stack) == 0 {
+			return root
+		}
+		switch block := stack[len(stack)-1].stmt.(type) {
+		case *RepeatStmt:
+			return &block.Body
+		case *LoopStmt:
+			return &block.Body
+		case *IfStmt:
+			if stack[len(stack)-1].inElse {
+				return &block.Else
+			}
+			return &block.Then
+		}
+		return root
+	}
+
+	for i, raw := range strings.Split(source, "\n") {
+		lineNo := i + 1
+		line := raw
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		fail := func(format string, args ...interface{}) {
+			errs = append(errs, ScriptError{Line: lineNo, Message: fmt.Sprintf(format, args...)})
+		}
+
+		command := strings.ToLower(fields[0])
+		args := fields[1:]
+		at := stmtLine(lineNo)
+
+		switch command {
+		case "tap", "hold", "release":
+			if len(args) != 1 {
+				fail("%s에는 키 하나가 필요합니다", command)
+				continue
+			}
+			if !IsValidKey(args[0]) {
+				fail("알 수 없는 키 '%s'", args[0])
+				continue
+			}
+			var stmt Stmt
+			switch command {
+			case "tap":
+				stmt = &TapStmt{at, args[0]}
+			case "hold":
+				stmt = &HoldStmt{at, args[0]}
+			default:
+				stmt = &ReleaseStmt{at, args[0]}
+			}
+			*current() = append(*current(), stmt)
+
+		case "wait":
+			if len(args) > 0 && strings.ToLower(args[0]) == "until" {
+				if len(args) != 2 && len(args) != 3 {
+					fail("사용법: wait until <대상> [<제한 시간>]")
+					continue
+				}
+				if !isIdentifier(args[1]) {
+					fail("잘못된 대상 이름 '%s'", args[1])
+					continue
+				}
+				stmt := &WaitUntilStmt{stmtLine: at, Target: args[1]}
+				if len(args) == 3 {
+					timeout, err := time.ParseDuration(args[2])
+					if err != nil || timeout <= 0 || timeout > maxScriptWait {
+						fail("잘못된 제한 시간 '%s' (0 ~ %v)", args[2], maxScriptWait)
+						continue
+					}
+					stmt.Timeout = timeout
+				}
+				*current() = append(*current(), stmt)
+				continue
+			}
+			if len(args) != 1 && len(args) != 2 {
+				fail("사용법: wait <시간> [~<편차>]")
+				continue
+			}
+			duration, err := time.ParseDuration(args[0])
+			if err != nil || duration < 0 || duration > maxScriptWait {
+				fail("잘못된 대기 시간 '%s' (0 ~ %v)", args[0], maxScriptWait)
+				continue
+			}
+			stmt := &WaitStmt{stmtLine: at, Duration: duration}
+			if len(args) == 2 {
+				jitter, err := time.ParseDuration(strings.TrimPrefix(args[1], "~"))
+				if !strings.HasPrefix(args[1], "~") || err != nil || jitter < 0 {
+					fail("잘못된 편차 '%s' (예: ~200ms)", args[1])
+					continue
+				}
+				if jitter > maxScriptWait {
+					fail("편차 '%s'가 너무 깁니다 (최대 %v)", args[1], maxScriptWait)
+					continue
+				}
+				stmt.Jitter = jitter
+			}
+			*current() = append(*current(), stmt)
+
+		case "repeat":
+			if len(args) < 1 || len(args) > 2 {
+				fail("사용법: repeat <횟수> [<이름>]")
+				continue
+			}
+			count, err := strconv.Atoi(args[0])
+			if err != nil || count <= 0 {
+				fail("반복 횟수는 1 이상의 정수여야 합니다: '%s'", args[0])
+				continue
+			}
+			stmt := &RepeatStmt{stmtLine: at, Count: count}
+			if len(args) == 2 {
+				if !isIdentifier(args[1]) {
+					fail("잘못된 반복 이름 '%s'", args[1])
+					continue
+				}
+				stmt.Name = args[1]
+			}
+			*current() = append(*current(), stmt)
+			stack = append(stack, &openBlock{stmt: stmt})
+
+		case "loop":
+			if len(args) > 1 {
+				fail("사용법: loop [<이름>]")
+				continue
+			}
+			stmt := &LoopStmt{stmtLine: at}
+			if len(args) == 1 {
+				if !isIdentifier(args[0]) {
+					fail("잘못된 반복 이름 '%s'", args[0])
+					continue
+				}
+				stmt.Name = args[0]
+			}
+			*current() = append(*current(), stmt)
+			stack = append(stack, &openBlock{stmt: stmt})
+
+		case "if":
+			if len(args) != 3 {
+				fail("사용법: if <값> <연산자> <값>")
+				continue
+			}
+			left, ok1 := parseOperand(args[0])
+			right, ok2 := parseOperand(args[2])
+			if !ok1 || !ok2 {
+				fail("잘못된 비교식 '%s'", strings.Join(args, " "))
+				continue
+			}
+			if !isComparison(args[1]) {
+				fail("알 수 없는 연산자 '%s'", args[1])
+				continue
+			}
+			stmt := &IfStmt{stmtLine: at, Cond: Condition{Left: left, Op: args[1], Right: right}}
+			*current() = append(*current(), stmt)
+			stack = append(stack, &openBlock{stmt: stmt})
+
+		case "else":
+			if len(args) != 0 {
+				fail("else 뒤에는 아무것도 올 수 없습니다")
+				continue
+			}
+			if len(stack) == 0 {
+				fail("if 없이 else가 사용되었습니다")
+				continue
+			}
+			top := stack[len(stack)-1]
+			if _, ok := top.stmt.(*IfStmt); !ok || top.inElse {
+				fail("else의 위치가 잘못되었습니다")
+				continue
+			}
+			top.inElse = true
+
+		case "end":
+			if len(args) != 0 {
+				fail("end 뒤에는 아무것도 올 수 없습니다")
+				continue
+			}
+			if len(stack) == 0 {
+				fail("닫을 블록이 없습니다")
+				continue
+			}
+			stack = stack[:len(stack)-1]
+
+		case "break", "continue", "label", "goto":
+			name := ""
+			if len(args) > 1 {
+				fail("사용법: %s [<이름>]", command)
+				continue
+			}
+			if len(args) == 1 {
+				if !isIdentifier(args[0]) {
+					fail("잘못된 이름 '%s'", args[0])
+					continue
+				}
+				name = args[0]
+			}
+			var stmt Stmt
+			switch command {
+			case "break":
+				stmt = &BreakStmt{at, name}
+			case "continue":
+				stmt = &ContinueStmt{at, name}
+			case "label", "goto":
+				if name == "" {
+					fail("%s에는 이름이 필요합니다", command)
+					continue
+				}
+				if command == "label" {
+					stmt = &LabelStmt{at, name}
+				} else {
+					stmt = &GotoStmt{at, name}
+				}
+			}
+			*current() = append(*current(), stmt)
+
+		case "set":
+			// set <변수> = <값> [+|- <값>]
+			if (len(args) != 3 && len(args) != 5) || args[1] != "=" || !isIdentifier(args[0]) {
+				fail("사용법: set <변수> = <값> [+|- <값>]")
+				continue
+			}
+			left, ok := parseOperand(args[2])
+			if !ok {
+				fail("잘못된 값 '%s'", args[2])
+				continue
+			}
+			stmt := &SetStmt{stmtLine: at, Var: args[0], Left: left}
+			if len(args) == 5 {
+				right, ok := parseOperand(args[4])
+				if (args[3] != "+" && args[3] != "-") || !ok {
+					fail("잘못된 계산식 '%s'", strings.Join(args[2:], " "))
+					continue
+				}
+				stmt.Op = args[3]
+				stmt.Right = right
+			}
+			*current() = append(*current(), stmt)
+
+		case "inc", "dec":
+			if len(args) < 1 || len(args) > 2 || !isIdentifier(args[0]) {
+				fail("사용법: %s <변수> [N]", command)
+				continue
+			}
+			delta := 1
+			if len(args) == 2 {
+				n, err := strconv.Atoi(args[1])
+				if err != nil {
+					fail("잘못된 증감 값 '%s'", args[1])
+					continue
+				}
+				delta = n
+			}
+			if command == "dec" {
+				delta = -delta
+			}
+			*current() = append(*current(), &IncStmt{at, args[0], delta})
+
+		case "abort":
+			if len(args) != 2 || strings.ToLower(args[0]) != "if" || !isIdentifier(args[1]) {
+				fail("사용법: abort if <대상>")
+				continue
+			}
+			*current() = append(*current(), &AbortIfStmt{at, args[1]})
+
+		case "read":
+			if len(args) != 3 || strings.ToLower(args[1]) != "from" || !isIdentifier(args[0]) || !isIdentifier(args[2]) {
+				fail("사용법: read <변수> from <대상>")
+				continue
+			}
+			*current() = append(*current(), &ReadStmt{at, args[0], args[2]})
+
+		case "stop":
+			if len(args) != 0 {
+				fail("stop 뒤에는 아무것도 올 수 없습니다")
+				continue
+			}
+			*current() = append(*current(), &StopStmt{at})
+
+		default:
+			fail("알 수 없는 명령 '%s'", fields[0])
+		}
+	}
+
+	for _, block := range stack {
+		errs = append(errs, ScriptError{Line: block.stmt.Line(), Message: "블록이 end로 닫히지 않았습니다"})
+	}
+
+	script := &Script{Source: source, Body: *root}
+	if len(errs) == 0 {
+		errs = append(errs, script.validate()...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	script.compile()
+	return script, nil
+}
+
+// 식별자 (변수, 레이블, 반복 이름) 형식 확인
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		isLetter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// 정수 또는 변수 이름을 피연산자로 해석
+func parseOperand(token string) (Operand, bool) {
+	if n, err := strconv.Atoi(token); err == nil {
+		return Operand{Value: n}, true
+	}
+	if isIdentifier(token) {
+		return Operand{Var: token}, true
+	}
+	return Operand{}, false
+}
+
+// 비교 연산자 확인
+func isComparison(op string) bool {
+	switch op {
+	case ">", "<", ">=", "<=", "==", "!=":
+		return true
+	}
+	return false
+}
+
+// 검증 중 블록 위치 추적 정보
+type scopeInfo struct {
+	path        []Stmt // 현재 문장을 감싸는 블록 목록 (바깥쪽부터)
+	labels      map[string]*labelInfo
+	vars        map[string]bool
+	targets     map[string]bool // wait until/abort if/read에서 참조한 화면 대상
+	textTargets map[string]bool // read에서 참조한 텍스트 대상
+	errs        ScriptErrors
+}
+
+type labelInfo struct {
+	line int
+	path []Stmt
+}
+
+// validate는 레이블, goto, break/continue, 변수 사용을 검증합니다
+func (s *Script) validate() ScriptErrors {
+	info := &scopeInfo{
+		labels:      make(map[string]*labelInfo),
+		vars:        make(map[string]bool),
+		targets:     make(map[string]bool),
+		textTargets: make(map[string]bool),
+	}
+
+	// 1단계: 레이블과 변수 선언 수집
+	var collect func(body []Stmt, path []Stmt)
+	collect = func(body []Stmt, path []Stmt) {
+		for _, stmt := range body {
+			switch st := stmt.(type) {
+			case *LabelStmt:
+				if prev, exists := info.labels[st.Name]; exists {
+					info.errs = append(info.errs, ScriptError{st.Line(), fmt.Sprintf("레이블 '%s'가 %d번째 줄에 이미 있습니다", st.Name, prev.line)})
+					continue
+				}
+				info.labels[st.Name] = &labelInfo{line: st.Line(), path: append([]Stmt(nil), path...)}
+			case *SetStmt:
+				info.vars[st.Var] = true
+			case *IncStmt:
+				info.vars[st.Var] = true
+			case *WaitUntilStmt:
+				info.targets[st.Target] = true
+			case *AbortIfStmt:
+				info.targets[st.Target] = true
+			case *ReadStmt:
+				info.vars[st.Var] = true
+				info.targets[st.Target] = true
+				info.textTargets[st.Target] = true
+			case *RepeatStmt:
+				collect(st.Body, append(path, st))
+			case *LoopStmt:
+				collect(st.Body, append(path, st))
+			case *IfStmt:
+				collect(st.Then, append(path, st))
+				collect(st.Else, append(path, st))
+			}
+		}
+	}
+	collect(s.Body, nil)
+
+	// 2단계: 참조 검증
+	checkOperand := func(line int, o Operand) {
+		if o.Var != "" && !info.vars[o.Var] {
+			info.errs = append(info.errs, ScriptError{line, fmt.Sprintf("정의되지 않은 변수 '%s' (set 또는 inc로 먼저 정의하세요)", o.Var)})
+		}
+	}
+
+	var check func(body []Stmt, path []Stmt)
+	check = func(body []Stmt, path []Stmt) {
+		for _, stmt := range body {
+			switch st := stmt.(type) {
+			case *GotoStmt:
+				label, ok := info.labels[st.Name]
+				if !ok {
+					info.errs = append(info.errs, ScriptError{st.Line(), fmt.Sprintf("레이블 '%s'를 찾을 수 없습니다", st.Name)})
+				} else if !isPrefixPath(label.path, path) {
+					info.errs = append(info.errs, ScriptError{st.Line(), fmt.Sprintf("블록 안쪽의 레이블 '%s'(으)로는 이동할 수 없습니다", st.Name)})
+				}
+			case *BreakStmt:
+				if findLoop(path, st.Name) == nil {
+					info.errs = append(info.errs, ScriptError{st.Line(), loopNotFoundMessage("break", st.Name)})
+				}
+			case *ContinueStmt:
+				if findLoop(path, st.Name) == nil {
+					info.errs = append(info.errs, ScriptError{st.Line(), loopNotFoundMessage("continue", st.Name)})
+				}
+			case *SetStmt:
+				checkOperand(st.Line(), st.Left)
+				if st.Op != "" {
+					checkOperand(st.Line(), st.Right)
+				}
+			case *RepeatStmt:
+				check(st.Body, append(path, st))
+			case *LoopStmt:
+				check(st.Body, append(path, st))
+			case *IfStmt:
+				checkOperand(st.Line(), st.Cond.Left)
+				checkOperand(st.Line(), st.Cond.Right)
+				check(st.Then, append(path, st))
+				check(st.Else, append(path, st))
+			}
+		}
+	}
+	check(s.Body, nil)
+
+	for name := range info.vars {
+		s.Vars = append(s.Vars, name)
+	}
+	for name := range info.targets {
+		s.Targets = append(s.Targets, name)
+	}
+	sort.Strings(s.Targets)
+	for name := range info.textTargets {
+		s.TextTargets = append(s.TextTargets, name)
+	}
+	sort.Strings(s.TextTargets)
+
+	return info.errs
+}
+
+// isPrefixPath는 레이블 위치가 goto 위치를 감싸는 블록(또는 같은 블록)인지 확인합니다
+func isPrefixPath(labelPath, gotoPath []Stmt) bool {
+	if len(labelPath) > len(gotoPath) {
+		return false
+	}
+	for i := range labelPath {
+		if labelPath[i] != gotoPath[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// findLoop는 break/continue가 가리키는 반복 블록을 찾습니다
+func findLoop(path []Stmt, name string) Stmt {
+	for i := len(path) - 1; i >= 0; i-- {
+		switch st := path[i].(type) {
+		case *RepeatStmt:
+			if name == "" || st.Name == name {
+				return st
+			}
+		case *LoopStmt:
+			if name == "" || st.Name == name {
+				return st
+			}
+		}
+	}
+	return nil
+}
+
+func loopNotFoundMessage(command, name string) string {
+	if name == "" {
+		return fmt.Sprintf("%s는 repeat/loop 안에서만 사용할 수 있습니다", command)
+	}
+	return fmt.Sprintf("%s 대상 반복 '%s'를 찾을 수 없습니다", command, name)
+}
+
+// ToScript는 키 목록과 대기 시간으로 정의된 시퀀스를 동일하게 동작하는 스크립트로 변환합니다
+// 오류 메시지의 줄 번호가 Source의 줄을 가리키도록 문장마다 차례로 줄 번호를 붙입니다
+func (seq KeySequence) ToScript() *Script {
+	lines := []string{"loop"}
+	next := func(text string) stmtLine {
+		lines = append(lines, text)
+		return stmtLine(len(lines))
+	}
+
+	body := []Stmt{}
+	for i, key := range seq.KeyPresses {
+		body = append(body, &TapStmt{stmtLine: next("  tap " + key), Key: key})
+		if i < len(seq.Delays) && seq.Delays[i] > 0 {
+			body = append(body, &WaitStmt{stmtLine: next("  wait " + seq.Delays[i].String()), Duration: seq.Delays[i]})
+		}
+	}
+	// 루프 계속 진행 전 짧은 대기
+	body = append(body, &WaitStmt{stmtLine: next("  wait 1s"), Duration: 1 * time.Second})
+	lines = append(lines, "end")
+
+	script := &Script{
+		Source: strings.Join(lines, "\n"),
+		Body:   []Stmt{&LoopStmt{stmtLine: 1, Body: body}},
+	}
+	script.compile()
+	return script
+}
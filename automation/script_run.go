@@ -0,0 +1,423 @@
+package automation
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// 대기나 키 입력 없이 연속으로 실행할 수 있는 최대 명령 수 (대기 없는 무한 루프 방지)
+const maxIdleSteps = 10000
+
+// wait until에서 화면을 다시 확인하는 간격
+const targetPollInterval = 250 * time.Millisecond
+
+type opCode int
+
+const (
+	opTap opCode = iota
+	opHold
+	opRelease
+	opWait
+	opJump
+	opJumpUnless
+	opSet
+	opInc
+	opRepeatInit
+	opRepeatNext
+	opStop
+	opWaitUntil
+	opAbortIf
+	opRead
+)
+
+// instruction은 컴파일된 스크립트의 실행 단위입니다
+type instruction struct {
+	op     opCode
+	line   int
+	key    string
+	name   string // 화면 대상 이름
+	wait   time.Duration
+	jitter time.Duration
+	target int
+	slot   int
+	count  int
+	cond   Condition
+	set    *SetStmt
+
+	iteration bool // 가장 바깥쪽 반복의 한 바퀴가 끝나는 명령
+}
+
+// 반복 블록의 break/continue 위치 정보
+type loopContext struct {
+	stmt           Stmt
+	breakFixups    []int
+	continueFixups []int
+}
+
+// scriptCompiler는 AST를 점프 기반 명령 목록으로 변환합니다
+type scriptCompiler struct {
+	code       []instruction
+	slots      map[string]int
+	nextSlot   int
+	labels     map[string]int
+	gotoFixups map[int]string
+	loops      []*loopContext
+}
+
+// compile은 검증된 AST를 실행 가능한 명령 목록으로 변환합니다
+func (s *Script) compile() {
+	c := &scriptCompiler{
+		slots:      make(map[string]int),
+		labels:     make(map[string]int),
+		gotoFixups: make(map[int]string),
+	}
+	for _, name := range s.Vars {
+		c.slot(name)
+	}
+
+	c.block(s.Body)
+
+	for idx, name := range c.gotoFixups {
+		c.code[idx].target = c.labels[name]
+	}
+
+	s.code = c.code
+	s.slots = c.nextSlot
+}
+
+// slot은 변수(또는 반복 카운터)의 저장 위치를 반환합니다
+func (c *scriptCompiler) slot(name string) int {
+	if idx, ok := c.slots[name]; ok {
+		return idx
+	}
+	idx := c.nextSlot
+	c.nextSlot++
+	if name != "" {
+		c.slots[name] = idx
+	}
+	return idx
+}
+
+func (c *scriptCompiler) emit(ins instruction) int {
+	c.code = append(c.code, ins)
+	return len(c.code) - 1
+}
+
+// resolve는 변수 피연산자를 슬롯 번호로 바꿉니다 (상수는 그대로)
+func (c *scriptCompiler) resolve(o Operand) Operand {
+	if o.Var != "" {
+		o.Value = c.slot(o.Var)
+	}
+	return o
+}
+
+func (c *scriptCompiler) findLoop(name string) *loopContext {
+	for i := len(c.loops) - 1; i >= 0; i-- {
+		switch st := c.loops[i].stmt.(type) {
+		case *RepeatStmt:
+			if name == "" || st.Name == name {
+				return c.loops[i]
+			}
+		case *LoopStmt:
+			if name == "" || st.Name == name {
+				return c.loops[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (c *scriptCompiler) block(body []Stmt) {
+	for _, stmt := range body {
+		line := stmt.Line()
+		switch st := stmt.(type) {
+		case *TapStmt:
+			c.emit(instruction{op: opTap, line: line, key: st.Key})
+		case *HoldStmt:
+			c.emit(instruction{op: opHold, line: line, key: st.Key})
+		case *ReleaseStmt:
+			c.emit(instruction{op: opRelease, line: line, key: st.Key})
+		case *WaitStmt:
+			c.emit(instruction{op: opWait, line: line, wait: st.Duration, jitter: st.Jitter})
+		case *StopStmt:
+			c.emit(instruction{op: opStop, line: line})
+		case *WaitUntilStmt:
+			c.emit(instruction{op: opWaitUntil, line: line, name: st.Target, wait: st.Timeout})
+		case *AbortIfStmt:
+			c.emit(instruction{op: opAbortIf, line: line, name: st.Target})
+		case *ReadStmt:
+			c.emit(instruction{op: opRead, line: line, name: st.Target, slot: c.slot(st.Var)})
+		case *LabelStmt:
+			c.labels[st.Name] = len(c.code)
+		case *GotoStmt:
+			c.gotoFixups[c.emit(instruction{op: opJump, line: line})] = st.Name
+		case *SetStmt:
+			set := *st
+			set.Left = c.resolve(st.Left)
+			set.Right = c.resolve(st.Right)
+			c.emit(instruction{op: opSet, line: line, slot: c.slot(st.Var), set: &set})
+		case *IncStmt:
+			c.emit(instruction{op: opInc, line: line, slot: c.slot(st.Var), count: st.Delta})
+		case *IfStmt:
+			cond := st.Cond
+			cond.Left = c.resolve(cond.Left)
+			cond.Right = c.resolve(cond.Right)
+			jumpElse := c.emit(instruction{op: opJumpUnless, line: line, cond: cond})
+			c.block(st.Then)
+			if len(st.Else) > 0 {
+				jumpEnd := c.emit(instruction{op: opJump, line: line})
+				c.code[jumpElse].target = len(c.code)
+				c.block(st.Else)
+				c.code[jumpEnd].target = len(c.code)
+			} else {
+				c.code[jumpElse].target = len(c.code)
+			}
+		case *RepeatStmt:
+			counter := c.slot("")
+			c.emit(instruction{op: opRepeatInit, line: line, slot: counter, count: st.Count})
+			c.loopBody(st, st.Body, instruction{op: opRepeatNext, line: line, slot: counter})
+		case *LoopStmt:
+			c.loopBody(st, st.Body, instruction{op: opJump, line: line})
+		case *BreakStmt:
+			loop := c.findLoop(st.Name)
+			loop.breakFixups = append(loop.breakFixups, c.emit(instruction{op: opJump, line: line}))
+		case *ContinueStmt:
+			loop := c.findLoop(st.Name)
+			loop.continueFixups = append(loop.continueFixups, c.emit(instruction{op: opJump, line: line}))
+		}
+	}
+}
+
+// loopBody는 반복 본문과 반복 명령(back)을 생성하고 break/continue 위치를 연결합니다
+func (c *scriptCompiler) loopBody(stmt Stmt, body []Stmt, back instruction) {
+	ctx := &loopContext{stmt: stmt}
+	start := len(c.code)
+
+	c.loops = append(c.loops, ctx)
+	c.block(body)
+	c.loops = c.loops[:len(c.loops)-1]
+
+	back.target = start
+	back.iteration = len(c.loops) == 0
+	next := c.emit(back)
+	for _, idx := range ctx.continueFixups {
+		c.code[idx].target = next
+	}
+	for _, idx := range ctx.breakFixups {
+		c.code[idx].target = len(c.code)
+	}
+}
+
+// value는 피연산자의 현재 값을 반환합니다
+func (o Operand) value(vars []int) int {
+	if o.Var != "" {
+		return vars[o.Value]
+	}
+	return o.Value
+}
+
+// evaluate는 비교식을 계산합니다
+func (cond Condition) evaluate(vars []int) bool {
+	left, right := cond.Left.value(vars), cond.Right.value(vars)
+	switch cond.Op {
+	case ">":
+		return left > right
+	case "<":
+		return left < right
+	case ">=":
+		return left >= right
+	case "<=":
+		return left <= right
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	}
+	return false
+}
+
+// jitterDuration은 d에 ±jitter 범위의 무작위 편차를 더합니다
+func jitterDuration(d, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	d += time.Duration(rand.Int63n(int64(2*jitter)+1)) - jitter
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// runScript는 실행 고루틴에서 스크립트를 실행합니다
+// 매 명령마다 중지 여부를 확인하며, 끝나면 누르고 있던 키를 모두 뗍니다
+// 일시정지되면 현재 명령에서 멈췄다가 재개 시 그 위치부터 이어서 실행합니다
+// 입력 드라이버 등에서 panic이 발생하면 프로그램을 멈추지 않고 오류로 중지합니다
+func (r *Run) runScript(script *Script) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("예기치 않은 오류: %v", p)
+		}
+	}()
+
+	driver := r.km.Driver
+	vars := make([]int, script.slots)
+	held := make(map[string]bool)
+	defer func() {
+		for key := range held {
+			driver.KeyUp(key)
+		}
+	}()
+
+	r.startIteration()
+
+	idle := 0
+	for pc := 0; pc < len(script.code); {
+		if err := r.checkpoint(held); err != nil {
+			return err
+		}
+
+		ins := script.code[pc]
+		pc++
+		idle++
+		if ins.iteration {
+			r.addIteration()
+		}
+
+		switch ins.op {
+		case opTap:
+			if err := r.sleep(keyPressDelay, held); err != nil {
+				return err
+			}
+			if err := r.km.tapKey(ins.key); err != nil {
+				return fmt.Errorf("%d번째 줄: %v", ins.line, err)
+			}
+			r.addKey()
+			idle = 0
+		case opHold, opRelease:
+			var err error
+			if ins.op == opHold {
+				err = driver.KeyDown(ins.key)
+				held[ins.key] = true
+				r.addKey()
+			} else {
+				err = driver.KeyUp(ins.key)
+				delete(held, ins.key)
+			}
+			if err != nil {
+				return fmt.Errorf("%d번째 줄: 키 입력 중 오류 발생: %v", ins.line, err)
+			}
+		case opWait:
+			if err := r.sleep(jitterDuration(ins.wait, ins.jitter), held); err != nil {
+				return err
+			}
+			idle = 0
+		case opJump:
+			pc = ins.target
+		case opJumpUnless:
+			if !ins.cond.evaluate(vars) {
+				pc = ins.target
+			}
+		case opSet:
+			result := ins.set.Left.value(vars)
+			switch ins.set.Op {
+			case "+":
+				result += ins.set.Right.value(vars)
+			case "-":
+				result -= ins.set.Right.value(vars)
+			}
+			vars[ins.slot] = result
+		case opInc:
+			vars[ins.slot] += ins.count
+		case opRepeatInit:
+			vars[ins.slot] = ins.count
+		case opRepeatNext:
+			vars[ins.slot]--
+			if vars[ins.slot] > 0 {
+				pc = ins.target
+			}
+		case opStop:
+			return nil
+		case opWaitUntil:
+			if err := r.waitUntil(ins.name, ins.wait, held); err != nil {
+				return fmt.Errorf("%d번째 줄: %v", ins.line, err)
+			}
+			idle = 0
+		case opAbortIf:
+			visible, err := r.targetVisible(ins.name)
+			if err != nil {
+				return fmt.Errorf("%d번째 줄: %v", ins.line, err)
+			}
+			if visible {
+				return fmt.Errorf("%d번째 줄: 화면에서 '%s'이(가) 감지되어 중지했습니다", ins.line, ins.name)
+			}
+		case opRead:
+			value, err := r.readTarget(ins.name)
+			if err != nil {
+				return fmt.Errorf("%d번째 줄: %v", ins.line, err)
+			}
+			vars[ins.slot] = value
+		}
+
+		if idle > maxIdleSteps {
+			return fmt.Errorf("%d번째 줄: 대기 없이 %d개 이상의 명령이 연속 실행되었습니다 (무한 루프)", ins.line, maxIdleSteps)
+		}
+	}
+
+	return nil
+}
+
+// waitUntil은 화면에 대상이 보일 때까지 대기합니다
+// 대기 중에도 중지/일시정지가 적용되며, 제한 시간(일시정지 제외)을 넘기면 오류를 반환합니다
+func (r *Run) waitUntil(name string, timeout time.Duration, held map[string]bool) error {
+	start := r.Active()
+	for {
+		visible, err := r.targetVisible(name)
+		if err != nil {
+			return err
+		}
+		if visible {
+			return nil
+		}
+
+		step := targetPollInterval
+		if timeout > 0 {
+			left := timeout - (r.Active() - start)
+			if left <= 0 {
+				return fmt.Errorf("'%s'이(가) %v 안에 나타나지 않았습니다", name, timeout)
+			}
+			step = min(step, left)
+		}
+		if err := r.sleep(step, held); err != nil {
+			return err
+		}
+	}
+}
+
+// targetVisible은 시퀀스에 정의된 대상이 현재 화면에 보이는지 확인합니다
+func (r *Run) targetVisible(name string) (bool, error) {
+	target, ok := r.Sequence.Targets[name]
+	if !ok {
+		return false, fmt.Errorf("정의되지 않은 대상 '%s'", name)
+	}
+	if r.km.Screen == nil {
+		return false, fmt.Errorf("화면 캡처를 사용할 수 없습니다")
+	}
+	return target.Visible(r.km.Screen, r.km.OCR)
+}
+
+// readTarget은 텍스트 대상을 인식해 변수 값으로 변환합니다
+func (r *Run) readTarget(name string) (int, error) {
+	target, ok := r.Sequence.Targets[name]
+	if !ok {
+		return 0, fmt.Errorf("정의되지 않은 대상 '%s'", name)
+	}
+	if r.km.Screen == nil {
+		return 0, fmt.Errorf("화면 캡처를 사용할 수 없습니다")
+	}
+	text, err := target.ReadText(r.km.Screen, r.km.OCR)
+	if err != nil {
+		return 0, err
+	}
+	return target.TextValue(text), nil
+}
@@ -0,0 +1,306 @@
+//go:build headless
+
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseScriptJitterLimit(t *testing.T) {
+	_, err := ParseScript("tap a\nwait 1s ~300000h\n")
+	var errs ScriptErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Line != 2 {
+		t.Fatalf("2번째 줄 편차 오류가 나야 합니다: %v", err)
+	}
+
+	script, err := ParseScript("wait 1s ~1h\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait := script.Body[0].(*WaitStmt); wait.Jitter != time.Hour {
+		t.Fatalf("편차 %v, 기대 1h", wait.Jitter)
+	}
+}
+
+func TestToScriptLineNumbers(t *testing.T) {
+	seq := KeySequence{KeyPresses: []string{"a", "b"}, Delays: []time.Duration{time.Second}}
+	script := seq.ToScript()
+
+	lines := strings.Split(script.Source, "\n")
+	loop := script.Body[0].(*LoopStmt)
+	if loop.Line() != 1 || lines[0] != "loop" {
+		t.Fatalf("loop 줄 번호 %d: %q", loop.Line(), lines[0])
+	}
+	for i, stmt := range loop.Body {
+		if stmt.Line() != i+2 {
+			t.Errorf("%d번째 문장 줄 번호 %d, 기대 %d", i, stmt.Line(), i+2)
+		}
+	}
+	if got := lines[loop.Body[1].Line()-1]; got != "  wait 1s" {
+		t.Errorf("대기 문장 줄: %q", got)
+	}
+
+	// 키 입력 오류에도 변환된 스크립트의 줄 번호가 표시됨
+	km, driver, _, _ := newTestKeyboard()
+	driver.FailOn["b"] = errors.New("입력 실패")
+	result, _ := km.RunKeySequence(seq, RunOptions{})
+	if !strings.HasPrefix(result.Reason, "4번째 줄:") {
+		t.Fatalf("오류 줄 번호: %q", result.Reason)
+	}
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		lines  []int  // 오류가 난 줄
+		want   string // 첫 오류 메시지에 포함될 내용
+	}{
+		{"알 수 없는 명령", "tap a\ntapp b\n", []int{2}, "알 수 없는 명령 'tapp'"},
+		{"알 수 없는 키", "tap nokey\n", []int{1}, "알 수 없는 키"},
+		// 잘못된 블록 시작 줄은 블록을 열지 않으므로 end도 오류
+		{"반복 횟수 0", "repeat 0\n  tap a\nend\n", []int{1, 3}, "1 이상의 정수"},
+		{"잘못된 대기 시간", "wait abc\nwait 2h\nwait 1s 200ms\n", []int{1, 2, 3}, "잘못된 대기 시간 'abc'"},
+		{"잘못된 제한 시간", "wait until ok 0s\n", []int{1}, "잘못된 제한 시간"},
+		{"닫히지 않은 블록", "# 주석\nloop\n  if 1 == 1\n    tap a\n", []int{2, 3}, "end로 닫히지 않았습니다"},
+		{"남는 end", "tap a\nend\n", []int{2}, "닫을 블록이 없습니다"},
+		{"if 없는 else", "loop\nelse\nend\n", []int{2}, "else의 위치"},
+		{"두 번째 else", "if 1 == 1\nelse\nelse\nend\n", []int{3}, "else의 위치"},
+		{"잘못된 연산자", "if 1 =< 2\nend\n", []int{1, 2}, "알 수 없는 연산자"},
+		{"반복 밖 break", "tap a\nbreak\n", []int{2}, "repeat/loop 안에서만"},
+		{"없는 반복 이름", "loop outer\n  repeat 2 inner\n    continue other\n  end\nend\n", []int{3}, "반복 'other'"},
+		{"없는 레이블", "goto nowhere\n", []int{1}, "레이블 'nowhere'를 찾을 수 없습니다"},
+		{"중복 레이블", "label a\ntap a\nlabel a\n", []int{3}, "1번째 줄에 이미 있습니다"},
+		{"블록 안쪽 레이블로 이동", "repeat 2\n  label inner\nend\ngoto inner\n", []int{4}, "블록 안쪽의 레이블"},
+		{"정의되지 않은 변수", "set n = m + 1\nif k > 0\nend\n", []int{1, 2}, "정의되지 않은 변수 'm'"},
+		{"잘못된 계산식", "set n = 1 * 2\n", []int{1}, "잘못된 계산식"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			script, err := ParseScript(tc.source)
+			if script != nil {
+				t.Fatal("오류가 있는 스크립트가 반환되었습니다")
+			}
+			var errs ScriptErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("ScriptErrors가 아닌 오류: %v", err)
+			}
+			var lines []int
+			for _, e := range errs {
+				lines = append(lines, e.Line)
+			}
+			if !reflect.DeepEqual(lines, tc.lines) {
+				t.Errorf("오류 줄 %v, 기대 %v (%v)", lines, tc.lines, err)
+			}
+			if !strings.Contains(errs[0].Message, tc.want) {
+				t.Errorf("오류 메시지 %q에 %q가 없습니다", errs[0].Message, tc.want)
+			}
+			if prefix := errs[0].Error(); !strings.HasPrefix(prefix, strconv.Itoa(tc.lines[0])+"번째 줄: ") {
+				t.Errorf("오류 문자열 %q", prefix)
+			}
+		})
+	}
+}
+
+func TestParseScriptReferences(t *testing.T) {
+	script, err := ParseScript(`
+read hp from hp_text   # 텍스트 대상
+wait until ready 5s
+abort if dead
+set total = hp + 1
+dec left 2
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(script.Targets, []string{"dead", "hp_text", "ready"}) {
+		t.Errorf("화면 대상 %v", script.Targets)
+	}
+	if !reflect.DeepEqual(script.TextTargets, []string{"hp_text"}) {
+		t.Errorf("텍스트 대상 %v", script.TextTargets)
+	}
+	if len(script.Vars) != 3 {
+		t.Errorf("변수 %v", script.Vars)
+	}
+	if wait := script.Body[1].(*WaitUntilStmt); wait.Line() != 3 || wait.Target != "ready" || wait.Timeout != 5*time.Second {
+		t.Errorf("wait until 문장 %+v", wait)
+	}
+	if dec := script.Body[4].(*IncStmt); dec.Var != "left" || dec.Delta != -2 {
+		t.Errorf("dec 문장 %+v", dec)
+	}
+}
+
+func TestScriptCompileJumps(t *testing.T) {
+	type op struct {
+		code   opCode
+		target int
+	}
+	cases := []struct {
+		name   string
+		source string
+		want   []op // 점프가 아닌 명령의 target은 0
+	}{
+		{"if/else", "if 1 == 1\n  tap a\nelse\n  tap b\nend\ntap c\n", []op{
+			{opJumpUnless, 3}, {opTap, 0}, {opJump, 4}, {opTap, 0}, {opTap, 0},
+		}},
+		{"else 없는 if", "if 1 == 1\n  tap a\nend\n", []op{
+			{opJumpUnless, 2}, {opTap, 0},
+		}},
+		{"repeat와 break/continue", "repeat 3\n  tap a\n  continue\n  break\nend\n", []op{
+			{opRepeatInit, 0}, {opTap, 0}, {opJump, 4}, {opJump, 5}, {opRepeatNext, 1},
+		}},
+		{"뒤로 가는 goto", "label top\ntap a\ngoto top\n", []op{
+			{opTap, 0}, {opJump, 0},
+		}},
+		{"앞으로 가는 goto", "goto skip\ntap a\nlabel skip\ntap b\n", []op{
+			{opJump, 2}, {opTap, 0}, {opTap, 0},
+		}},
+		{"바깥 반복으로 break", "loop outer\n  loop\n    break outer\n  end\nend\n", []op{
+			{opJump, 3}, {opJump, 0}, {opJump, 0},
+		}},
+	}
+	for _, tc := range cases {
+		script, err := ParseScript(tc.source)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		var got []op
+		for _, ins := range script.code {
+			got = append(got, op{ins.op, ins.target})
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: 명령 %v, 기대 %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		keys   string
+	}{
+		{"중첩 반복과 이름 있는 break", `
+set n = 0
+loop outer
+  repeat 2
+    tap a
+    wait 1s
+  end
+  inc n
+  if n >= 3
+    break outer
+  else
+    tap b
+  end
+end
+tap c
+`, "aabaabaac"},
+		{"goto로 만든 반복", `
+set n = 3
+label top
+tap a
+dec n
+if n > 0
+  goto top
+end
+tap b
+`, "aaab"},
+		{"continue와 계산식", `
+set i = 0
+repeat 4
+  inc i
+  set odd = i - 1
+  if odd == 0
+    continue
+  end
+  if i == 3
+    continue
+  end
+  tap x
+end
+`, "xx"},
+		{"stop", "tap a\nstop\ntap b\n", "a"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			script, err := ParseScript(tc.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			km, driver, _, _ := newTestKeyboard()
+			result, err := km.RunKeySequence(KeySequence{ID: "test", Script: script}, RunOptions{})
+			if err != nil || result.Status != RunCompleted {
+				t.Fatalf("실행 결과 %s (%s), 오류 %v", result.Status, result.Reason, err)
+			}
+			if got := strings.Join(driver.Keys(), ""); got != tc.keys {
+				t.Errorf("입력한 키 %q, 기대 %q", got, tc.keys)
+			}
+		})
+	}
+}
+
+func TestRunScriptReleasesHeldKeys(t *testing.T) {
+	script, err := ParseScript("hold shift\ntap a\nwait 2s\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	km, driver, _, start := newTestKeyboard()
+	result, _ := km.RunKeySequence(KeySequence{ID: "test", Script: script}, RunOptions{})
+	if result.Status != RunCompleted || result.KeysSent != 2 {
+		t.Fatalf("실행 결과 %+v", result)
+	}
+
+	events := driver.Events()
+	last := events[len(events)-1]
+	if last.Type != EventKeyUp || last.Key != "shift" {
+		t.Errorf("마지막 이벤트 %+v, 기대 shift 떼기", last)
+	}
+	// 시작 대기 300ms + 키 입력 대기 300ms + wait 2s
+	if got := last.Time.Sub(start); got != 2600*time.Millisecond {
+		t.Errorf("키를 뗀 시각 %v, 기대 2.6s", got)
+	}
+}
+
+func TestRunScriptIdleLimit(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		line   int
+	}{
+		{"goto 무한 루프", "label a\ngoto a\n", 2},
+		{"대기 없는 loop", "set n = 0\nloop\n  inc n\nend\n", 2}, // 반복으로 돌아가는 명령은 loop 줄
+	}
+	for _, tc := range cases {
+		script, err := ParseScript(tc.source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		km, _, _, _ := newTestKeyboard()
+		result, _ := km.RunKeySequence(KeySequence{ID: "test", Script: script}, RunOptions{})
+		if result.Status != RunStoppedByError || !strings.Contains(result.Reason, "무한 루프") {
+			t.Errorf("%s: 실행 결과 %s (%s)", tc.name, result.Status, result.Reason)
+			continue
+		}
+		if prefix := strconv.Itoa(tc.line) + "번째 줄"; !strings.HasPrefix(result.Reason, prefix) {
+			t.Errorf("%s: 오류 %q, 기대 %s", tc.name, result.Reason, prefix)
+		}
+	}
+
+	// 제한보다 적게 대기 없이 실행한 뒤 키를 누르면 정상 종료
+	script, err := ParseScript(fmt.Sprintf("set n = 0\nlabel top\ninc n\nif n < %d\n  goto top\nend\ntap a\n", maxIdleSteps/4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	km, driver, _, _ := newTestKeyboard()
+	result, _ := km.RunKeySequence(KeySequence{ID: "test", Script: script}, RunOptions{})
+	if result.Status != RunCompleted || strings.Join(driver.Keys(), "") != "a" {
+		t.Errorf("제한 안의 반복: %s (%s)", result.Status, result.Reason)
+	}
+}
@@ -2,11 +2,18 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
+	"image"
+	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
+	"example.com/m/notify"
+	"example.com/m/scheduler"
 	"example.com/m/telegram"
+	"example.com/m/utils"
 )
 
 // 버전 정보 (빌드 시 -ldflags로 주입됨)
@@ -17,26 +24,89 @@ var (
 
 // ConfigData는 저장할 설정 데이터 구조체입니다
 type ConfigData struct {
-	TelegramToken   string `json:"telegram_token"`
-	TelegramChatID  string `json:"telegram_chat_id"`
-	TelegramEnabled bool   `json:"telegram_enabled"`
-	DarkMode        bool   `json:"dark_mode"`
-	SoundEnabled    bool   `json:"sound_enabled"`
-	AutoStartup     bool   `json:"auto_startup"`
+	TelegramToken   string               `json:"telegram_token"`
+	TelegramChatID  string               `json:"telegram_chat_id"`
+	TelegramEnabled bool                 `json:"telegram_enabled"`
+	DarkMode        bool                 `json:"dark_mode"`
+	SoundEnabled    bool                 `json:"sound_enabled"`
+	AutoStartup     bool                 `json:"auto_startup"`
+	Hotkeys         map[string]string    `json:"hotkeys,omitempty"`
+	Schedules       []scheduler.Schedule `json:"schedules,omitempty"`
+	RunTime         string               `json:"run_time,omitempty"` // 마지막으로 선택한 실행 시간 ("3h10m", "04:30")
+	Screenshot      ScreenshotSettings   `json:"screenshot"`
+	TelegramEvents  []notify.Event       `json:"telegram_events,omitempty"`
+	TelegramTimeout int                  `json:"telegram_timeout,omitempty"` // 텔레그램 요청 제한 시간 (초)
+	Notifiers       []notify.Config      `json:"notifiers,omitempty"`
+	Language        string               `json:"language,omitempty"`         // 알림 메시지 언어 ("ko", "en")
+	TimeZone        string               `json:"time_zone,omitempty"`        // 메시지에 표시할 시간대 (IANA 이름)
+	ProgressMinutes int                  `json:"progress_minutes,omitempty"` // 진행 상황 알림 주기 (분, 0이면 끔)
+	Heartbeat       notify.Heartbeat     `json:"heartbeat,omitzero"`
+}
+
+// 화면 캡처 기본 최대 너비 (텔레그램 사진은 어차피 줄여서 보여 주므로 전송량을 줄임)
+const DefaultScreenshotMaxWidth = 1280
+
+// ScreenshotSettings는 텔레그램 알림에 첨부할 화면 캡처 설정입니다
+type ScreenshotSettings struct {
+	Enabled bool `json:"enabled"` // 완료, 오류 알림에 화면 캡처 첨부
+	// 캡처 영역 (너비와 높이가 0이면 전체 화면)
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	MaxWidth int `json:"max_width,omitempty"` // 이보다 넓으면 비율을 유지해 줄임 (0이면 원본 크기)
+}
+
+// Region은 캡처할 화면 영역을 반환합니다 (빈 영역이면 전체 화면)
+func (s ScreenshotSettings) Region() image.Rectangle {
+	if s.Width <= 0 || s.Height <= 0 {
+		return image.Rectangle{}
+	}
+	return image.Rect(s.X, s.Y, s.X+s.Width, s.Y+s.Height)
+}
+
+// Validate는 설정 값이 올바른지 확인합니다
+func (s ScreenshotSettings) Validate() error {
+	if s.X < 0 || s.Y < 0 || s.Width < 0 || s.Height < 0 {
+		return fmt.Errorf("캡처 영역은 0 이상이어야 합니다")
+	}
+	if (s.Width == 0) != (s.Height == 0) {
+		return fmt.Errorf("캡처 영역의 너비와 높이를 모두 입력하거나 모두 비워 주세요")
+	}
+	if s.MaxWidth < 0 {
+		return fmt.Errorf("최대 너비는 0 이상이어야 합니다")
+	}
+	return nil
 }
 
 // AppConfig는 애플리케이션 설정을 관리합니다
 type AppConfig struct {
-	DevelopmentMode bool
-	Version         string
-	BuildDate       string
-	TelegramBot     *telegram.TelegramBot
-	TelegramEnabled bool
-	DarkMode        bool
-	SoundEnabled    bool
-	AutoStartup     bool
-	configFilePath  string
-	logFilePath     string
+	DevelopmentMode  bool
+	Version          string
+	BuildDate        string
+	TelegramBot      *telegram.TelegramBot
+	TelegramDelivery *telegram.Delivery  // 텔레그램 재시도와 보관함 (봇을 새로 만들어도 유지)
+	MessageTemplates *telegram.Templates // 텔레그램 메시지 언어, 시간대, 템플릿 (봇을 새로 만들어도 유지)
+	TelegramEnabled  bool
+	DarkMode         bool
+	SoundEnabled     bool
+	AutoStartup      bool
+	Hotkeys          map[string]string    // 동작별 전역 단축키 (nil이면 기본값 사용)
+	Schedules        []scheduler.Schedule // 예약 작업
+	RunTime          utils.RunTime        // 작업 실행 시간
+	Screenshot       ScreenshotSettings   // 알림에 첨부할 화면 캡처
+	TelegramEvents   []notify.Event       // 텔레그램으로 보낼 알림 (비어 있으면 모두)
+	Notifiers        []notify.Config      // 텔레그램 외의 알림 대상
+	Language         string               // 알림 메시지 언어 (텔레그램과 다른 알림 대상)
+	TimeZone         string               // 메시지에 표시할 시간대
+	ProgressInterval time.Duration        // 진행 상황 알림 주기 (0이면 보내지 않음)
+	Heartbeat        notify.Heartbeat     // 실행 중 생존 신호
+	configFilePath   string
+	logFilePath      string
+	sequencesDir     string
+	historyFilePath  string
+	outboxFilePath   string
 }
 
 // NewAppConfig는 새로운 앱 설정을 생성합니다
@@ -50,11 +120,20 @@ func NewAppConfig() *AppConfig {
 		DarkMode:        true,  // 기본값: 다크모드 켜짐
 		SoundEnabled:    true,  // 기본값: 소리 켜짐
 		AutoStartup:     false, // 기본값: 자동시작 꺼짐
+		RunTime:         utils.DefaultRunTime,
+		Screenshot:      ScreenshotSettings{Enabled: true, MaxWidth: DefaultScreenshotMaxWidth},
 	}
 
 	// 경로 설정
 	cfg.configFilePath = getConfigFilePath()
 	cfg.logFilePath = getLogFilePath()
+	cfg.sequencesDir = getSequencesDir()
+	cfg.historyFilePath = getHistoryFilePath()
+	cfg.outboxFilePath = getOutboxFilePath()
+	cfg.TelegramDelivery = telegram.NewDelivery(cfg.outboxFilePath)
+	cfg.MessageTemplates = telegram.NewTemplates(getTemplatesDir())
+	cfg.Language = telegram.LanguageKorean
+	cfg.TimeZone = telegram.DefaultTimeZone
 
 	// 개발 모드 확인 (dev 태그로 빌드된 경우)
 	if Version == "dev" {
@@ -118,11 +197,45 @@ func getLogFilePath() string {
 	return filepath.Join(appDataDir, "app.log")
 }
 
+// getSequencesDir는 사용자 키 시퀀스 파일 디렉토리 경로를 반환합니다
+func getSequencesDir() string {
+	appDataDir := getAppDataDir()
+	return filepath.Join(appDataDir, "sequences")
+}
+
+// getHistoryFilePath는 실행 기록 파일 경로를 반환합니다
+func getHistoryFilePath() string {
+	appDataDir := getAppDataDir()
+	return filepath.Join(appDataDir, "history.jsonl")
+}
+
+// getOutboxFilePath는 보내지 못한 텔레그램 메시지 보관함 파일 경로를 반환합니다
+func getOutboxFilePath() string {
+	appDataDir := getAppDataDir()
+	return filepath.Join(appDataDir, "telegram_outbox.json")
+}
+
+// getTemplatesDir는 사용자 메시지 템플릿 디렉토리 경로를 반환합니다
+func getTemplatesDir() string {
+	appDataDir := getAppDataDir()
+	return filepath.Join(appDataDir, "templates")
+}
+
 // GetLogFilePath는 외부에서 로그 파일 경로를 가져올 수 있도록 합니다
 func (cfg *AppConfig) GetLogFilePath() string {
 	return cfg.logFilePath
 }
 
+// GetSequencesDir는 외부에서 키 시퀀스 디렉토리 경로를 가져올 수 있도록 합니다
+func (cfg *AppConfig) GetSequencesDir() string {
+	return cfg.sequencesDir
+}
+
+// GetHistoryFilePath는 외부에서 실행 기록 파일 경로를 가져올 수 있도록 합니다
+func (cfg *AppConfig) GetHistoryFilePath() string {
+	return cfg.historyFilePath
+}
+
 // dirExists는 디렉토리 존재 여부를 확인합니다
 func dirExists(dirPath string) bool {
 	info, err := os.Stat(dirPath)
@@ -145,8 +258,8 @@ func (cfg *AppConfig) LoadSettings() error {
 		return err
 	}
 
-	// JSON 파싱
-	var configData ConfigData
+	// JSON 파싱 (예전 설정 파일에 없는 항목은 기본값 유지)
+	configData := ConfigData{Screenshot: cfg.Screenshot}
 	if err := json.Unmarshal(data, &configData); err != nil {
 		return err
 	}
@@ -156,10 +269,40 @@ func (cfg *AppConfig) LoadSettings() error {
 	cfg.DarkMode = configData.DarkMode
 	cfg.SoundEnabled = configData.SoundEnabled
 	cfg.AutoStartup = configData.AutoStartup
+	cfg.Hotkeys = configData.Hotkeys
+	cfg.Schedules = configData.Schedules
+	cfg.TelegramEvents = configData.TelegramEvents
+	if configData.TelegramTimeout > 0 && cfg.TelegramDelivery != nil {
+		cfg.TelegramDelivery.Timeout = time.Duration(configData.TelegramTimeout) * time.Second
+	}
+	cfg.Notifiers = configData.Notifiers
+	if configData.Language != "" {
+		cfg.Language = configData.Language
+	}
+	if configData.TimeZone != "" {
+		cfg.TimeZone = configData.TimeZone
+	}
+	if configData.ProgressMinutes > 0 {
+		cfg.ProgressInterval = time.Duration(configData.ProgressMinutes) * time.Minute
+	}
+	if configData.Heartbeat.Validate() == nil && configData.Heartbeat.ValidateFile(getAppDataDir()) == nil {
+		cfg.Heartbeat = configData.Heartbeat
+	}
+	if err := cfg.ReloadTemplates(); err != nil {
+		log.Printf("경고: %v", err)
+	}
+	if configData.Screenshot.Validate() == nil {
+		cfg.Screenshot = configData.Screenshot
+	}
+	if configData.RunTime != "" {
+		if runTime, err := utils.ParseRunTime(configData.RunTime); err == nil && !runTime.IsZero() {
+			cfg.RunTime = runTime
+		}
+	}
 
 	// 텔레그램 봇 초기화
 	if configData.TelegramToken != "" && configData.TelegramChatID != "" {
-		cfg.TelegramBot = telegram.NewTelegramBot(configData.TelegramToken, configData.TelegramChatID)
+		cfg.TelegramBot = cfg.newTelegramBot(configData.TelegramToken, configData.TelegramChatID)
 		cfg.TelegramEnabled = true
 	}
 
@@ -174,6 +317,19 @@ func (cfg *AppConfig) SaveSettings() error {
 		DarkMode:        cfg.DarkMode,
 		SoundEnabled:    cfg.SoundEnabled,
 		AutoStartup:     cfg.AutoStartup,
+		Hotkeys:         cfg.Hotkeys,
+		Schedules:       cfg.Schedules,
+		RunTime:         cfg.RunTime.String(),
+		Screenshot:      cfg.Screenshot,
+		TelegramEvents:  cfg.TelegramEvents,
+		Notifiers:       cfg.Notifiers,
+		Language:        cfg.Language,
+		TimeZone:        cfg.TimeZone,
+		ProgressMinutes: int(cfg.ProgressInterval / time.Minute),
+		Heartbeat:       cfg.Heartbeat,
+	}
+	if cfg.TelegramDelivery != nil && cfg.TelegramDelivery.Timeout != telegram.DefaultTimeout {
+		configData.TelegramTimeout = int(cfg.TelegramDelivery.Timeout / time.Second)
 	}
 
 	// 텔레그램 설정 저장
@@ -195,7 +351,7 @@ func (cfg *AppConfig) SaveSettings() error {
 // SetTelegramConfig는 텔레그램 설정을 업데이트하고 저장합니다
 func (cfg *AppConfig) SetTelegramConfig(token, chatID string) error {
 	if token != "" && chatID != "" {
-		cfg.TelegramBot = telegram.NewTelegramBot(token, chatID)
+		cfg.TelegramBot = cfg.newTelegramBot(token, chatID)
 		cfg.TelegramEnabled = true
 	} else {
 		cfg.TelegramEnabled = false
@@ -206,6 +362,62 @@ func (cfg *AppConfig) SetTelegramConfig(token, chatID string) error {
 	return cfg.SaveSettings()
 }
 
+// SetTelegramTimeout은 텔레그램 요청 제한 시간(초)을 업데이트하고 저장합니다
+func (cfg *AppConfig) SetTelegramTimeout(seconds int) error {
+	if seconds < 1 || seconds > 120 {
+		return fmt.Errorf("요청 제한 시간은 1초에서 120초 사이여야 합니다")
+	}
+	if cfg.TelegramDelivery == nil {
+		cfg.TelegramDelivery = telegram.NewDelivery(cfg.outboxFilePath)
+	}
+	cfg.TelegramDelivery.Timeout = time.Duration(seconds) * time.Second
+	return cfg.SaveSettings()
+}
+
+// newTelegramBot은 재시도와 보관함을 공유하는 텔레그램 봇을 생성합니다
+func (cfg *AppConfig) newTelegramBot(token, chatID string) *telegram.TelegramBot {
+	bot := telegram.NewTelegramBot(token, chatID)
+	bot.Delivery = cfg.TelegramDelivery
+	bot.Templates = cfg.MessageTemplates
+	return bot
+}
+
+// SetMessageSettings는 텔레그램 메시지 언어와 시간대를 확인하고 저장합니다
+// 사용자 템플릿도 다시 읽으며, 템플릿 오류는 설정을 저장한 뒤 반환합니다
+func (cfg *AppConfig) SetMessageSettings(language, timeZone string) error {
+	if !telegram.ValidLanguage(language) {
+		return fmt.Errorf("지원하지 않는 언어입니다: %s", language)
+	}
+	if _, err := telegram.LoadLocation(timeZone); err != nil {
+		return err
+	}
+	cfg.Language = language
+	cfg.TimeZone = timeZone
+	if err := cfg.SaveSettings(); err != nil {
+		return err
+	}
+	return cfg.ReloadTemplates()
+}
+
+// ReloadTemplates는 현재 언어와 시간대로 메시지 템플릿을 다시 읽습니다
+// 설정 값이 잘못되었으면 기본 언어와 시간대를 사용합니다
+func (cfg *AppConfig) ReloadTemplates() error {
+	if cfg.MessageTemplates == nil {
+		cfg.MessageTemplates = telegram.NewTemplates(getTemplatesDir())
+	}
+	language := cfg.Language
+	if !telegram.ValidLanguage(language) {
+		log.Printf("경고: 지원하지 않는 언어입니다: %s (한국어 사용)", language)
+		language = telegram.LanguageKorean
+	}
+	location, err := telegram.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.Printf("경고: %v (%s 사용)", err, telegram.DefaultTimeZone)
+		location = nil
+	}
+	return cfg.MessageTemplates.Configure(language, location)
+}
+
 // SetDarkMode는 다크모드 설정을 업데이트하고 저장합니다
 func (cfg *AppConfig) SetDarkMode(enabled bool) error {
 	cfg.DarkMode = enabled
@@ -230,6 +442,92 @@ func (cfg *AppConfig) SetTelegramEnabled(enabled bool) error {
 	return cfg.SaveSettings()
 }
 
+// SetHotkeys는 전역 단축키 설정을 업데이트하고 저장합니다
+func (cfg *AppConfig) SetHotkeys(hotkeys map[string]string) error {
+	cfg.Hotkeys = hotkeys
+	return cfg.SaveSettings()
+}
+
+// SetSchedules는 예약 작업 목록을 업데이트하고 저장합니다
+func (cfg *AppConfig) SetSchedules(schedules []scheduler.Schedule) error {
+	cfg.Schedules = schedules
+	return cfg.SaveSettings()
+}
+
+// SetRunTime은 작업 실행 시간 설정을 업데이트하고 저장합니다
+func (cfg *AppConfig) SetRunTime(runTime utils.RunTime) error {
+	cfg.RunTime = runTime
+	return cfg.SaveSettings()
+}
+
+// SetScreenshot은 화면 캡처 설정을 확인하고 저장합니다
+func (cfg *AppConfig) SetScreenshot(settings ScreenshotSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	cfg.Screenshot = settings
+	return cfg.SaveSettings()
+}
+
+// SetNotifiers는 텔레그램 알림 이벤트와 추가 알림 대상을 확인하고 저장합니다
+func (cfg *AppConfig) SetNotifiers(telegramEvents []notify.Event, notifiers []notify.Config) error {
+	if err := notify.ValidateEvents(telegramEvents); err != nil {
+		return err
+	}
+	for i, notifier := range notifiers {
+		if err := notifier.Validate(); err != nil {
+			return fmt.Errorf("%d번째 알림 대상: %v", i+1, err)
+		}
+	}
+
+	cfg.TelegramEvents = telegramEvents
+	cfg.Notifiers = notifiers
+	return cfg.SaveSettings()
+}
+
+// SetProgressInterval은 진행 상황 알림 주기(분)를 업데이트하고 저장합니다 (0이면 끔)
+func (cfg *AppConfig) SetProgressInterval(minutes int) error {
+	if minutes < 0 || minutes > 24*60 {
+		return fmt.Errorf("진행 상황 알림 주기는 0분(끔)에서 1440분 사이여야 합니다")
+	}
+	cfg.ProgressInterval = time.Duration(minutes) * time.Minute
+	return cfg.SaveSettings()
+}
+
+// SetHeartbeat는 생존 신호 설정을 확인하고 저장합니다
+// 생존 신호 파일은 앱 데이터 디렉토리 안에만 만들 수 있습니다
+func (cfg *AppConfig) SetHeartbeat(heartbeat notify.Heartbeat) error {
+	if err := heartbeat.Validate(); err != nil {
+		return err
+	}
+	if err := heartbeat.ValidateFile(getAppDataDir()); err != nil {
+		return err
+	}
+	cfg.Heartbeat = heartbeat
+	return cfg.SaveSettings()
+}
+
+// NotifyTargets는 알림을 보낼 대상 목록을 반환합니다 (켜져 있는 텔레그램 봇과 추가 알림 대상)
+// 추가 알림 대상의 문구도 텔레그램 메시지 언어를 따릅니다
+func (cfg *AppConfig) NotifyTargets() []notify.Target {
+	var targets []notify.Target
+	if cfg.TelegramEnabled && cfg.TelegramBot != nil {
+		targets = append(targets, notify.Target{Name: "텔레그램", Notifier: cfg.TelegramBot, Events: cfg.TelegramEvents})
+	}
+	for _, notifier := range cfg.Notifiers {
+		if !notifier.Enabled {
+			continue
+		}
+		target, err := notifier.Target(cfg.Language)
+		if err != nil {
+			log.Printf("알림 대상 %s 설정 오류: %v", notifier.Name, err)
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
 // GetModeText는 현재 모드의 텍스트 표현을 반환합니다
 func (cfg *AppConfig) GetModeText() string {
 	if cfg.DevelopmentMode {
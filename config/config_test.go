@@ -0,0 +1,36 @@
+package config
+
+import (
+	"image"
+	"testing"
+)
+
+func TestScreenshotSettingsValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings ScreenshotSettings
+		valid    bool
+		region   image.Rectangle
+	}{
+		{"전체 화면", ScreenshotSettings{}, true, image.Rectangle{}},
+		{"영역", ScreenshotSettings{X: 10, Y: 20, Width: 300, Height: 200}, true, image.Rect(10, 20, 310, 220)},
+		{"왼쪽 위 영역", ScreenshotSettings{Width: 1, Height: 1, MaxWidth: 800}, true, image.Rect(0, 0, 1, 1)},
+		{"위치만 있고 크기는 없음", ScreenshotSettings{X: 10, Y: 20}, true, image.Rectangle{}},
+		{"음수 X", ScreenshotSettings{X: -1, Width: 10, Height: 10}, false, image.Rectangle{}},
+		{"음수 Y", ScreenshotSettings{Y: -1, Width: 10, Height: 10}, false, image.Rectangle{}},
+		{"음수 너비", ScreenshotSettings{Width: -10, Height: 10}, false, image.Rectangle{}},
+		{"음수 높이", ScreenshotSettings{Width: 10, Height: -10}, false, image.Rectangle{}},
+		{"너비만 있음", ScreenshotSettings{Width: 10}, false, image.Rectangle{}},
+		{"높이만 있음", ScreenshotSettings{Height: 10}, false, image.Rectangle{}},
+		{"음수 최대 너비", ScreenshotSettings{MaxWidth: -1}, false, image.Rectangle{}},
+	}
+	for _, tc := range cases {
+		err := tc.settings.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("%s: 오류 %v", tc.name, err)
+		}
+		if tc.valid && tc.settings.Region() != tc.region {
+			t.Errorf("%s: 영역 %v, 기대 %v", tc.name, tc.settings.Region(), tc.region)
+		}
+	}
+}
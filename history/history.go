@@ -0,0 +1,185 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 조회 한 번에 반환하는 기록 수
+const (
+	DefaultLimit = 20
+	MaxLimit     = 200
+)
+
+// Session은 작업 실행 한 번의 기록입니다
+type Session struct {
+	ID             string    `json:"id"`
+	Mode           string    `json:"mode"` // 시퀀스 ID
+	ModeName       string    `json:"mode_name"`
+	Trigger        string    `json:"trigger,omitempty"` // 작업을 시작한 주체
+	StartedAt      time.Time `json:"started_at"`
+	EndedAt        time.Time `json:"ended_at"`
+	PlannedSeconds int64     `json:"planned_seconds"` // 설정된 실행 시간 (0이면 제한 없음)
+	ActualSeconds  int64     `json:"actual_seconds"`  // 일시정지를 제외한 실제 실행 시간
+	Status         string    `json:"status"`          // 실행 결과 (automation.RunStatus)
+	StopTrigger    string    `json:"stop_trigger,omitempty"`
+	StopReason     string    `json:"stop_reason,omitempty"`
+	Iterations     int       `json:"iterations"`
+	KeysSent       int       `json:"keys_sent"`
+	Errors         []string  `json:"errors,omitempty"`
+
+	PlannedIterations int `json:"planned_iterations,omitempty"` // 설정된 반복 횟수 제한 (0이면 제한 없음)
+}
+
+// Query는 기록 조회 조건입니다 (비어 있는 조건은 적용하지 않음)
+type Query struct {
+	Mode   string
+	Status string
+	Since  time.Time // 이 시각 이후에 시작한 기록
+	Until  time.Time // 이 시각 이전에 시작한 기록
+	Offset int
+	Limit  int
+}
+
+// Page는 조회 결과의 한 페이지입니다
+type Page struct {
+	Total    int       `json:"total"` // 조건에 맞는 전체 기록 수
+	Offset   int       `json:"offset"`
+	Limit    int       `json:"limit"`
+	Sessions []Session `json:"sessions"` // 최근 기록부터
+}
+
+// Store는 실행 기록을 JSONL 파일(한 줄에 한 기록)에 추가 방식으로 저장합니다
+type Store struct {
+	path     string
+	sessions []Session
+	partial  bool // 파일이 줄바꿈 없이 끝남
+	mutex    sync.RWMutex
+}
+
+// NewStore는 기존 기록을 읽지 않고 빈 저장소를 생성합니다 (새 기록은 path에 추가됨)
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Open은 기록 파일을 읽어 저장소를 생성합니다 (파일이 없으면 빈 저장소)
+// 비정상 종료로 잘린 줄처럼 해석할 수 없는 줄은 건너뜁니다
+func Open(path string) (*Store, error) {
+	store := NewStore(path)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("기록 파일을 열 수 없습니다: %v", err)
+	}
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var session Session
+		if err := json.Unmarshal(line, &session); err != nil || session.ID == "" {
+			continue
+		}
+		store.sessions = append(store.sessions, session)
+	}
+
+	// 마지막 줄이 잘려 있으면 다음 기록은 새 줄에서 시작
+	store.partial = len(data) > 0 && data[len(data)-1] != '\n'
+
+	return store, nil
+}
+
+// Add는 기록을 파일 끝에 추가합니다
+// ID가 비어 있으면 시작 시각으로 만든 ID를 지정합니다
+func (s *Store) Add(session Session) (Session, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if session.ID == "" {
+		session.ID = fmt.Sprintf("%s-%d", session.StartedAt.Format("20060102-150405"), len(s.sessions)+1)
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return session, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return session, fmt.Errorf("기록 디렉토리 생성 실패: %v", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return session, fmt.Errorf("기록 파일을 열 수 없습니다: %v", err)
+	}
+	defer f.Close()
+
+	line := append(data, '\n')
+	if s.partial {
+		line = append([]byte{'\n'}, line...)
+	}
+	if _, err := f.Write(line); err != nil {
+		return session, fmt.Errorf("기록 저장 실패: %v", err)
+	}
+	s.partial = false
+
+	s.sessions = append(s.sessions, session)
+	return session, nil
+}
+
+// Query는 조건에 맞는 기록을 최근 기록부터 페이지 단위로 반환합니다
+func (s *Store) Query(q Query) Page {
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	page := Page{Offset: q.Offset, Limit: q.Limit, Sessions: []Session{}}
+	for i := len(s.sessions) - 1; i >= 0; i-- {
+		session := s.sessions[i]
+		if !q.matches(session) {
+			continue
+		}
+		if page.Total >= q.Offset && len(page.Sessions) < q.Limit {
+			page.Sessions = append(page.Sessions, session)
+		}
+		page.Total++
+	}
+	return page
+}
+
+// Sessions는 모든 기록을 저장된 순서대로 반환합니다
+func (s *Store) Sessions() []Session {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return append([]Session(nil), s.sessions...)
+}
+
+// matches는 기록이 조회 조건에 맞는지 확인합니다
+func (q Query) matches(session Session) bool {
+	if q.Mode != "" && session.Mode != q.Mode {
+		return false
+	}
+	if q.Status != "" && session.Status != q.Status {
+		return false
+	}
+	if !q.Since.IsZero() && session.StartedAt.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !session.StartedAt.Before(q.Until) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSession(mode, status string, started time.Time) Session {
+	return Session{Mode: mode, ModeName: strings.ToUpper(mode), StartedAt: started, EndedAt: started.Add(time.Hour), ActualSeconds: 3600, Status: status}
+}
+
+func TestOpenMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "history.jsonl")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Sessions()) != 0 {
+		t.Fatalf("기록 %d개", len(store.Sessions()))
+	}
+
+	// 디렉토리가 없어도 첫 기록을 저장할 때 생성
+	session, err := store.Add(testSession("a", statusCompleted, kstTime(10, 14, 9, 5)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.ID != "20261014-090500-1" {
+		t.Errorf("ID %q", session.ID)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := store.Add(testSession("a", statusCompleted, kstTime(10, 14, 9, 0)))
+	second := testSession("b", statusFailed, kstTime(10, 14, 10, 0))
+	second.ID = "custom"
+	second.Errors = []string{"키 입력 실패"}
+	if _, err := store.Add(second); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessions := reopened.Sessions()
+	if len(sessions) != 2 || sessions[0].ID != first.ID || sessions[1].ID != "custom" || sessions[1].Errors[0] != "키 입력 실패" {
+		t.Fatalf("다시 읽은 기록 %+v", sessions)
+	}
+	if !sessions[0].StartedAt.Equal(first.StartedAt) {
+		t.Errorf("시작 시각 %v, 기대 %v", sessions[0].StartedAt, first.StartedAt)
+	}
+}
+
+func TestAddAfterTruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, _ := Open(path)
+	store.Add(testSession("a", statusCompleted, kstTime(10, 14, 9, 0)))
+	store.Add(testSession("a", statusCompleted, kstTime(10, 14, 10, 0)))
+
+	// 비정상 종료로 마지막 줄이 잘린 파일
+	data, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, data[:len(data)-20], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Sessions()) != 1 {
+		t.Fatalf("잘린 줄을 건너뛰고 기록 1개가 남아야 합니다: %d개", len(store.Sessions()))
+	}
+	if _, err := store.Add(testSession("b", statusStopped, kstTime(10, 14, 11, 0))); err != nil {
+		t.Fatal(err)
+	}
+
+	// 새 기록은 잘린 줄에 이어 붙지 않고 새 줄에 저장
+	reopened, _ := Open(path)
+	sessions := reopened.Sessions()
+	if len(sessions) != 2 || sessions[1].Mode != "b" {
+		t.Fatalf("다시 읽은 기록 %+v", sessions)
+	}
+	data, _ = os.ReadFile(path)
+	if lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"); len(lines) != 3 {
+		t.Errorf("파일 줄 수 %d, 기대 3 (기록 2개와 잘린 줄)", len(lines))
+	}
+}
+
+func TestQuery(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "history.jsonl"))
+	for i := 0; i < 30; i++ {
+		mode, status := "a", statusCompleted
+		if i%3 == 0 {
+			mode = "b"
+		}
+		if i%5 == 0 {
+			status = statusFailed
+		}
+		store.Add(testSession(mode, status, kstTime(10, 1, 0, 0).Add(time.Duration(i)*time.Hour)))
+	}
+
+	ids := func(page Page) []string {
+		var result []string
+		for _, session := range page.Sessions {
+			result = append(result, session.StartedAt.Format("02 15"))
+		}
+		return result
+	}
+
+	cases := []struct {
+		name  string
+		query Query
+		total int
+		first string // 첫 기록 시작 시각 ("일 시")
+		count int
+	}{
+		{"기본 페이지", Query{}, 30, "02 05", DefaultLimit},
+		{"두 번째 페이지", Query{Offset: 20, Limit: 20}, 30, "01 09", 10},
+		{"범위를 넘은 offset", Query{Offset: 40}, 30, "", 0},
+		{"음수 offset", Query{Offset: -5, Limit: 1}, 30, "02 05", 1},
+		{"최대 개수 제한", Query{Limit: MaxLimit + 100}, 30, "02 05", 30},
+		{"모드", Query{Mode: "b"}, 10, "02 03", 10},
+		{"상태", Query{Status: statusFailed}, 6, "02 01", 6},
+		{"모드와 상태", Query{Mode: "b", Status: statusFailed}, 2, "01 15", 2},
+		{"기간", Query{Since: kstTime(10, 1, 10, 0), Until: kstTime(10, 1, 12, 0)}, 2, "01 11", 2},
+		{"없는 모드", Query{Mode: "c"}, 0, "", 0},
+	}
+	for _, c := range cases {
+		page := store.Query(c.query)
+		got := ids(page)
+		first := ""
+		if len(got) > 0 {
+			first = got[0]
+		}
+		if page.Total != c.total || len(got) != c.count || first != c.first {
+			t.Errorf("%s: 전체 %d, %d개, 첫 기록 %q (기대 %d, %d개, %q)", c.name, page.Total, len(got), first, c.total, c.count, c.first)
+		}
+	}
+
+	if page := store.Query(Query{Offset: -5, Limit: -1}); page.Offset != 0 || page.Limit != DefaultLimit {
+		t.Errorf("정규화된 조회 조건 %d, %d", page.Offset, page.Limit)
+	}
+}
@@ -0,0 +1,172 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// 실행 결과 (automation.RunStatus와 같은 값)
+const (
+	statusCompleted = "completed"
+	statusTimedOut  = "timed_out"
+	statusStopped   = "stopped_by_user"
+	statusFailed    = "stopped_by_error"
+)
+
+// Totals는 여러 실행 기록의 합계입니다
+type Totals struct {
+	Runs           int     `json:"runs"`
+	Succeeded      int     `json:"succeeded"` // 끝까지 실행되었거나 설정한 시간만큼 실행됨
+	Aborted        int     `json:"aborted"`   // 사용자가 중지함
+	Failed         int     `json:"failed"`    // 오류로 중지됨
+	SuccessRate    float64 `json:"success_rate"`
+	ActiveSeconds  int64   `json:"active_seconds"`
+	Iterations     int     `json:"iterations"`
+	KeysSent       int     `json:"keys_sent"`
+	AvgLoopSeconds float64 `json:"avg_loop_seconds"` // 반복 한 바퀴의 평균 시간
+
+	loopSeconds int64 // 반복 기록이 있는 실행의 시간 합
+}
+
+// ModeStats는 모드(시퀀스)별 합계입니다
+type ModeStats struct {
+	Mode     string `json:"mode"`
+	ModeName string `json:"mode_name"`
+	Totals
+}
+
+// PeriodStats는 하루 또는 한 주의 합계입니다
+type PeriodStats struct {
+	Start       string           `json:"start"` // 기간 시작일 (YYYY-MM-DD)
+	ModeSeconds map[string]int64 `json:"mode_seconds"`
+	Totals
+}
+
+// Stats는 기간 동안의 실행 통계입니다
+type Stats struct {
+	From   time.Time     `json:"from"`
+	To     time.Time     `json:"to"`
+	Total  Totals        `json:"total"`
+	Modes  []ModeStats   `json:"modes"`  // 실행 시간이 긴 순서
+	Daily  []PeriodStats `json:"daily"`  // 날짜순 (실행이 없는 날 포함)
+	Weekly []PeriodStats `json:"weekly"` // 월요일 시작 주 단위 (기간 중간에 시작하는 첫 주는 from의 날짜)
+}
+
+// add는 실행 기록 하나를 합계에 더합니다
+func (t *Totals) add(session Session) {
+	t.Runs++
+	switch session.Status {
+	case statusCompleted, statusTimedOut:
+		t.Succeeded++
+	case statusStopped:
+		t.Aborted++
+	case statusFailed:
+		t.Failed++
+	}
+	t.ActiveSeconds += session.ActualSeconds
+	t.Iterations += session.Iterations
+	t.KeysSent += session.KeysSent
+	if session.Iterations > 0 {
+		t.loopSeconds += session.ActualSeconds
+	}
+}
+
+// finish는 비율과 평균을 계산합니다
+func (t *Totals) finish() {
+	if t.Runs > 0 {
+		t.SuccessRate = float64(t.Succeeded) / float64(t.Runs)
+	}
+	if t.Iterations > 0 {
+		t.AvgLoopSeconds = float64(t.loopSeconds) / float64(t.Iterations)
+	}
+}
+
+// Summarize는 [from, to) 기간에 시작한 기록을 모드별, 일별, 주별로 집계합니다
+// 날짜 구분은 from의 시간대를 따르며, 자정을 넘긴 실행은 시작한 날에 포함됩니다
+func Summarize(sessions []Session, from, to time.Time) Stats {
+	loc := from.Location()
+	stats := Stats{From: from, To: to, Modes: []ModeStats{}}
+
+	// 기간의 모든 날과 주를 미리 만들어 실행이 없는 날도 0으로 표시
+	daily := make(map[string]*PeriodStats)
+	weekly := make(map[string]*PeriodStats)
+	var weeks []string
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		daily[key] = &PeriodStats{Start: key, ModeSeconds: map[string]int64{}}
+		stats.Daily = append(stats.Daily, PeriodStats{Start: key})
+
+		week := startOfWeek(day).Format("2006-01-02")
+		if _, ok := weekly[week]; !ok {
+			// 첫 주는 from 이후의 기록만 담으므로 주의 시작일 대신 from의 날짜로 표시
+			weekly[week] = &PeriodStats{Start: key, ModeSeconds: map[string]int64{}}
+			weeks = append(weeks, week)
+		}
+	}
+
+	modes := make(map[string]*ModeStats)
+	for _, session := range sessions {
+		started := session.StartedAt.In(loc)
+		if started.Before(from) || !started.Before(to) {
+			continue
+		}
+
+		stats.Total.add(session)
+
+		mode, ok := modes[session.Mode]
+		if !ok {
+			mode = &ModeStats{Mode: session.Mode}
+			modes[session.Mode] = mode
+		}
+		mode.ModeName = session.ModeName
+		mode.add(session)
+
+		for _, period := range []*PeriodStats{
+			daily[startOfDay(started).Format("2006-01-02")],
+			weekly[startOfWeek(started).Format("2006-01-02")],
+		} {
+			if period != nil {
+				period.add(session)
+				period.ModeSeconds[session.Mode] += session.ActualSeconds
+			}
+		}
+	}
+
+	stats.Total.finish()
+	for _, mode := range modes {
+		mode.finish()
+		stats.Modes = append(stats.Modes, *mode)
+	}
+	sort.Slice(stats.Modes, func(i, j int) bool {
+		if stats.Modes[i].ActiveSeconds != stats.Modes[j].ActiveSeconds {
+			return stats.Modes[i].ActiveSeconds > stats.Modes[j].ActiveSeconds
+		}
+		return stats.Modes[i].Mode < stats.Modes[j].Mode
+	})
+
+	for i := range stats.Daily {
+		period := daily[stats.Daily[i].Start]
+		period.finish()
+		stats.Daily[i] = *period
+	}
+	for _, week := range weeks {
+		period := weekly[week]
+		period.finish()
+		stats.Weekly = append(stats.Weekly, *period)
+	}
+
+	return stats
+}
+
+// startOfDay는 같은 시간대에서 그날 0시를 반환합니다
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek는 그 주 월요일 0시를 반환합니다
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7 // 월요일 = 0
+	return day.AddDate(0, 0, -offset)
+}
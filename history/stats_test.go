@@ -0,0 +1,104 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var kst = time.FixedZone("KST", 9*3600)
+
+func kstTime(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2026, month, day, hour, minute, 0, 0, kst)
+}
+
+// 2026-10-14(수) 12:00부터 2026-10-21(수) 0시 전까지의 테스트 기록
+var statsSessions = []Session{
+	{Mode: "a", StartedAt: kstTime(10, 14, 11, 59), ActualSeconds: 999, Status: statusCompleted}, // from 이전
+	{Mode: "a", ModeName: "A", StartedAt: kstTime(10, 14, 12, 0), ActualSeconds: 3600, Status: statusCompleted, Iterations: 10, KeysSent: 40},
+	// 자정을 넘긴 실행은 시작한 날에 포함
+	{Mode: "b", ModeName: "B", StartedAt: kstTime(10, 14, 23, 30), EndedAt: kstTime(10, 15, 1, 0), ActualSeconds: 5400, Status: statusTimedOut, Iterations: 5},
+	{Mode: "a", ModeName: "A", StartedAt: kstTime(10, 19, 10, 0), ActualSeconds: 600, Status: statusStopped},
+	// UTC로 저장된 기록은 from의 시간대 날짜로 구분 (한국 시간 10월 19일 1시)
+	{Mode: "b", ModeName: "B", StartedAt: time.Date(2026, 10, 18, 16, 0, 0, 0, time.UTC), ActualSeconds: 1200, Status: statusFailed},
+	{Mode: "b", StartedAt: kstTime(10, 21, 0, 0), ActualSeconds: 999, Status: statusCompleted}, // to와 같은 시각
+}
+
+func TestSummarizeTotals(t *testing.T) {
+	stats := Summarize(statsSessions, kstTime(10, 14, 12, 0), kstTime(10, 21, 0, 0))
+
+	want := Totals{Runs: 4, Succeeded: 2, Aborted: 1, Failed: 1, SuccessRate: 0.5, ActiveSeconds: 10800, Iterations: 15, KeysSent: 40, AvgLoopSeconds: 600, loopSeconds: 9000}
+	if stats.Total != want {
+		t.Errorf("합계 %+v\n기대 %+v", stats.Total, want)
+	}
+
+	modes := []struct {
+		mode    string
+		name    string
+		runs    int
+		seconds int64
+	}{
+		{"b", "B", 2, 6600}, // 실행 시간이 긴 순서
+		{"a", "A", 2, 4200},
+	}
+	if len(stats.Modes) != len(modes) {
+		t.Fatalf("모드 %d개: %+v", len(stats.Modes), stats.Modes)
+	}
+	for i, m := range modes {
+		got := stats.Modes[i]
+		if got.Mode != m.mode || got.ModeName != m.name || got.Runs != m.runs || got.ActiveSeconds != m.seconds {
+			t.Errorf("%d번째 모드 %+v, 기대 %+v", i, got, m)
+		}
+	}
+}
+
+func TestSummarizePeriods(t *testing.T) {
+	stats := Summarize(statsSessions, kstTime(10, 14, 12, 0), kstTime(10, 21, 0, 0))
+
+	cases := []struct {
+		name    string
+		periods []PeriodStats
+		index   int
+		start   string
+		runs    int
+		seconds map[string]int64
+	}{
+		{"첫날", stats.Daily, 0, "2026-10-14", 2, map[string]int64{"a": 3600, "b": 5400}},
+		{"자정 다음 날", stats.Daily, 1, "2026-10-15", 0, map[string]int64{}},
+		{"실행 없는 날", stats.Daily, 4, "2026-10-18", 0, map[string]int64{}},
+		{"시간대 변환", stats.Daily, 5, "2026-10-19", 2, map[string]int64{"a": 600, "b": 1200}},
+		{"마지막 날", stats.Daily, 6, "2026-10-20", 0, map[string]int64{}},
+		{"일부만 포함된 첫 주", stats.Weekly, 0, "2026-10-14", 2, map[string]int64{"a": 3600, "b": 5400}},
+		{"둘째 주", stats.Weekly, 1, "2026-10-19", 2, map[string]int64{"a": 600, "b": 1200}},
+	}
+	if len(stats.Daily) != 7 || len(stats.Weekly) != 2 {
+		t.Fatalf("일별 %d개, 주별 %d개", len(stats.Daily), len(stats.Weekly))
+	}
+	for _, c := range cases {
+		got := c.periods[c.index]
+		if got.Start != c.start || got.Runs != c.runs || !reflect.DeepEqual(got.ModeSeconds, c.seconds) {
+			t.Errorf("%s: %s %d회 %v, 기대 %s %d회 %v", c.name, got.Start, got.Runs, got.ModeSeconds, c.start, c.runs, c.seconds)
+		}
+	}
+}
+
+func TestSummarizeWeekBoundaries(t *testing.T) {
+	cases := []struct {
+		from, to time.Time
+		weeks    []string
+	}{
+		{kstTime(10, 12, 0, 0), kstTime(10, 19, 0, 0), []string{"2026-10-12"}},               // 월요일부터 한 주
+		{kstTime(10, 18, 0, 0), kstTime(10, 20, 0, 0), []string{"2026-10-18", "2026-10-19"}}, // 일요일 하루 + 다음 주
+		{kstTime(10, 14, 0, 0), kstTime(10, 14, 0, 0), nil},                                  // 빈 기간
+	}
+	for _, c := range cases {
+		stats := Summarize(nil, c.from, c.to)
+		var weeks []string
+		for _, week := range stats.Weekly {
+			weeks = append(weeks, week.Start)
+		}
+		if !reflect.DeepEqual(weeks, c.weeks) {
+			t.Errorf("%s ~ %s: 주 %v, 기대 %v", c.from.Format("01-02"), c.to.Format("01-02"), weeks, c.weeks)
+		}
+	}
+}
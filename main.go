@@ -8,11 +8,17 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"example.com/m/automation"
 	"example.com/m/config"
+	"example.com/m/history"
+	"example.com/m/notify"
+	"example.com/m/scheduler"
+	"example.com/m/session"
+	"example.com/m/telegram"
 	"example.com/m/utils"
 	webview "github.com/webview/webview_go"
 )
@@ -20,38 +26,27 @@ import (
 //go:embed ui/web
 var webFiles embed.FS
 
-// 모드 타입 상수
-const (
-	ModeNone = iota
-	ModeDaeyaEnter
-	ModeDaeyaParty
-	ModeKanchenEnter
-	ModeKanchenParty
-)
-
-// 시간 설정 옵션 상수
-const (
-	TimeOption1Hour = iota
-	TimeOption2Hour
-	TimeOption3Hour
-	TimeOption4Hour
-)
+// OCR 테스트에 업로드할 수 있는 스크린샷의 최대 크기 (요청 전체)
+const maxOCRUploadSize = 32 << 20
 
 // Application 구조체는 애플리케이션의 상태를 관리합니다
 type Application struct {
-	WebView          webview.WebView
-	Config           *config.AppConfig
-	TimerManager     *utils.TimerManager
-	KeyboardManager  *automation.KeyboardManager
-	ActiveMode       int
-	TimeOption       int
-	AutoStopTimer    *time.Timer
-	WindowWidth      int
-	WindowHeight     int
-	RunningOperation bool
-	AutoStartup      bool
-	ServerPort       string
-	ServerReady      chan bool
+	WebView         webview.WebView
+	Config          *config.AppConfig
+	TimerManager    *utils.TimerManager
+	KeyboardManager *automation.KeyboardManager
+	Sequences       *automation.SequenceLibrary
+	Recorder        *automation.Recorder
+	Hotkeys         *automation.HotkeyManager
+	History         *history.Store
+	Scheduler       *scheduler.Scheduler
+	Commands        *telegram.CommandListener // 텔레그램 원격 제어 명령 수신
+	Session         *session.Controller       // 작업 시작, 중지와 모드 선택
+	WindowWidth     int
+	WindowHeight    int
+	AutoStartup     bool
+	ServerPort      string
+	ServerReady     chan bool
 }
 
 // 웹뷰에 전송할 이벤트 구조체
@@ -66,16 +61,6 @@ type TimerPayload struct {
 	IsRunning bool   `json:"isRunning"`
 }
 
-// 로그 이벤트 페이로드
-type LogPayload struct {
-	Message string `json:"message"`
-}
-
-// 모드 변경 이벤트 페이로드
-type ModePayload struct {
-	Mode int `json:"mode"`
-}
-
 // 버전 정보 페이로드
 type VersionPayload struct {
 	Version   string `json:"version"`
@@ -113,6 +98,22 @@ func main() {
 	// 로그 파일 설정 - AppConfig를 매개변수로 전달
 	setupLogging(app.Config)
 
+	// 키 시퀀스 라이브러리 로드
+	app.Sequences = loadSequenceLibrary(app.Config)
+
+	// 실행 기록 불러오기
+	app.History = openHistory(app.Config)
+
+	// 매크로 녹화기 생성
+	app.Recorder = automation.NewRecorder(automation.NewGlobalKeyHook())
+	app.Recorder.OnStop = func(keys []automation.RecordedKey) {
+		log.Printf("녹화 중지 키 입력: %d개 키 녹화됨", len(keys))
+		sendEvent(app, "recordStatus", map[string]interface{}{
+			"recording": false,
+			"keys":      len(keys),
+		})
+	}
+
 	// 키보드 매니저 생성
 	keyboardManager := automation.NewKeyboardManager()
 	app.KeyboardManager = keyboardManager
@@ -121,6 +122,22 @@ func main() {
 	timerManager := utils.NewTimerManager()
 	app.TimerManager = timerManager
 
+	// 작업 컨트롤러 생성 - 웹뷰 바인딩, API, 단축키, 예약 작업이 함께 사용
+	app.Session = session.NewController(app.Config, app.Sequences, keyboardManager, timerManager)
+	app.Session.History = app.History
+	app.Session.Emit = func(eventType string, payload interface{}) {
+		sendEvent(app, eventType, payload)
+	}
+
+	// 전역 단축키 등록
+	app.Hotkeys = setupHotkeys(app)
+
+	// 예약 작업 시작
+	app.Scheduler = setupScheduler(app)
+
+	// 텔레그램 명령 수신 시작
+	app.Commands = setupTelegramCommands(app)
+
 	// HTTP 서버 시작
 	go startServer(app, timerManager, keyboardManager)
 
@@ -161,16 +178,42 @@ func main() {
 // NewApplication은 새로운 애플리케이션 인스턴스를 생성합니다
 func NewApplication() *Application {
 	return &Application{
-		Config:           config.NewAppConfig(),
-		ActiveMode:       ModeDaeyaEnter,  // 기본값: 대야 (입장)
-		TimeOption:       TimeOption3Hour, // 기본값: 3시간 10분
-		WindowWidth:      1024,
-		WindowHeight:     768,
-		RunningOperation: false,
-		AutoStartup:      false,
-		ServerPort:       "8080",
-		ServerReady:      make(chan bool), // 서버 준비 상태를 알리는 채널
+		Config:       config.NewAppConfig(),
+		WindowWidth:  1024,
+		WindowHeight: 768,
+		AutoStartup:  false,
+		ServerPort:   "8080",
+		ServerReady:  make(chan bool), // 서버 준비 상태를 알리는 채널
+	}
+}
+
+// 키 시퀀스 라이브러리 생성 및 로드
+func loadSequenceLibrary(appConfig *config.AppConfig) *automation.SequenceLibrary {
+	library := automation.NewSequenceLibrary(appConfig.GetSequencesDir())
+
+	// 기본 시퀀스 파일 설치 (사용자가 복사해서 수정할 수 있도록)
+	if err := library.InstallDefaults(); err != nil {
+		log.Printf("경고: 기본 시퀀스 파일을 설치할 수 없습니다: %v", err)
+	}
+
+	// 잘못된 파일은 건너뛰고 나머지 시퀀스는 사용
+	if err := library.Load(); err != nil {
+		log.Printf("경고: 일부 시퀀스 파일을 불러오지 못했습니다:\n%v", err)
+	}
+
+	log.Printf("키 시퀀스 %d개 로드 완료 (%s)", len(library.List()), library.Dir)
+	return library
+}
+
+// 실행 기록 저장소 열기 - 실패해도 앱은 동작하도록 빈 기록으로 시작
+func openHistory(appConfig *config.AppConfig) *history.Store {
+	path := appConfig.GetHistoryFilePath()
+	store, err := history.Open(path)
+	if err != nil {
+		log.Printf("경고: 실행 기록을 불러오지 못했습니다: %v", err)
+		return history.NewStore(path)
 	}
+	return store
 }
 
 // 웹 서버 시작
@@ -233,86 +276,47 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 			return
 		}
 
-		// 모드 파라미터 가져오기
+		// 모드 파라미터 가져오기 (시퀀스 ID)
 		mode := r.FormValue("mode")
 		if mode == "" {
 			http.Error(w, "Mode not specified", http.StatusBadRequest)
 			return
 		}
 
-		// 자동 종료 시간 파라미터 가져오기 (옵션) - float64로 수정
-		autoStopStr := r.FormValue("auto_stop")
-		var autoStopHours float64 = 0
-		if autoStopStr != "" {
-			fmt.Sscanf(autoStopStr, "%f", &autoStopHours)
+		if _, ok := app.Sequences.Get(mode); !ok {
+			http.Error(w, "Unknown mode", http.StatusBadRequest)
+			return
 		}
 
-		// 재시작 여부 확인 (추가)
-		isResume := r.FormValue("resume") == "true"
-
-		// 현재 실행 중인지 확인
-		if tm.IsRunning() {
-			http.Error(w, "Already running", http.StatusConflict)
+		// 자동 종료 시간 파라미터 (옵션) - "3h10m", "04:30"(종료 시각), 시간 단위 숫자, 비어 있으면 제한 없음
+		runTime, err := utils.ParseRunTime(r.FormValue("auto_stop"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
-		// 모드에 따라 내부 모드 설정
-		internalMode := ModeDaeyaEnter // 기본값
-		if mode == "daeya-entrance" {
-			internalMode = ModeDaeyaEnter
-		} else if mode == "daeya-party" {
-			internalMode = ModeDaeyaParty
-		} else if mode == "kanchen-entrance" {
-			internalMode = ModeKanchenEnter
-		} else if mode == "kanchen-party" {
-			internalMode = ModeKanchenParty
+		// 반복 횟수 제한 파라미터 (옵션)
+		var maxIterations int
+		if value := r.FormValue("max_iterations"); value != "" {
+			fmt.Sscanf(value, "%d", &maxIterations)
 		}
-
-		// 애플리케이션 설정 업데이트
-		app.ActiveMode = internalMode
-
-		// 타이머 시작
-		tm.Start()
-
-		// 키보드 매니저 설정
-		km.SetRunning(true)
-
-		// 상태 업데이트
-		app.RunningOperation = true
-		sendEvent(app, "operationStatus", map[string]bool{"running": true})
-
-		// 자동 중지 설정
-		if autoStopHours > 0 {
-			setupAutoStop(app, autoStopHours)
+		if maxIterations < 0 {
+			http.Error(w, "max_iterations must not be negative", http.StatusBadRequest)
+			return
 		}
 
-		// 선택된 모드에 따라 자동화 시작
-		go func() {
-			switch internalMode {
-			case ModeDaeyaEnter:
-				km.DaeyaEnter()
-			case ModeDaeyaParty:
-				km.DaeyaParty()
-			case ModeKanchenEnter:
-				km.KanchenEnter()
-			case ModeKanchenParty:
-				km.KanchenParty()
-			}
-		}()
-
-		// 텔레그램 알림 전송 - 재시작이 아닐 때만 시작 알림 전송
-		if app.Config.TelegramEnabled && app.Config.TelegramBot != nil && !isResume {
-			modeName := getModeName(internalMode)
-			duration := time.Duration(autoStopHours * float64(time.Hour))
-			go func() {
-				err := app.Config.TelegramBot.SendStartNotification(modeName, duration)
-				if err != nil {
-					log.Printf("텔레그램 시작 알림 전송 실패: %v", err)
-				}
-			}()
-		} else if isResume {
-			// 재시작 로그만 기록
-			log.Printf("작업 재개: %s 모드", getModeName(internalMode))
+		// 선택된 시퀀스로 자동화 시작
+		err = app.Session.Start(session.TriggerAPI, session.StartOptions{
+			Sequence:      mode,
+			RunTime:       &runTime,
+			MaxIterations: &maxIterations,
+		})
+		if err == automation.ErrAlreadyRunning {
+			http.Error(w, "Already running", http.StatusConflict)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 
 		// 응답 전송
@@ -327,31 +331,47 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 			return
 		}
 
-		// 현재 실행 중인지 확인
-		if !tm.IsRunning() {
+		// 실행 중인 작업을 중지하고 정리가 끝날 때까지 대기
+		if !app.Session.Stop(session.TriggerAPI, "") {
 			http.Error(w, "Not running", http.StatusConflict)
 			return
 		}
 
-		// 타이머 중지
-		tm.Stop()
+		// 응답 전송
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "Stopped")
+	})
 
-		// 키보드 매니저 중지
-		km.SetRunning(false)
+	// 일시정지 API - 현재 단계에서 멈추고 경과 시간과 자동 중지 시간을 고정
+	http.HandleFunc("/api/pause", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
 
-		// 상태 업데이트
-		app.RunningOperation = false
-		sendEvent(app, "operationStatus", map[string]bool{"running": false})
+		if !app.Session.Pause(session.TriggerAPI, "") {
+			http.Error(w, "Not running", http.StatusConflict)
+			return
+		}
 
-		// 자동 중지 타이머 중지
-		if app.AutoStopTimer != nil {
-			app.AutoStopTimer.Stop()
-			app.AutoStopTimer = nil
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "Paused")
+	})
+
+	// 재개 API - 일시정지된 단계부터 이어서 실행
+	http.HandleFunc("/api/resume", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !app.Session.Resume(session.TriggerAPI, "") {
+			http.Error(w, "Not paused", http.StatusConflict)
+			return
 		}
 
-		// 응답 전송
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprint(w, "Stopped")
+		fmt.Fprint(w, "Resumed")
 	})
 
 	// 설정 API
@@ -374,29 +394,20 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 		// 설정 타입에 따라 처리
 		switch settingType {
 		case "mode":
-			// 모드 설정
-			if settingValue == "daeya-entrance" {
-				app.ActiveMode = ModeDaeyaEnter
-			} else if settingValue == "daeya-party" {
-				app.ActiveMode = ModeDaeyaParty
-			} else if settingValue == "kanchen-entrance" {
-				app.ActiveMode = ModeKanchenEnter
-			} else if settingValue == "kanchen-party" {
-				app.ActiveMode = ModeKanchenParty
-			}
+			// 모드 설정 (시퀀스 ID)
+			app.Session.SelectSequence(settingValue)
 		case "time":
-			// 시간 설정 - float64로 처리하도록 수정
-			var hours float64
-			fmt.Sscanf(settingValue, "%f", &hours)
-			// 10분 추가된 시간으로 시간 옵션 결정
-			if hours >= 1.0 && hours < 1.5 {
-				app.TimeOption = TimeOption1Hour // 1시간 10분
-			} else if hours >= 2.0 && hours < 2.5 {
-				app.TimeOption = TimeOption2Hour // 2시간 10분
-			} else if hours >= 3.0 && hours < 3.5 {
-				app.TimeOption = TimeOption3Hour // 3시간 10분
-			} else if hours >= 4.0 && hours < 4.5 {
-				app.TimeOption = TimeOption4Hour // 4시간 10분
+			// 실행 시간 설정 ("3h10m", "04:30" 등)
+			if _, err := app.Session.SetRunTime(settingValue); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case "max_iterations":
+			var count int
+			fmt.Sscanf(settingValue, "%d", &count)
+			if err := app.Session.SetMaxIterations(count); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
 		case "dark_mode":
 			var enabled int
@@ -414,6 +425,20 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 			var enabled int
 			fmt.Sscanf(settingValue, "%d", &enabled)
 			err = app.Config.SetTelegramEnabled(enabled == 1)
+		case "telegram_timeout":
+			var seconds int
+			fmt.Sscanf(settingValue, "%d", &seconds)
+			if err := app.Config.SetTelegramTimeout(seconds); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case "progress_minutes":
+			var minutes int
+			fmt.Sscanf(settingValue, "%d", &minutes)
+			if err := app.Config.SetProgressInterval(minutes); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
 		}
 
 		if err != nil {
@@ -432,25 +457,421 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 			"sound_enabled":    app.Config.SoundEnabled,
 			"auto_startup":     app.Config.AutoStartup,
 			"telegram_enabled": app.Config.TelegramEnabled,
+			"max_iterations":   app.Session.MaxIterations(),
+			"progress_minutes": int(app.Config.ProgressInterval / time.Minute),
+			"run_time":         app.Config.RunTime.String(),
+			"hotkeys":          app.Hotkeys.Bindings(),
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(settings)
 	})
 
-	// 상태 API
-	http.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
-		// 상태 정보 구성
+	// 단축키 설정 API
+	http.HandleFunc("/api/hotkeys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// 동작 하나의 단축키 변경 (빈 값이면 해제)
+			action := r.FormValue("action")
+			if action == "" {
+				http.Error(w, "Missing parameters", http.StatusBadRequest)
+				return
+			}
+
+			bindings := app.Hotkeys.Bindings()
+			bindings[action] = r.FormValue("binding")
+
+			if err := app.Hotkeys.SetBindings(bindings); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if err := app.Config.SetHotkeys(app.Hotkeys.Bindings()); err != nil {
+				http.Error(w, fmt.Sprintf("설정 저장 실패: %v", err), http.StatusInternalServerError)
+				return
+			}
+
+			log.Printf("단축키 변경: %s = %s", action, app.Hotkeys.Bindings()[action])
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, "Hotkeys updated")
+			return
+		}
+
+		// GET 요청인 경우 현재 설정 반환
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(app.Hotkeys.Bindings())
+	})
+
+	// 시퀀스 목록 API
+	http.HandleFunc("/api/sequences", func(w http.ResponseWriter, r *http.Request) {
+		sequences := []map[string]interface{}{}
+		for _, seq := range app.Sequences.List() {
+			delays := make([]string, len(seq.Delays))
+			for i, delay := range seq.Delays {
+				delays[i] = delay.String()
+			}
+			sequences = append(sequences, map[string]interface{}{
+				"id":        seq.ID,
+				"name":      seq.Name,
+				"start_key": seq.StartKey,
+				"keys":      seq.KeyPresses,
+				"delays":    delays,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"directory": app.Sequences.Dir,
+			"sequences": sequences,
+		})
+	})
+
+	// 시퀀스 다시 불러오기 API - 파일 수정 후 재빌드 없이 반영
+	http.HandleFunc("/api/sequences/reload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := app.Sequences.Load(); err != nil {
+			log.Printf("시퀀스 다시 불러오기 경고:\n%v", err)
+			http.Error(w, fmt.Sprintf("일부 시퀀스를 불러오지 못했습니다: %v", err), http.StatusUnprocessableEntity)
+			return
+		}
+
+		log.Printf("키 시퀀스 %d개 다시 불러옴", len(app.Sequences.List()))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "Sequences reloaded")
+	})
+
+	// OCR 테스트 API - 실제 화면 대신 업로드한 스크린샷(file, PNG, 여러 개 가능)으로 텍스트 대상 인식 결과 확인
+	http.HandleFunc("/api/ocr/test", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		// 서버의 파일 경로는 받지 않고 업로드된 이미지만 사용
+		r.Body = http.MaxBytesReader(w, r.Body, maxOCRUploadSize)
+		if err := r.ParseMultipartForm(maxOCRUploadSize); err != nil {
+			http.Error(w, "multipart/form-data upload is required", http.StatusBadRequest)
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		sequence, ok := app.Sequences.Get(r.FormValue("sequence"))
+		if !ok {
+			http.Error(w, "Unknown sequence", http.StatusNotFound)
+			return
+		}
+		target, ok := sequence.Targets[r.FormValue("target")]
+		if !ok || target.Pattern == nil {
+			http.Error(w, "Unknown text target", http.StatusNotFound)
+			return
+		}
+		uploads := r.MultipartForm.File["file"]
+		if len(uploads) == 0 {
+			http.Error(w, "file is required", http.StatusBadRequest)
+			return
+		}
+
+		results := make([]automation.OCRResult, 0, len(uploads))
+		for _, upload := range uploads {
+			file, err := upload.Open()
+			if err != nil {
+				results = append(results, automation.OCRResult{File: upload.Filename, Error: err.Error()})
+				continue
+			}
+			results = append(results, automation.PreviewTextTarget(target, km.OCR, upload.Filename, file))
+			file.Close()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"pattern": target.Pattern.String(),
+			"results": results,
+		})
+	})
+
+	// 녹화 시작 API
+	http.HandleFunc("/api/record/start", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if tm.IsRunning() {
+			http.Error(w, "Already running", http.StatusConflict)
+			return
+		}
+
+		stopKey := r.FormValue("stop_key")
+		err := app.Recorder.Start(stopKey)
+		if err == automation.ErrAlreadyRecording {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		} else if err != nil {
+			http.Error(w, fmt.Sprintf("녹화 시작 실패: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		log.Printf("매크로 녹화 시작 (중지 키: %s)", app.Recorder.StopKey())
+		sendEvent(app, "recordStatus", map[string]interface{}{"recording": true, "keys": 0})
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "Recording")
+	})
+
+	// 녹화 중지 API
+	http.HandleFunc("/api/record/stop", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !app.Recorder.IsRecording() {
+			http.Error(w, "Not recording", http.StatusConflict)
+			return
+		}
+
+		keys := app.Recorder.Stop()
+		log.Printf("매크로 녹화 중지: %d개 키 녹화됨", len(keys))
+		sendEvent(app, "recordStatus", map[string]interface{}{"recording": false, "keys": len(keys)})
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "Stopped")
+	})
+
+	// 녹화 상태 API
+	http.HandleFunc("/api/record/status", func(w http.ResponseWriter, r *http.Request) {
 		status := map[string]interface{}{
-			"running": tm.IsRunning(),
-			"mode":    app.ActiveMode,
+			"recording": app.Recorder.IsRecording(),
+			"stop_key":  app.Recorder.StopKey(),
+			"keys":      app.Recorder.Keys(),
 		}
 
-		// JSON 응답 전송
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(status)
 	})
 
+	// 녹화 저장 API - 녹화된 키를 이름 있는 시퀀스로 저장
+	http.HandleFunc("/api/record/save", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if app.Recorder.IsRecording() {
+			http.Error(w, "Still recording", http.StatusConflict)
+			return
+		}
+
+		id := r.FormValue("id")
+		name := r.FormValue("name")
+		overwrite := r.FormValue("overwrite") == "true"
+
+		// 대기 시간 반올림 단위 (밀리초, 옵션)
+		var quantizeMs int
+		if value := r.FormValue("quantize_ms"); value != "" {
+			ms, err := strconv.Atoi(value)
+			if err != nil || ms < 0 {
+				http.Error(w, fmt.Sprintf("잘못된 quantize_ms: %s", value), http.StatusBadRequest)
+				return
+			}
+			quantizeMs = ms
+		}
+
+		sequence, err := automation.BuildRecordedSequence(id, name, app.Recorder.Keys(), time.Duration(quantizeMs)*time.Millisecond)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("시퀀스 생성 실패: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if err := app.Sequences.Save(sequence, overwrite); err != nil {
+			http.Error(w, fmt.Sprintf("시퀀스 저장 실패: %v", err), http.StatusConflict)
+			return
+		}
+
+		log.Printf("녹화된 시퀀스 저장: %s (%s, 키 %d개)", sequence.Name, sequence.ID, len(sequence.KeyPresses))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "Saved")
+	})
+
+	// 실행 기록 API - mode, status, since, until(YYYY-MM-DD 또는 RFC3339), offset, limit으로 조회
+	http.HandleFunc("/api/history", func(w http.ResponseWriter, r *http.Request) {
+		query := history.Query{
+			Mode:   r.FormValue("mode"),
+			Status: r.FormValue("status"),
+		}
+
+		var err error
+		if query.Since, err = parseHistoryTime(r.FormValue("since"), false); err != nil {
+			http.Error(w, fmt.Sprintf("잘못된 since: %v", err), http.StatusBadRequest)
+			return
+		}
+		if query.Until, err = parseHistoryTime(r.FormValue("until"), true); err != nil {
+			http.Error(w, fmt.Sprintf("잘못된 until: %v", err), http.StatusBadRequest)
+			return
+		}
+		if value := r.FormValue("offset"); value != "" {
+			fmt.Sscanf(value, "%d", &query.Offset)
+		}
+		if value := r.FormValue("limit"); value != "" {
+			fmt.Sscanf(value, "%d", &query.Limit)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(app.History.Query(query))
+	})
+
+	// 통계 API - since/until(YYYY-MM-DD) 기간 또는 오늘까지 최근 days일(기본 7일)의 실행 기록 집계
+	http.HandleFunc("/api/stats", func(w http.ResponseWriter, r *http.Request) {
+		since, err := parseHistoryTime(r.FormValue("since"), false)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("잘못된 since: %v", err), http.StatusBadRequest)
+			return
+		}
+		until, err := parseHistoryTime(r.FormValue("until"), true)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("잘못된 until: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if until.IsZero() {
+			year, month, day := time.Now().Date()
+			until = time.Date(year, month, day+1, 0, 0, 0, 0, time.Local)
+		}
+		if since.IsZero() {
+			days := 7
+			if value := r.FormValue("days"); value != "" {
+				fmt.Sscanf(value, "%d", &days)
+			}
+			if days < 1 || days > 366 {
+				http.Error(w, "days는 1~366 사이여야 합니다", http.StatusBadRequest)
+				return
+			}
+			since = until.AddDate(0, 0, -days)
+		}
+		if !since.Before(until) || until.Sub(since) > 366*24*time.Hour {
+			http.Error(w, "잘못된 기간입니다", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(history.Summarize(app.History.Sessions(), since, until))
+	})
+
+	// 예약 작업 API
+	// GET: 목록, POST: 추가, PUT: 수정(id 필수, 보낸 항목만 변경), DELETE: 삭제(id 필수)
+	http.HandleFunc("/api/schedules", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var (
+			schedule scheduler.Schedule
+			err      error
+		)
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(app.Scheduler.List())
+			return
+		case http.MethodPost:
+			schedule = scheduler.Schedule{Enabled: true}
+			if err = applyScheduleForm(app, &schedule, r); err == nil {
+				schedule, err = app.Scheduler.Add(schedule)
+			}
+		case http.MethodPut:
+			var ok bool
+			if schedule, ok = app.Scheduler.Get(r.FormValue("id")); !ok {
+				http.Error(w, "Unknown schedule", http.StatusNotFound)
+				return
+			}
+			if err = applyScheduleForm(app, &schedule, r); err == nil {
+				schedule, err = app.Scheduler.Update(schedule)
+			}
+		case http.MethodDelete:
+			if err := app.Scheduler.Remove(r.FormValue("id")); err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, "Deleted")
+			return
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(schedule)
+	})
+
+	// 재생 목록 API
+	// GET: 실행 중이거나 마지막으로 실행한 재생 목록의 상태
+	// POST: {"steps": [{"sequence", "duration", "iterations", "pause"}, ...]} 형식의 재생 목록 시작
+	http.HandleFunc("/api/playlist", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			playlist := app.Session.Playlist()
+			if playlist == nil {
+				fmt.Fprint(w, "null")
+				return
+			}
+			json.NewEncoder(w).Encode(playlist.Status())
+		case http.MethodPost:
+			var request struct {
+				Steps []automation.PlaylistStep `json:"steps"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, fmt.Sprintf("잘못된 요청: %v", err), http.StatusBadRequest)
+				return
+			}
+
+			err := app.Session.StartPlaylist(request.Steps)
+			if err == automation.ErrAlreadyRunning {
+				http.Error(w, "Already running", http.StatusConflict)
+				return
+			} else if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(app.Session.Playlist().Status())
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// 재생 목록 중지 API - 실행 중인 단계를 중지하고 남은 단계 취소
+	http.HandleFunc("/api/playlist/stop", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if app.Session.ActivePlaylist() == nil {
+			http.Error(w, "Not running", http.StatusConflict)
+			return
+		}
+
+		app.Session.Stop(session.TriggerAPI, "재생 목록을 중지했습니다")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "Stopped")
+	})
+
+	// 상태 API
+	http.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		// JSON 응답 전송
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(app.Session.Status())
+	})
+
 	// 로그 API
 	http.HandleFunc("/api/log", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
@@ -546,15 +967,142 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 			return
 		}
 
-		// GET 요청인 경우 현재 설정 반환
+		// GET 요청인 경우 현재 설정과 전송 상태 반환
 		status := map[string]interface{}{
-			"enabled": app.Config.TelegramEnabled,
+			"enabled":  app.Config.TelegramEnabled,
+			"timeout":  int(app.Config.TelegramDelivery.Timeout / time.Second),
+			"delivery": app.Config.TelegramDelivery.Stats(),
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(status)
 	})
 
+	// 텔레그램 메시지 설정 API - 언어와 시간대 (저장하면 사용자 템플릿도 다시 읽음)
+	http.HandleFunc("/api/telegram/messages", func(w http.ResponseWriter, r *http.Request) {
+		var warning string
+		if r.Method == http.MethodPost {
+			var settings struct {
+				Language string `json:"language"`
+				TimeZone string `json:"time_zone"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+			if !telegram.ValidLanguage(settings.Language) {
+				http.Error(w, fmt.Sprintf("지원하지 않는 언어입니다: %s", settings.Language), http.StatusBadRequest)
+				return
+			}
+			if _, err := telegram.LoadLocation(settings.TimeZone); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			// 템플릿 오류는 설정을 저장한 뒤에 알려 줌
+			if err := app.Config.SetMessageSettings(settings.Language, settings.TimeZone); err != nil {
+				warning = err.Error()
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"language":      app.Config.Language,
+			"time_zone":     app.Config.TimeZone,
+			"languages":     telegram.Languages,
+			"templates_dir": app.Config.MessageTemplates.Dir,
+			"warning":       warning,
+		})
+	})
+
+	// 텔레그램 화면 캡처 설정 API
+	http.HandleFunc("/api/telegram/screenshot", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var settings config.ScreenshotSettings
+			if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+			if err := settings.Validate(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := app.Config.SetScreenshot(settings); err != nil {
+				http.Error(w, fmt.Sprintf("설정 저장 실패: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(app.Config.Screenshot)
+	})
+
+	// 알림 대상 설정 API - 텔레그램으로 보낼 이벤트와 추가 알림 대상 목록
+	http.HandleFunc("/api/notifiers", func(w http.ResponseWriter, r *http.Request) {
+		var settings struct {
+			TelegramEvents []notify.Event  `json:"telegram_events"`
+			Notifiers      []notify.Config `json:"notifiers"`
+		}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+			if err := app.Config.SetNotifiers(settings.TelegramEvents, settings.Notifiers); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		settings.TelegramEvents = app.Config.TelegramEvents
+		settings.Notifiers = app.Config.Notifiers
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(settings)
+	})
+
+	// 생존 신호 설정 API - 실행 중 주기적으로 호출할 주소와 상태를 기록할 파일 (다음 작업부터 적용)
+	http.HandleFunc("/api/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var heartbeat notify.Heartbeat
+			if err := json.NewDecoder(r.Body).Decode(&heartbeat); err != nil {
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+			if err := app.Config.SetHeartbeat(heartbeat); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(app.Config.Heartbeat)
+	})
+
+	// 알림 대상 테스트 API - 요청 본문의 설정으로 테스트 알림 전송 (저장하지 않음)
+	http.HandleFunc("/api/notifiers/test", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var notifier notify.Config
+		if err := json.NewDecoder(r.Body).Decode(&notifier); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		target, err := notifier.Target(app.Config.Language)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := target.Notifier.TestConnection(); err != nil {
+			http.Error(w, fmt.Sprintf("테스트 실패: %v", err), http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "테스트 알림이 전송되었습니다")
+	})
+
 	// 텔레그램 테스트 API
 	http.HandleFunc("/api/telegram/test", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -584,19 +1132,11 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 			return
 		}
 
-		// 타이머 재설정
-		tm.Reset()
-
-		// 모드 초기화 - 대야 입장(기본값)으로 설정
-		app.ActiveMode = ModeDaeyaEnter
-		sendEvent(app, "resetMode", ModePayload{Mode: ModeDaeyaEnter})
-
-		// 시간 설정 초기화 - 3시간 10분(기본값)으로 설정
-		app.TimeOption = TimeOption3Hour
-		sendEvent(app, "resetTimeOption", map[string]int{"option": TimeOption3Hour})
-
-		// 타이머 값 초기화 이벤트 추가
-		sendEvent(app, "resetTimer", nil)
+		// 일시정지 상태였다면 작업을 완전히 종료한 뒤 타이머, 모드, 실행 시간 초기화
+		if err := app.Session.Reset(session.TriggerAPI); err != nil {
+			http.Error(w, "Already running", http.StatusConflict)
+			return
+		}
 
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprint(w, "Settings reset")
@@ -625,12 +1165,13 @@ func setupAPIHandlers(app *Application, km *automation.KeyboardManager, tm *util
 func bindJavaScriptCallbacks(app *Application) {
 	// 모드 변경 바인딩
 	app.WebView.Bind("setMode", func(mode int) {
-		app.ActiveMode = mode
+		app.Session.SelectMode(mode)
 	})
 
-	// 시간 설정 변경 바인딩
-	app.WebView.Bind("setTimeOption", func(option int) {
-		app.TimeOption = option
+	// 실행 시간 변경 바인딩
+	app.WebView.Bind("setRunTime", func(value string) error {
+		_, err := app.Session.SetRunTime(value)
+		return err
 	})
 
 	// 자동 시작 설정 바인딩
@@ -640,17 +1181,25 @@ func bindJavaScriptCallbacks(app *Application) {
 
 	// 시작 버튼 클릭 바인딩
 	app.WebView.Bind("startOperation", func() {
-		startOperation(app)
+		app.Session.Start(session.TriggerUI, session.StartOptions{})
 	})
 
 	// 중지 버튼 클릭 바인딩
 	app.WebView.Bind("stopOperation", func() {
-		stopOperation(app)
+		app.Session.Stop(session.TriggerUI, "")
+	})
+
+	// 일시정지/재개 바인딩
+	app.WebView.Bind("pauseOperation", func() {
+		app.Session.Pause(session.TriggerUI, "")
+	})
+	app.WebView.Bind("resumeOperation", func() {
+		app.Session.Resume(session.TriggerUI, "")
 	})
 
 	// 재설정 버튼 클릭 바인딩
 	app.WebView.Bind("resetSettings", func() {
-		resetSettings(app)
+		app.Session.Reset(session.TriggerUI)
 	})
 
 	// 종료 버튼 클릭 바인딩
@@ -688,177 +1237,201 @@ func sendEvent(app *Application, eventType string, payload interface{}) {
 	})
 }
 
-// 시작 버튼 클릭 처리 - 수정된 버전
-func startOperation(app *Application) {
-	if app.TimerManager == nil || app.TimerManager.IsRunning() {
-		return
+// 스케줄러 생성 - 저장된 예약을 불러오고, 바뀔 때마다 설정 파일에 저장
+func setupScheduler(app *Application) *scheduler.Scheduler {
+	s := scheduler.New(nil, app.Config.Schedules)
+	s.Busy = app.Session.Busy
+	s.Launch = func(schedule scheduler.Schedule) error {
+		return startScheduledOperation(app, schedule)
 	}
-
-	if app.ActiveMode == ModeNone {
-		sendEvent(app, "operationStatus", map[string]bool{"running": false})
-		return
+	s.OnChange = func(schedules []scheduler.Schedule) {
+		if err := app.Config.SetSchedules(schedules); err != nil {
+			log.Printf("예약 작업 저장 실패: %v", err)
+		}
 	}
+	s.Start()
 
-	// 실행 시간 설정 확인 - 10분 추가된 시간 계산
-	var hours float64
-	switch app.TimeOption {
-	case TimeOption1Hour:
-		hours = 1 + (10.0 / 60.0) // 1시간 10분
-	case TimeOption2Hour:
-		hours = 2 + (10.0 / 60.0) // 2시간 10분
-	case TimeOption3Hour:
-		hours = 3 + (10.0 / 60.0) // 3시간 10분
-	case TimeOption4Hour:
-		hours = 4 + (10.0 / 60.0) // 4시간 10분
-	default:
-		hours = 3 + (10.0 / 60.0) // 기본값: 3시간 10분
-	}
-
-	// 상태 업데이트
-	app.RunningOperation = true
-	sendEvent(app, "operationStatus", map[string]bool{"running": true})
-
-	// 타이머 시작
-	app.TimerManager.Start()
-
-	// 자동 중지 타이머 설정
-	setupAutoStop(app, hours)
-
-	// 키보드 매니저 시작
-	if app.KeyboardManager != nil {
-		app.KeyboardManager.SetRunning(true)
-
-		// 선택된 모드에 따라 자동화 시작
-		switch app.ActiveMode {
-		case ModeDaeyaEnter:
-			go app.KeyboardManager.DaeyaEnter()
-		case ModeDaeyaParty:
-			go app.KeyboardManager.DaeyaParty()
-		case ModeKanchenEnter:
-			go app.KeyboardManager.KanchenEnter()
-		case ModeKanchenParty:
-			go app.KeyboardManager.KanchenParty()
-		}
-	}
-
-	// 텔레그램 시작 알림 전송
-	if app.Config.TelegramEnabled && app.Config.TelegramBot != nil {
-		modeName := getModeName(app.ActiveMode)
-		duration := time.Duration(hours * float64(time.Hour))
-		go func() {
-			err := app.Config.TelegramBot.SendStartNotification(modeName, duration)
-			if err != nil {
-				log.Printf("텔레그램 시작 알림 전송 실패: %v", err)
-			}
-		}()
-	}
+	log.Printf("예약 작업 %d개 로드 완료", len(s.List()))
+	return s
 }
 
-// 중지 버튼 클릭 처리
-func stopOperation(app *Application) {
-	if app.TimerManager == nil || !app.TimerManager.IsRunning() {
-		return
+// 텔레그램 명령 수신기 생성 - 텔레그램이 켜져 있는 동안 설정된 채팅의 명령을 받아 처리
+// 보내지 못한 메시지의 재전송과 전송 실패 알림도 함께 시작
+func setupTelegramCommands(app *Application) *telegram.CommandListener {
+	bot := func() *telegram.TelegramBot {
+		if !app.Config.TelegramEnabled {
+			return nil
+		}
+		return app.Config.TelegramBot
 	}
 
-	// 상태 업데이트
-	app.RunningOperation = false
-	sendEvent(app, "operationStatus", map[string]bool{"running": false})
-
-	// 타이머 중지
-	app.TimerManager.Stop()
-
-	// 자동 중지 타이머가 있으면 중지
-	if app.AutoStopTimer != nil {
-		app.AutoStopTimer.Stop()
-		app.AutoStopTimer = nil
+	// 사용자 템플릿을 만들 때 참고할 기본 메시지 템플릿 설치
+	if err := app.Config.MessageTemplates.InstallExamples(); err != nil {
+		log.Printf("경고: 메시지 템플릿 예제를 설치할 수 없습니다: %v", err)
 	}
 
-	// 키보드 매니저 중지
-	if app.KeyboardManager != nil {
-		app.KeyboardManager.SetRunning(false)
+	delivery := app.Config.TelegramDelivery
+	delivery.OnChange = func(stats telegram.DeliveryStats) {
+		sendEvent(app, "telegramDelivery", stats)
 	}
+	delivery.Start(bot)
+
+	listener := telegram.NewCommandListener(bot, app.Session.TelegramCommands())
+	// 상태 카드의 중지/일시정지 버튼
+	listener.OnCallback = app.Session.HandleTelegramCallback
+	listener.Start()
+	return listener
 }
 
-// 재설정 버튼 클릭 처리
-func resetSettings(app *Application) {
-	if app.TimerManager == nil || app.TimerManager.IsRunning() {
-		return
+// 예약된 작업 시작
+func startScheduledOperation(app *Application, schedule scheduler.Schedule) error {
+	sequence, ok := app.Sequences.Get(schedule.Sequence)
+	if !ok {
+		return fmt.Errorf("알 수 없는 시퀀스입니다: %s", schedule.Sequence)
 	}
 
-	// 타이머 재설정
-	app.TimerManager.Reset()
+	runTime := schedule.RunTime()
+	err := app.Session.Start(session.TriggerSchedule, session.StartOptions{
+		Sequence:      sequence.ID,
+		RunTime:       &runTime,
+		MaxIterations: &schedule.MaxIterations,
+	})
+	if err != nil {
+		return err
+	}
 
-	// 모드 초기화 - 대야 입장(기본값)으로 설정
-	app.ActiveMode = ModeDaeyaEnter
-	sendEvent(app, "resetMode", ModePayload{Mode: ModeDaeyaEnter})
+	sendEvent(app, "logMessage", session.LogPayload{Message: fmt.Sprintf("예약된 작업을 시작합니다: %s", sequence.Name)})
+	return nil
+}
 
-	// 시간 설정 초기화 - 3시간 10분(기본값)으로 설정
-	app.TimeOption = TimeOption3Hour
-	sendEvent(app, "resetTimeOption", map[string]int{"option": TimeOption3Hour})
+// 예약 작업 요청 파싱 - 요청에 포함된 항목만 변경
+func applyScheduleForm(app *Application, schedule *scheduler.Schedule, r *http.Request) error {
+	has := func(key string) bool {
+		_, ok := r.Form[key]
+		return ok
+	}
 
-	// 타이머 값 초기화 이벤트 추가
-	sendEvent(app, "resetTimer", nil)
+	if has("name") {
+		schedule.Name = strings.TrimSpace(r.FormValue("name"))
+	}
+	if has("sequence") {
+		schedule.Sequence = r.FormValue("sequence")
+		if _, ok := app.Sequences.Get(schedule.Sequence); !ok {
+			return fmt.Errorf("알 수 없는 시퀀스입니다: %s", schedule.Sequence)
+		}
+	}
+	if has("cron") {
+		schedule.Cron = strings.TrimSpace(r.FormValue("cron"))
+	}
+	if has("at") {
+		at, err := parseScheduleTime(r.FormValue("at"))
+		if err != nil {
+			return fmt.Errorf("잘못된 실행 시각: %v", err)
+		}
+		schedule.At = at
+	}
+	if has("duration") {
+		schedule.Duration = strings.TrimSpace(r.FormValue("duration"))
+	}
+	if has("max_iterations") {
+		schedule.MaxIterations = 0
+		if value := r.FormValue("max_iterations"); value != "" {
+			if _, err := fmt.Sscanf(value, "%d", &schedule.MaxIterations); err != nil {
+				return fmt.Errorf("잘못된 반복 횟수: %s", value)
+			}
+		}
+	}
+	if has("enabled") {
+		value := r.FormValue("enabled")
+		schedule.Enabled = value == "1" || value == "true"
+	}
+	return nil
 }
 
-// 시간이 지난 후 자동 중지 처리 - float64로 수정
-func setupAutoStop(app *Application, hours float64) {
-	// 이전 타이머가 있다면 중지
-	if app.AutoStopTimer != nil {
-		app.AutoStopTimer.Stop()
-		app.AutoStopTimer = nil
+// 예약 시각 파싱 - RFC3339 또는 로컬 시각(YYYY-MM-DDTHH:MM)
+func parseScheduleTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
 	}
-
-	if hours <= 0 {
-		return
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
 	}
+	return time.ParseInLocation("2006-01-02T15:04", value, time.Local)
+}
 
-	// 새 타이머 설정 - float64를 time.Duration으로 변환
-	duration := time.Duration(hours * float64(time.Hour))
-	app.AutoStopTimer = time.AfterFunc(duration, func() {
-		if app.TimerManager != nil && app.TimerManager.IsRunning() {
-			// 현재 모드 이름 가져오기
-			modeName := getModeName(app.ActiveMode)
+// 실행 기록 조회 시각 파싱 - 날짜만 지정한 until은 그날 전체를 포함
+func parseHistoryTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
 
-			// 상태 업데이트
-			app.RunningOperation = false
-			sendEvent(app, "operationStatus", map[string]bool{"running": false})
+// 전역 단축키 관리자 생성 및 등록
+func setupHotkeys(app *Application) *automation.HotkeyManager {
+	hotkeys := automation.NewHotkeyManager(automation.NewGlobalKeyHook())
 
-			// 타이머 중지
-			app.TimerManager.Stop()
+	bindings := app.Config.Hotkeys
+	if bindings == nil {
+		bindings = automation.DefaultHotkeys()
+	}
+	if err := hotkeys.SetBindings(bindings); err != nil {
+		log.Printf("경고: 저장된 단축키 설정이 잘못되어 기본값을 사용합니다: %v", err)
+		hotkeys.SetBindings(automation.DefaultHotkeys())
+	}
 
-			// 키보드 매니저 중지
-			if app.KeyboardManager != nil {
-				app.KeyboardManager.SetRunning(false)
-			}
+	hotkeys.OnAction = func(action automation.HotkeyAction, binding string) {
+		// 후크 이벤트 처리가 막히지 않도록 별도 고루틴에서 실행
+		go handleHotkey(app, action, binding)
+	}
 
-			// 텔레그램 완료 알림 전송
-			if app.Config.TelegramEnabled && app.Config.TelegramBot != nil {
-				go func() {
-					err := app.Config.TelegramBot.SendCompletionNotification(modeName, duration)
-					if err != nil {
-						log.Printf("텔레그램 완료 알림 전송 실패: %v", err)
-					}
-				}()
-			}
+	if err := hotkeys.Start(); err != nil {
+		log.Printf("경고: 전역 단축키를 사용할 수 없습니다: %v", err)
+	}
 
-			log.Printf("작업 완료: %s 모드, %v 실행", modeName, duration)
-		}
-	})
+	return hotkeys
 }
 
-// 모드 이름 가져오기
-func getModeName(mode int) string {
-	switch mode {
-	case ModeDaeyaEnter:
-		return "대야 (입장)"
-	case ModeDaeyaParty:
-		return "대야 (파티)"
-	case ModeKanchenEnter:
-		return "칸첸 (입장)"
-	case ModeKanchenParty:
-		return "칸첸 (파티)"
-	default:
-		return "알 수 없음"
+// 단축키 동작 처리
+func handleHotkey(app *Application, action automation.HotkeyAction, binding string) {
+	log.Printf("단축키 입력: %s (%s)", binding, action)
+
+	switch action {
+	case automation.HotkeyStart:
+		// 일시정지 상태면 재개
+		if app.Session.IsPaused() {
+			app.Session.Resume(session.TriggerHotkey, fmt.Sprintf("단축키(%s)로 작업을 재개했습니다", binding))
+			return
+		}
+		app.Session.Start(session.TriggerHotkey, session.StartOptions{})
+	case automation.HotkeyStop:
+		app.Session.Stop(session.TriggerHotkey, fmt.Sprintf("단축키(%s)로 작업을 중지했습니다", binding))
+	case automation.HotkeyPause:
+		// 실행 중이면 일시정지, 일시정지 상태면 재개
+		if app.Session.IsPaused() {
+			app.Session.Resume(session.TriggerHotkey, fmt.Sprintf("단축키(%s)로 작업을 재개했습니다", binding))
+		} else {
+			app.Session.Pause(session.TriggerHotkey, fmt.Sprintf("단축키(%s)로 작업을 일시정지했습니다", binding))
+		}
+	case automation.HotkeyAbort:
+		// 긴급 중지 - 키 입력을 즉시 멈추고 녹화도 중지
+		reason := fmt.Sprintf("단축키(%s)로 긴급 중지했습니다", binding)
+		if app.Recorder != nil && app.Recorder.IsRecording() {
+			app.Recorder.Stop()
+			sendEvent(app, "recordStatus", map[string]interface{}{"recording": false, "keys": len(app.Recorder.Keys())})
+		}
+		app.Session.Stop(session.TriggerHotkey, reason)
+		log.Println(reason)
 	}
 }
 
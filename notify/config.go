@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// 알림 서비스 종류
+const (
+	TypeDiscord = "discord"
+	TypeSlack   = "slack"
+	TypeWebhook = "webhook"
+	TypeNtfy    = "ntfy"
+	TypeGotify  = "gotify"
+)
+
+// Config는 설정 파일에 저장하는 알림 대상 하나입니다
+type Config struct {
+	Name    string  `json:"name,omitempty"` // 로그에 표시할 이름 (비어 있으면 종류)
+	Type    string  `json:"type"`
+	Enabled bool    `json:"enabled"`
+	URL     string  `json:"url,omitempty"`    // 웹훅 주소 또는 서버 주소 (ntfy는 비어 있으면 ntfy.sh)
+	Topic   string  `json:"topic,omitempty"`  // ntfy 토픽
+	Token   string  `json:"token,omitempty"`  // 일반 웹훅 Bearer 토큰, ntfy 접근 토큰, Gotify 앱 토큰
+	Events  []Event `json:"events,omitempty"` // 받을 이벤트 (비어 있으면 모두)
+}
+
+// Validate는 설정 값이 올바른지 확인합니다
+func (c Config) Validate() error {
+	switch c.Type {
+	case TypeDiscord, TypeSlack, TypeWebhook, TypeGotify:
+		if c.URL == "" {
+			return fmt.Errorf("%s 알림 주소가 필요합니다", c.Type)
+		}
+	case TypeNtfy:
+		if c.Topic == "" {
+			return fmt.Errorf("ntfy 토픽이 필요합니다")
+		}
+	default:
+		return fmt.Errorf("알 수 없는 알림 종류입니다: %s", c.Type)
+	}
+	if c.Type == TypeGotify && c.Token == "" {
+		return fmt.Errorf("Gotify 앱 토큰이 필요합니다")
+	}
+
+	if c.URL != "" {
+		u, err := url.Parse(c.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("올바른 http(s) 주소가 아닙니다: %s", c.URL)
+		}
+	}
+	return ValidateEvents(c.Events)
+}
+
+// Target은 설정으로 알림 대상을 만듭니다 (알림 문구는 language로 만듦)
+func (c Config) Target(language string) (Target, error) {
+	if err := c.Validate(); err != nil {
+		return Target{}, err
+	}
+
+	var sender Sender
+	switch c.Type {
+	case TypeDiscord:
+		sender = &Discord{WebhookURL: c.URL}
+	case TypeSlack:
+		sender = &Slack{WebhookURL: c.URL}
+	case TypeWebhook:
+		sender = &Webhook{URL: c.URL, Token: c.Token}
+	case TypeNtfy:
+		sender = &Ntfy{Server: c.URL, Topic: c.Topic, Token: c.Token}
+	case TypeGotify:
+		sender = &Gotify{Server: c.URL, Token: c.Token}
+	}
+
+	name := c.Name
+	if name == "" {
+		name = c.Type
+	}
+	return Target{Name: name, Notifier: Wrap(sender, language), Events: c.Events}, nil
+}
+
+// ValidateEvents는 설정할 수 있는 이벤트만 있는지 확인합니다
+func ValidateEvents(events []Event) error {
+	for _, event := range events {
+		if !slices.Contains(Events, event) {
+			return fmt.Errorf("알 수 없는 알림 이벤트입니다: %s", event)
+		}
+	}
+	return nil
+}
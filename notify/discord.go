@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"net/http"
+	"time"
+)
+
+// 디스코드 임베드 색상
+var discordColors = map[Event]int{
+	EventStart:      0x3b82f6,
+	EventProgress:   0xf59e0b,
+	EventCompletion: 0x10b981,
+	EventError:      0xef4444,
+	EventTest:       0x7c3aed,
+}
+
+// Discord는 디스코드 웹훅으로 알림을 보냅니다
+type Discord struct {
+	WebhookURL string
+	Client     *http.Client // nil이면 기본 클라이언트
+}
+
+// Send는 알림을 임베드 메시지로 보냅니다
+func (d *Discord) Send(message Message) error {
+	return postJSON(d.Client, d.WebhookURL, map[string]interface{}{
+		"username": "도우미",
+		"embeds": []map[string]interface{}{{
+			"title":       message.Title,
+			"description": message.Text,
+			"color":       discordColors[message.Event],
+			"timestamp":   message.Time.Format(time.RFC3339),
+		}},
+	}, nil)
+}
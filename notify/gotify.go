@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Gotify 메시지 우선순위 (클라이언트 설정에 따라 소리, 진동 여부가 달라짐)
+const (
+	gotifyPriority         = 5
+	gotifyProgressPriority = 2
+	gotifyErrorPriority    = 8
+)
+
+// Gotify는 Gotify 서버로 알림을 보냅니다
+type Gotify struct {
+	Server string // Gotify 서버 주소
+	Token  string // 애플리케이션 토큰
+	Client *http.Client
+}
+
+// Send는 알림을 메시지로 보냅니다
+func (g *Gotify) Send(message Message) error {
+	if g.Server == "" || g.Token == "" {
+		return fmt.Errorf("Gotify 서버 주소와 토큰이 필요합니다")
+	}
+
+	priority := gotifyPriority
+	switch message.Event {
+	case EventError:
+		priority = gotifyErrorPriority
+	case EventProgress:
+		priority = gotifyProgressPriority
+	}
+	header := http.Header{}
+	header.Set("X-Gotify-Key", g.Token)
+	return postJSON(g.Client, strings.TrimSuffix(g.Server, "/")+"/message", map[string]interface{}{
+		"title":    message.Title,
+		"message":  message.Text,
+		"priority": priority,
+	}, header)
+}
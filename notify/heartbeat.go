@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 생존 신호 기본 주기와 허용 범위 (초)
+const (
+	DefaultHeartbeatInterval = 60
+	minHeartbeatInterval     = 10
+	maxHeartbeatInterval     = 3600
+)
+
+// Heartbeat는 작업 실행 중에 주기적으로 보내는 생존 신호 설정입니다
+// 신호가 끊기면 PC나 프로그램이 멈춘 것이므로 외부 감시 도구(healthchecks.io, Uptime Kuma의 Push 모니터,
+// 파일을 확인하는 스크립트 등)가 알아챌 수 있습니다
+type Heartbeat struct {
+	URL      string `json:"url,omitempty"`      // 신호마다 GET으로 호출할 주소
+	File     string `json:"file,omitempty"`     // 신호마다 상태(Beat)를 JSON으로 기록할 파일 (설정 API로는 앱 데이터 디렉토리 안만)
+	Interval int    `json:"interval,omitempty"` // 신호 주기 (초, 0이면 DefaultHeartbeatInterval)
+
+	Client *http.Client `json:"-"` // nil이면 기본 제한 시간의 클라이언트
+}
+
+// Beat는 생존 신호 하나의 내용입니다 (File에 기록되는 JSON)
+type Beat struct {
+	Time       time.Time `json:"time"`
+	Next       time.Time `json:"next,omitzero"` // 다음 신호 예정 시각 (작업이 끝났으면 비어 있음)
+	State      string    `json:"state"`         // 작업 상태 ("running", "paused", 끝나면 "completed", "failed")
+	Mode       string    `json:"mode,omitempty"`
+	Elapsed    int64     `json:"elapsed_seconds"`
+	Remaining  int64     `json:"remaining_seconds,omitempty"`
+	Iterations int       `json:"iterations"`
+	PID        int       `json:"pid"`
+}
+
+// Enabled는 신호를 보낼 곳이 있는지 확인합니다
+func (h Heartbeat) Enabled() bool {
+	return h.URL != "" || h.File != ""
+}
+
+// Period는 신호 주기를 반환합니다
+func (h Heartbeat) Period() time.Duration {
+	if h.Interval <= 0 {
+		return DefaultHeartbeatInterval * time.Second
+	}
+	return time.Duration(h.Interval) * time.Second
+}
+
+// Validate는 설정 값이 올바른지 확인합니다
+func (h Heartbeat) Validate() error {
+	if h.URL != "" {
+		u, err := url.Parse(h.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("올바른 http(s) 주소가 아닙니다: %s", h.URL)
+		}
+	}
+	if h.File != "" && !filepath.IsAbs(h.File) {
+		return fmt.Errorf("생존 신호 파일은 절대 경로여야 합니다: %s", h.File)
+	}
+	if h.Interval != 0 && (h.Interval < minHeartbeatInterval || h.Interval > maxHeartbeatInterval) {
+		return fmt.Errorf("생존 신호 주기는 %d초에서 %d초 사이여야 합니다", minHeartbeatInterval, maxHeartbeatInterval)
+	}
+	return nil
+}
+
+// ValidateFile은 생존 신호 파일이 dir 안에 있는지, 이미 있는 파일이면 생존 신호 파일인지 확인합니다
+// 설정 API로 받은 경로가 다른 파일(설정, 실행 기록 등)을 덮어쓰지 않게 합니다
+func (h Heartbeat) ValidateFile(dir string) error {
+	if h.File == "" {
+		return nil
+	}
+	rel, err := filepath.Rel(dir, filepath.Clean(h.File))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("생존 신호 파일은 %s 안에 있어야 합니다: %s", dir, h.File)
+	}
+
+	info, err := os.Lstat(h.File)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("생존 신호 파일을 확인할 수 없습니다: %v", err)
+	}
+	if !info.Mode().IsRegular() || !isBeatFile(h.File) {
+		return fmt.Errorf("생존 신호 파일이 아닌 파일은 덮어쓸 수 없습니다: %s", h.File)
+	}
+	return nil
+}
+
+// isBeatFile은 파일이 writeBeat로 기록한 생존 신호인지 확인합니다
+func isBeatFile(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(io.LimitReader(file, 64<<10)).Decode(&fields); err != nil {
+		return false
+	}
+	for _, key := range []string{"time", "state", "pid"} {
+		if _, ok := fields[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Send는 파일에 상태를 기록하고 주소를 호출합니다
+// 한쪽이 실패해도 다른 쪽은 시도하며, 실패한 내용을 모아 반환합니다
+func (h Heartbeat) Send(beat Beat) error {
+	var problems []string
+	if h.File != "" {
+		if err := writeBeat(h.File, beat); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if h.URL != "" {
+		if err := h.ping(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("생존 신호 전송 실패: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ping은 주소를 GET으로 호출합니다
+func (h Heartbeat) ping() error {
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	resp, err := client.Get(h.URL)
+	if err != nil {
+		return fmt.Errorf("주소 호출 실패: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("감시 서버 오류: %d %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// writeBeat는 감시 도구가 쓰다 만 파일을 읽지 않도록 임시 파일에 쓴 뒤 바꿔치기합니다
+func writeBeat(path string, beat Beat) error {
+	data, err := json.MarshalIndent(beat, "", "  ")
+	if err != nil {
+		return fmt.Errorf("상태 인코딩 실패: %v", err)
+	}
+
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, data, 0644); err != nil {
+		return fmt.Errorf("파일 기록 실패: %v", err)
+	}
+	if err := os.Rename(temp, path); err != nil {
+		os.Remove(temp)
+		return fmt.Errorf("파일 기록 실패: %v", err)
+	}
+	return nil
+}
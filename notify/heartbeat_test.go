@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatPing(t *testing.T) {
+	server, requests := newBackendServer(t, http.StatusOK)
+	heartbeat := Heartbeat{URL: server.URL + "/ping/abc"}
+	if err := heartbeat.Send(Beat{State: "running"}); err != nil {
+		t.Fatal(err)
+	}
+	got := requests()
+	if len(got) != 1 || got[0].Method != http.MethodGet || got[0].Path != "/ping/abc" {
+		t.Errorf("요청 %+v", got)
+	}
+
+	failing, _ := newBackendServer(t, http.StatusNotFound)
+	err := Heartbeat{URL: failing.URL}.Send(Beat{})
+	if err == nil || !strings.Contains(err.Error(), "감시 서버 오류: 404 응답 본문") {
+		t.Errorf("오류: %v", err)
+	}
+}
+
+func TestHeartbeatWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heartbeat.json")
+	heartbeat := Heartbeat{File: path}
+	first := Beat{Time: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), State: "running", Mode: "대야", Elapsed: 60, PID: 42}
+	if err := heartbeat.Send(first); err != nil {
+		t.Fatal(err)
+	}
+	last := first
+	last.State = "completed"
+	last.Elapsed = 120
+	if err := heartbeat.Send(last); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Beat
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("JSON 해석 실패: %v (%s)", err, data)
+	}
+	if got != last {
+		t.Errorf("기록된 상태 %+v, 기대 %+v", got, last)
+	}
+	// 임시 파일을 바꿔치기하므로 남은 파일이 없어야 함
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("디렉토리 파일 %d개, 기대 1개", len(entries))
+	}
+
+	// 파일 기록이 실패해도 주소는 호출
+	server, requests := newBackendServer(t, http.StatusOK)
+	heartbeat = Heartbeat{File: filepath.Join(t.TempDir(), "없는 디렉토리", "heartbeat.json"), URL: server.URL}
+	if err := heartbeat.Send(first); err == nil || !strings.Contains(err.Error(), "파일 기록 실패") {
+		t.Errorf("오류: %v", err)
+	}
+	if len(requests()) != 1 {
+		t.Error("파일 기록이 실패하자 주소를 호출하지 않았습니다")
+	}
+}
+
+func TestHeartbeatValidateBounds(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "heartbeat.json")
+	cases := []struct {
+		name      string
+		heartbeat Heartbeat
+		valid     bool
+	}{
+		{"빈 설정", Heartbeat{}, true},
+		{"기본 주기", Heartbeat{URL: "https://hc-ping.com/abc"}, true},
+		{"최소 주기", Heartbeat{URL: "http://localhost:3001/api/push/x", Interval: minHeartbeatInterval}, true},
+		{"최대 주기", Heartbeat{File: file, Interval: maxHeartbeatInterval}, true},
+		{"너무 짧은 주기", Heartbeat{File: file, Interval: minHeartbeatInterval - 1}, false},
+		{"너무 긴 주기", Heartbeat{File: file, Interval: maxHeartbeatInterval + 1}, false},
+		{"음수 주기", Heartbeat{File: file, Interval: -1}, false},
+		{"http가 아닌 주소", Heartbeat{URL: "ftp://example.com"}, false},
+		{"호스트 없는 주소", Heartbeat{URL: "https://"}, false},
+		{"상대 경로 파일", Heartbeat{File: "heartbeat.json"}, false},
+	}
+	for _, tc := range cases {
+		if err := tc.heartbeat.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: 오류 %v", tc.name, err)
+		}
+	}
+
+	if period := (Heartbeat{}).Period(); period != DefaultHeartbeatInterval*time.Second {
+		t.Errorf("기본 주기 %v", period)
+	}
+}
+
+func TestHeartbeatValidateFile(t *testing.T) {
+	dir := t.TempDir()
+	beat := filepath.Join(dir, "heartbeat.json")
+	if err := (Heartbeat{File: beat}).Send(Beat{State: "completed", PID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	settings := filepath.Join(dir, "settings.json")
+	if err := os.WriteFile(settings, []byte(`{"language":"ko"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sequences"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name  string
+		file  string
+		valid bool
+	}{
+		{"파일 없음", "", true},
+		{"새 파일", filepath.Join(dir, "new.json"), true},
+		{"하위 디렉토리의 새 파일", filepath.Join(dir, "monitor", "beat.json"), true},
+		{"이전 생존 신호 파일", beat, true},
+		{"다른 파일", settings, false},
+		{"디렉토리", filepath.Join(dir, "sequences"), false},
+		{"데이터 디렉토리 자체", dir, false},
+		{"데이터 디렉토리 밖", filepath.Join(filepath.Dir(dir), "heartbeat.json"), false},
+		{"상위 디렉토리로 벗어남", filepath.Join(dir, "..", "heartbeat.json"), false},
+	}
+	for _, tc := range cases {
+		if err := (Heartbeat{File: tc.file}).ValidateFile(dir); (err == nil) != tc.valid {
+			t.Errorf("%s: 오류 %v", tc.name, err)
+		}
+	}
+}
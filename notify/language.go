@@ -0,0 +1,87 @@
+package notify
+
+// 알림 언어
+const (
+	LanguageKorean  = "ko"
+	LanguageEnglish = "en"
+)
+
+// messageTexts는 한 언어의 알림 제목과 문구입니다 (문구는 fmt 형식)
+type messageTexts struct {
+	startTitle string
+	start      string // 모드
+	estimated  string // 예상 실행 시간
+
+	progressTitle      string
+	progress           string // 모드, 실행 시간
+	remaining          string // 남은 시간
+	progressIterations string // 반복 횟수
+
+	completionTitle      string
+	completion           string // 모드, 총 실행 시간
+	completionIterations string // 반복 횟수
+
+	errorTitle string
+	error      string // 모드, 오류 내용
+
+	testTitle string
+	test      string
+
+	units [3]string // 시간, 분, 초
+}
+
+// languageTexts는 언어별 알림 문구입니다
+var languageTexts = map[string]messageTexts{
+	LanguageKorean: {
+		startTitle: "🚀 매크로 작업 시작",
+		start:      "%s 모드 작업을 시작했습니다.",
+		estimated:  " 예상 실행 시간: %s",
+
+		progressTitle:      "⏳ 매크로 진행 상황",
+		progress:           "%s 모드 작업 실행 중입니다. 실행 시간: %s",
+		remaining:          ", 남은 시간: %s",
+		progressIterations: ", %d회 반복",
+
+		completionTitle:      "🎉 매크로 작업 완료",
+		completion:           "%s 모드 작업이 완료되었습니다. 총 실행 시간: %s",
+		completionIterations: " (%d회 반복)",
+
+		errorTitle: "⚠️ 매크로 오류 발생",
+		error:      "%s 모드 작업 중 오류가 발생했습니다: %s",
+
+		testTitle: "🤖 도우미 연결 테스트",
+		test:      "알림이 정상적으로 연결되었습니다.",
+
+		units: [3]string{"시간", "분", "초"},
+	},
+	LanguageEnglish: {
+		startTitle: "🚀 Macro started",
+		start:      "Started %s mode.",
+		estimated:  " Estimated run time: %s",
+
+		progressTitle:      "⏳ Macro progress",
+		progress:           "%s mode is running. Run time: %s",
+		remaining:          ", remaining: %s",
+		progressIterations: ", %d iterations",
+
+		completionTitle:      "🎉 Macro completed",
+		completion:           "%s mode has completed. Total run time: %s",
+		completionIterations: " (%d iterations)",
+
+		errorTitle: "⚠️ Macro error",
+		error:      "An error occurred in %s mode: %s",
+
+		testTitle: "🤖 Helper connection test",
+		test:      "Notifications are connected.",
+
+		units: [3]string{"h", "m", "s"},
+	},
+}
+
+// textsFor는 언어의 알림 문구를 반환합니다 (지원하지 않는 언어이면 한국어)
+func textsFor(language string) messageTexts {
+	if texts, ok := languageTexts[language]; ok {
+		return texts
+	}
+	return languageTexts[LanguageKorean]
+}
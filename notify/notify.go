@@ -0,0 +1,240 @@
+// Package notify는 작업 시작, 완료, 오류 알림을 여러 서비스로 보냅니다
+// 텔레그램 봇과 디스코드, 슬랙, 일반 웹훅, ntfy, Gotify를 같은 Notifier 인터페이스로 다룹니다
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 요청 기본 대기 시간
+const defaultTimeout = 10 * time.Second
+
+// Event는 알림을 보내는 시점입니다
+type Event string
+
+// 알림 이벤트 상수
+const (
+	EventStart      Event = "start"      // 작업 시작
+	EventProgress   Event = "progress"   // 실행 중 주기적인 진행 상황
+	EventCompletion Event = "completion" // 작업 완료 (재생 목록 종료 포함)
+	EventError      Event = "error"      // 오류로 작업 중지
+	EventTest       Event = "test"       // 연결 테스트 (항상 전송)
+)
+
+// Events는 설정할 수 있는 알림 이벤트 목록입니다
+var Events = []Event{EventStart, EventProgress, EventCompletion, EventError}
+
+// Details는 알림에 담는 작업 정보입니다
+type Details struct {
+	Mode       string        // 모드(시퀀스) 이름
+	Duration   time.Duration // 시작: 예상 실행 시간 (0이면 제한 없음), 진행: 지금까지의 실행 시간, 완료·오류: 실제 실행 시간
+	Remaining  time.Duration // 진행: 남은 실행 시간 (제한이 없으면 0)
+	Start      time.Time     // 시작 시각
+	End        time.Time     // 시작·진행: 종료 예상 시각 (제한이 없으면 0), 완료·오류: 끝난 시각
+	Iterations int           // 완료한 반복 횟수
+	Error      string        // 오류 내용 (오류 알림만)
+}
+
+// Notifier는 작업 알림을 받을 수 있는 대상입니다
+// telegram.TelegramBot도 이 인터페이스를 구현합니다
+type Notifier interface {
+	SendStartNotification(details Details) error
+	SendProgressNotification(details Details) error
+	SendCompletionNotification(details Details) error
+	SendErrorNotification(details Details) error
+	TestConnection() error
+}
+
+// PhotoSender는 화면 캡처를 함께 보낼 수 있는 Notifier입니다
+type PhotoSender interface {
+	SendPhoto(img image.Image, caption string) error
+}
+
+// Target은 알림 대상 하나와 그 대상이 받을 이벤트입니다
+type Target struct {
+	Name     string
+	Notifier Notifier
+	Events   []Event // 비어 있으면 모든 이벤트
+}
+
+// Wants는 대상이 event 알림을 받는지 확인합니다
+func (t Target) Wants(event Event) bool {
+	return event == EventTest || len(t.Events) == 0 || slices.Contains(t.Events, event)
+}
+
+// Send는 event를 받는 대상마다 send를 호출합니다
+// 느린 대상이 다른 대상을 막지 않도록 각각 고루틴에서 보내며 실패는 로그에 남깁니다
+func Send(targets []Target, event Event, send func(Notifier) error) {
+	for _, target := range targets {
+		if !target.Wants(event) {
+			continue
+		}
+		go func(target Target) {
+			if err := send(target.Notifier); err != nil {
+				log.Printf("%s %s 알림 전송 실패: %v", target.Name, event, err)
+			}
+		}(target)
+	}
+}
+
+// Message는 서비스에 상관없이 보낼 알림 내용입니다
+type Message struct {
+	Event      Event         `json:"event"`
+	Title      string        `json:"title"`
+	Text       string        `json:"message"`
+	Mode       string        `json:"mode,omitempty"`
+	Duration   time.Duration `json:"-"`
+	Iterations int           `json:"iterations,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Time       time.Time     `json:"time"`
+}
+
+// Sender는 알림 내용 하나를 보내는 서비스입니다
+type Sender interface {
+	Send(message Message) error
+}
+
+// Wrap은 Sender를 Notifier로 사용할 수 있게 합니다
+// 알림 문구는 language로 만들며, 지원하지 않는 언어이면 한국어를 사용합니다
+func Wrap(sender Sender, language string) Notifier {
+	return senderNotifier{sender: sender, language: language}
+}
+
+// senderNotifier는 Notifier의 각 알림을 Message로 만들어 Sender로 보냅니다
+type senderNotifier struct {
+	sender   Sender
+	language string
+}
+
+// SendStartNotification은 작업 시작 알림을 보냅니다
+func (n senderNotifier) SendStartNotification(details Details) error {
+	texts := textsFor(n.language)
+	text := fmt.Sprintf(texts.start, details.Mode)
+	if details.Duration > 0 {
+		text += fmt.Sprintf(texts.estimated, formatDurationIn(n.language, details.Duration))
+	}
+	return n.sender.Send(newMessage(EventStart, texts.startTitle, text, details, details.Start))
+}
+
+// SendProgressNotification은 실행 중인 작업의 진행 상황을 보냅니다
+func (n senderNotifier) SendProgressNotification(details Details) error {
+	texts := textsFor(n.language)
+	text := fmt.Sprintf(texts.progress, details.Mode, formatDurationIn(n.language, details.Duration))
+	if details.Remaining > 0 {
+		text += fmt.Sprintf(texts.remaining, formatDurationIn(n.language, details.Remaining))
+	}
+	if details.Iterations > 0 {
+		text += fmt.Sprintf(texts.progressIterations, details.Iterations)
+	}
+	return n.sender.Send(newMessage(EventProgress, texts.progressTitle, text, details, time.Time{}))
+}
+
+// SendCompletionNotification은 작업 완료 알림을 보냅니다
+func (n senderNotifier) SendCompletionNotification(details Details) error {
+	texts := textsFor(n.language)
+	text := fmt.Sprintf(texts.completion, details.Mode, formatDurationIn(n.language, details.Duration))
+	if details.Iterations > 0 {
+		text += fmt.Sprintf(texts.completionIterations, details.Iterations)
+	}
+	return n.sender.Send(newMessage(EventCompletion, texts.completionTitle, text, details, details.End))
+}
+
+// SendErrorNotification은 오류 알림을 보냅니다
+func (n senderNotifier) SendErrorNotification(details Details) error {
+	texts := textsFor(n.language)
+	text := fmt.Sprintf(texts.error, details.Mode, details.Error)
+	return n.sender.Send(newMessage(EventError, texts.errorTitle, text, details, details.End))
+}
+
+// newMessage는 작업 정보로 Message를 만듭니다 (at이 비어 있으면 현재 시각)
+func newMessage(event Event, title, text string, details Details, at time.Time) Message {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return Message{
+		Event:      event,
+		Title:      title,
+		Text:       text,
+		Mode:       details.Mode,
+		Duration:   details.Duration,
+		Iterations: details.Iterations,
+		Error:      details.Error,
+		Time:       at,
+	}
+}
+
+// TestConnection은 테스트 알림을 보냅니다
+func (n senderNotifier) TestConnection() error {
+	texts := textsFor(n.language)
+	return n.sender.Send(Message{Event: EventTest, Title: texts.testTitle, Text: texts.test, Time: time.Now()})
+}
+
+// postJSON은 payload를 JSON으로 보내고 2xx가 아니면 오류를 반환합니다
+func postJSON(client *http.Client, url string, payload interface{}, header http.Header) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("요청 인코딩 실패: %v", err)
+	}
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+	return post(client, url, bytes.NewReader(body), header)
+}
+
+// post는 요청을 보내고 2xx가 아니면 응답 일부를 포함한 오류를 반환합니다
+func post(client *http.Client, url string, body io.Reader, header http.Header) error {
+	if url == "" {
+		return fmt.Errorf("알림 주소가 설정되지 않았습니다")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return fmt.Errorf("요청 생성 실패: %v", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("알림 전송 실패: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("알림 서버 오류: %d %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// formatDurationIn은 언어에 맞게 시간 길이를 표시합니다 ("1시간 2분 3초", "1h 2m 3s")
+func formatDurationIn(language string, d time.Duration) string {
+	units := textsFor(language).units
+	d = d.Round(time.Second)
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%d%s %d%s %d%s", hours, units[0], minutes, units[1], seconds, units[2])
+	case minutes > 0:
+		return fmt.Sprintf("%d%s %d%s", minutes, units[1], seconds, units[2])
+	default:
+		return fmt.Sprintf("%d%s", seconds, units[2])
+	}
+}
@@ -0,0 +1,301 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordedRequest는 가짜 알림 서버가 받은 요청입니다
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+// newBackendServer는 받은 요청을 기록하고 status로 응답하는 서버를 생성합니다
+func newBackendServer(t *testing.T, status int) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+	var mutex sync.Mutex
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		requests = append(requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone(), Body: string(body)})
+		mutex.Unlock()
+		w.WriteHeader(status)
+		w.Write([]byte("응답 본문"))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []recordedRequest {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]recordedRequest{}, requests...)
+	}
+}
+
+// onlyRequest는 요청이 하나만 왔는지 확인하고 반환합니다
+func onlyRequest(t *testing.T, requests func() []recordedRequest) recordedRequest {
+	t.Helper()
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("요청 %d개, 기대 1개", len(got))
+	}
+	if got[0].Method != http.MethodPost {
+		t.Errorf("메서드 %s, 기대 POST", got[0].Method)
+	}
+	return got[0]
+}
+
+// decodeBody는 JSON 본문을 해석합니다
+func decodeBody(t *testing.T, request recordedRequest) map[string]interface{} {
+	t.Helper()
+	if contentType := request.Header.Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type %q", contentType)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		t.Fatalf("JSON 본문 해석 실패: %v (%s)", err, request.Body)
+	}
+	return body
+}
+
+var testMessage = Message{
+	Event:      EventCompletion,
+	Title:      "🎉 작업 완료",
+	Text:       "대야 모드 작업이 완료되었습니다.",
+	Mode:       "대야",
+	Duration:   90 * time.Second,
+	Iterations: 3,
+	Time:       time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+}
+
+func TestDiscordSend(t *testing.T) {
+	server, requests := newBackendServer(t, http.StatusNoContent)
+	if err := (&Discord{WebhookURL: server.URL + "/api/webhooks/1/abc"}).Send(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	request := onlyRequest(t, requests)
+	if request.Path != "/api/webhooks/1/abc" {
+		t.Errorf("경로 %s", request.Path)
+	}
+	body := decodeBody(t, request)
+	embeds, _ := body["embeds"].([]interface{})
+	if body["username"] != "도우미" || len(embeds) != 1 {
+		t.Fatalf("본문 %s", request.Body)
+	}
+	embed := embeds[0].(map[string]interface{})
+	if embed["title"] != testMessage.Title || embed["description"] != testMessage.Text ||
+		embed["color"] != float64(discordColors[EventCompletion]) || embed["timestamp"] != "2026-01-01T09:00:00Z" {
+		t.Errorf("임베드 %v", embed)
+	}
+}
+
+func TestSlackSend(t *testing.T) {
+	server, requests := newBackendServer(t, http.StatusOK)
+	if err := (&Slack{WebhookURL: server.URL + "/services/T/B/X"}).Send(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	request := onlyRequest(t, requests)
+	if request.Path != "/services/T/B/X" {
+		t.Errorf("경로 %s", request.Path)
+	}
+	if body := decodeBody(t, request); body["text"] != "*🎉 작업 완료*\n대야 모드 작업이 완료되었습니다." {
+		t.Errorf("본문 %v", body)
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	server, requests := newBackendServer(t, http.StatusAccepted)
+	if err := (&Webhook{URL: server.URL + "/hook", Token: "secret"}).Send(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	request := onlyRequest(t, requests)
+	if request.Path != "/hook" || request.Header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("경로 %s, Authorization %q", request.Path, request.Header.Get("Authorization"))
+	}
+	body := decodeBody(t, request)
+	want := map[string]interface{}{
+		"event":            "completion",
+		"title":            testMessage.Title,
+		"message":          testMessage.Text,
+		"mode":             "대야",
+		"iterations":       float64(3),
+		"time":             "2026-01-01T09:00:00Z",
+		"duration_seconds": float64(90),
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("%s: %v, 기대 %v", key, body[key], value)
+		}
+	}
+	if _, ok := body["error"]; ok {
+		t.Errorf("빈 error 필드가 포함되었습니다: %s", request.Body)
+	}
+}
+
+func TestNtfySend(t *testing.T) {
+	server, requests := newBackendServer(t, http.StatusOK)
+	ntfy := &Ntfy{Server: server.URL + "/", Topic: "doumi alerts", Token: "tk_abc"}
+
+	message := testMessage
+	message.Event = EventError
+	if err := ntfy.Send(message); err != nil {
+		t.Fatal(err)
+	}
+
+	request := onlyRequest(t, requests)
+	if request.Path != "/doumi alerts" || request.Body != message.Text {
+		t.Errorf("경로 %q, 본문 %q", request.Path, request.Body)
+	}
+	title, err := new(mime.WordDecoder).DecodeHeader(request.Header.Get("Title"))
+	if err != nil || title != message.Title {
+		t.Errorf("Title %q (%v)", request.Header.Get("Title"), err)
+	}
+	if request.Header.Get("Priority") != "high" || request.Header.Get("Tags") != "warning" ||
+		request.Header.Get("Authorization") != "Bearer tk_abc" {
+		t.Errorf("헤더 %v", request.Header)
+	}
+
+	// 진행 알림은 낮은 우선순위, 완료 알림은 기본 우선순위
+	message.Event = EventProgress
+	ntfy.Send(message)
+	message.Event = EventCompletion
+	ntfy.Send(message)
+	got := requests()
+	if got[1].Header.Get("Priority") != "low" || got[2].Header.Get("Priority") != "" {
+		t.Errorf("우선순위 %q, %q", got[1].Header.Get("Priority"), got[2].Header.Get("Priority"))
+	}
+}
+
+func TestGotifySend(t *testing.T) {
+	server, requests := newBackendServer(t, http.StatusOK)
+	if err := (&Gotify{Server: server.URL + "/", Token: "AbCdEf"}).Send(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	request := onlyRequest(t, requests)
+	if request.Path != "/message" || request.Header.Get("X-Gotify-Key") != "AbCdEf" {
+		t.Errorf("경로 %s, 토큰 %q", request.Path, request.Header.Get("X-Gotify-Key"))
+	}
+	body := decodeBody(t, request)
+	if body["title"] != testMessage.Title || body["message"] != testMessage.Text || body["priority"] != float64(gotifyPriority) {
+		t.Errorf("본문 %v", body)
+	}
+}
+
+func TestBackendErrorStatus(t *testing.T) {
+	server, _ := newBackendServer(t, http.StatusInternalServerError)
+	senders := map[string]Sender{
+		"discord": &Discord{WebhookURL: server.URL},
+		"slack":   &Slack{WebhookURL: server.URL},
+		"webhook": &Webhook{URL: server.URL},
+		"ntfy":    &Ntfy{Server: server.URL, Topic: "t"},
+		"gotify":  &Gotify{Server: server.URL, Token: "t"},
+	}
+	for name, sender := range senders {
+		err := sender.Send(testMessage)
+		if err == nil || !strings.Contains(err.Error(), "알림 서버 오류: 500 응답 본문") {
+			t.Errorf("%s: 오류 %v", name, err)
+		}
+	}
+}
+
+// messageRecorder는 보낸 Message를 모으는 Sender입니다
+type messageRecorder struct {
+	messages []Message
+}
+
+func (r *messageRecorder) Send(message Message) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestWrapLanguage(t *testing.T) {
+	details := Details{Mode: "대야", Duration: 3723 * time.Second, Remaining: 90 * time.Second, Iterations: 4, Error: "입력 실패"}
+	cases := []struct {
+		language        string
+		start           string
+		progress        string
+		completion      string
+		error           string
+		completionTitle string
+		testTitle       string
+	}{
+		{
+			language:        LanguageKorean,
+			start:           "대야 모드 작업을 시작했습니다. 예상 실행 시간: 1시간 2분 3초",
+			progress:        "대야 모드 작업 실행 중입니다. 실행 시간: 1시간 2분 3초, 남은 시간: 1분 30초, 4회 반복",
+			completion:      "대야 모드 작업이 완료되었습니다. 총 실행 시간: 1시간 2분 3초 (4회 반복)",
+			error:           "대야 모드 작업 중 오류가 발생했습니다: 입력 실패",
+			completionTitle: "🎉 매크로 작업 완료",
+			testTitle:       "🤖 도우미 연결 테스트",
+		},
+		{
+			language:        LanguageEnglish,
+			start:           "Started 대야 mode. Estimated run time: 1h 2m 3s",
+			progress:        "대야 mode is running. Run time: 1h 2m 3s, remaining: 1m 30s, 4 iterations",
+			completion:      "대야 mode has completed. Total run time: 1h 2m 3s (4 iterations)",
+			error:           "An error occurred in 대야 mode: 입력 실패",
+			completionTitle: "🎉 Macro completed",
+			testTitle:       "🤖 Helper connection test",
+		},
+		{
+			// 지원하지 않는 언어는 한국어
+			language:        "fr",
+			start:           "대야 모드 작업을 시작했습니다. 예상 실행 시간: 1시간 2분 3초",
+			progress:        "대야 모드 작업 실행 중입니다. 실행 시간: 1시간 2분 3초, 남은 시간: 1분 30초, 4회 반복",
+			completion:      "대야 모드 작업이 완료되었습니다. 총 실행 시간: 1시간 2분 3초 (4회 반복)",
+			error:           "대야 모드 작업 중 오류가 발생했습니다: 입력 실패",
+			completionTitle: "🎉 매크로 작업 완료",
+			testTitle:       "🤖 도우미 연결 테스트",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.language, func(t *testing.T) {
+			recorder := &messageRecorder{}
+			notifier := Wrap(recorder, tc.language)
+			notifier.SendStartNotification(details)
+			notifier.SendProgressNotification(details)
+			notifier.SendCompletionNotification(details)
+			notifier.SendErrorNotification(details)
+			notifier.TestConnection()
+
+			if len(recorder.messages) != 5 {
+				t.Fatalf("보낸 알림 %d개", len(recorder.messages))
+			}
+			want := []struct {
+				event Event
+				text  string
+			}{
+				{EventStart, tc.start},
+				{EventProgress, tc.progress},
+				{EventCompletion, tc.completion},
+				{EventError, tc.error},
+			}
+			for i, w := range want {
+				message := recorder.messages[i]
+				if message.Event != w.event || message.Text != w.text {
+					t.Errorf("%s 알림 %q, 기대 %q", w.event, message.Text, w.text)
+				}
+			}
+			if title := recorder.messages[2].Title; title != tc.completionTitle {
+				t.Errorf("완료 알림 제목 %q, 기대 %q", title, tc.completionTitle)
+			}
+			if test := recorder.messages[4]; test.Event != EventTest || test.Title != tc.testTitle {
+				t.Errorf("테스트 알림 %+v", test)
+			}
+		})
+	}
+}
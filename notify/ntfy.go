@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ntfy 기본 서버
+const DefaultNtfyServer = "https://ntfy.sh"
+
+// 이벤트별 ntfy 태그 (알림에 이모지로 표시됨)
+var ntfyTags = map[Event]string{
+	EventStart:      "rocket",
+	EventProgress:   "hourglass",
+	EventCompletion: "tada",
+	EventError:      "warning",
+	EventTest:       "robot",
+}
+
+// Ntfy는 ntfy 토픽으로 알림을 보냅니다
+type Ntfy struct {
+	Server string // 비어 있으면 DefaultNtfyServer
+	Topic  string
+	Token  string // 접근 토큰 (비공개 토픽)
+	Client *http.Client
+}
+
+// Send는 알림을 토픽에 게시합니다 (오류는 높은 우선순위)
+func (n *Ntfy) Send(message Message) error {
+	server := n.Server
+	if server == "" {
+		server = DefaultNtfyServer
+	}
+	if n.Topic == "" {
+		return fmt.Errorf("ntfy 토픽이 설정되지 않았습니다")
+	}
+
+	header := http.Header{}
+	// HTTP 헤더에는 ASCII만 쓸 수 있으므로 제목은 RFC 2047 형식으로 인코딩
+	header.Set("Title", mimeHeader(message.Title))
+	header.Set("Tags", ntfyTags[message.Event])
+	switch message.Event {
+	case EventError:
+		header.Set("Priority", "high")
+	case EventProgress:
+		header.Set("Priority", "low")
+	}
+	if n.Token != "" {
+		header.Set("Authorization", "Bearer "+n.Token)
+	}
+	topicURL := strings.TrimSuffix(server, "/") + "/" + url.PathEscape(n.Topic)
+	return post(n.Client, topicURL, strings.NewReader(message.Text), header)
+}
+
+// mimeHeader는 ASCII가 아닌 문자가 있으면 헤더 값을 RFC 2047 형식으로 인코딩합니다
+func mimeHeader(value string) string {
+	return mime.QEncoding.Encode("utf-8", value)
+}
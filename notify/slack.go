@@ -0,0 +1,16 @@
+package notify
+
+import "net/http"
+
+// Slack은 슬랙 수신 웹훅(Incoming Webhook)으로 알림을 보냅니다
+type Slack struct {
+	WebhookURL string
+	Client     *http.Client // nil이면 기본 클라이언트
+}
+
+// Send는 알림을 제목이 굵게 표시된 메시지로 보냅니다
+func (s *Slack) Send(message Message) error {
+	return postJSON(s.Client, s.WebhookURL, map[string]interface{}{
+		"text": "*" + message.Title + "*\n" + message.Text,
+	}, nil)
+}
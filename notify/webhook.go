@@ -0,0 +1,29 @@
+package notify
+
+import "net/http"
+
+// Webhook은 알림 내용을 JSON으로 지정된 주소에 보냅니다
+// 본문은 Message 필드와 duration_seconds이며, Token이 있으면 Authorization: Bearer 헤더로 보냅니다
+type Webhook struct {
+	URL    string
+	Token  string
+	Client *http.Client // nil이면 기본 클라이언트
+}
+
+// webhookPayload는 일반 웹훅 요청 본문입니다
+type webhookPayload struct {
+	Message
+	DurationSeconds int64 `json:"duration_seconds,omitempty"`
+}
+
+// Send는 알림을 JSON으로 보냅니다
+func (w *Webhook) Send(message Message) error {
+	header := http.Header{}
+	if w.Token != "" {
+		header.Set("Authorization", "Bearer "+w.Token)
+	}
+	return postJSON(w.Client, w.URL, webhookPayload{
+		Message:         message,
+		DurationSeconds: int64(message.Duration.Seconds()),
+	}, header)
+}
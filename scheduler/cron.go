@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 다음 실행 시각을 찾을 최대 범위 (2월 29일 같은 일정도 찾을 수 있도록 몇 년)
+const cronSearchYears = 5
+
+// 자주 쓰는 일정의 약칭
+var cronDescriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+// cron 필드의 허용 범위
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"분", 0, 59},
+	{"시", 0, 23},
+	{"일", 1, 31},
+	{"월", 1, 12},
+	{"요일", 0, 7}, // 0과 7은 모두 일요일
+}
+
+// Cron은 "분 시 일 월 요일" 형식의 반복 일정입니다
+// 각 필드는 *, 숫자, 범위(1-5), 간격(*/15, 8-20/2), 목록(1,15,30)을 지원하며
+// 일과 요일이 모두 지정되면 둘 중 하나만 맞아도 실행합니다 (일반적인 cron과 같음)
+type Cron struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	day     uint64
+	month   uint64
+	weekday uint64
+	anyDay  bool // 일 필드가 *
+	anyWeek bool // 요일 필드가 *
+}
+
+// ParseCron은 cron 형식 문자열을 해석합니다 (@daily 같은 약칭 포함)
+func ParseCron(expr string) (*Cron, error) {
+	expr = strings.TrimSpace(expr)
+	fields := strings.Fields(expr)
+	if len(fields) == 1 {
+		if full, ok := cronDescriptors[strings.ToLower(fields[0])]; ok {
+			fields = strings.Fields(full)
+		}
+	}
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("잘못된 일정 '%s' (분 시 일 월 요일 5개 필드가 필요합니다)", expr)
+	}
+
+	var bits [5]uint64
+	for i, field := range cronFields {
+		value, err := parseCronField(fields[i], field)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 일정 '%s': %v", expr, err)
+		}
+		bits[i] = value
+	}
+
+	// 요일 7은 일요일(0)로 취급
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &Cron{
+		expr:    expr,
+		minute:  bits[0],
+		hour:    bits[1],
+		day:     bits[2],
+		month:   bits[3],
+		weekday: bits[4],
+		anyDay:  fields[2] == "*",
+		anyWeek: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField는 필드 하나를 허용 값의 비트 집합으로 변환합니다
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s 필드의 간격 '%s'", field.name, part)
+			}
+			step = n
+			part = part[:idx]
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			low, err1 = strconv.Atoi(bounds[0])
+			high, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil || low > high {
+				return 0, fmt.Errorf("%s 필드의 범위 '%s'", field.name, part)
+			}
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("%s 필드의 값 '%s'", field.name, part)
+			}
+			low, high = n, n
+			if step > 1 {
+				high = field.max // "5/15"는 5부터 끝까지 15 간격
+			}
+		}
+
+		if low < field.min || high > field.max {
+			return 0, fmt.Errorf("%s 필드는 %d-%d 범위여야 합니다 ('%s')", field.name, field.min, field.max, part)
+		}
+		for n := low; n <= high; n += step {
+			bits |= 1 << uint(n)
+		}
+	}
+	return bits, nil
+}
+
+// String은 원래의 일정 문자열을 반환합니다
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Next는 after 이후(after는 제외) 일정에 맞는 첫 시각을 반환합니다
+// 찾을 수 없으면(예: 2월 30일) 0 값을 반환합니다
+func (c *Cron) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// 서머타임 전환으로 같은 시각이 반복되는 경우
+				next = t.Add(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches는 날짜가 일/요일 필드에 맞는지 확인합니다
+func (c *Cron) dayMatches(t time.Time) bool {
+	day := has(c.day, t.Day())
+	week := has(c.weekday, int(t.Weekday()))
+	switch {
+	case c.anyDay && c.anyWeek:
+		return true
+	case c.anyDay:
+		return week
+	case c.anyWeek:
+		return day
+	default:
+		return day || week
+	}
+}
+
+// has는 비트 집합에 n이 포함되는지 확인합니다
+func has(bits uint64, n int) bool {
+	return bits&(1<<uint(n)) != 0
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string // 오류 메시지에 포함되어야 하는 내용
+	}{
+		{"", "5개 필드"},
+		{"0 4 * *", "5개 필드"},
+		{"0 4 * * * *", "5개 필드"},
+		{"@sometimes", "5개 필드"},
+		{"60 4 * * *", "분 필드는 0-59 범위"},
+		{"0 24 * * *", "시 필드는 0-23 범위"},
+		{"0 4 0 * *", "일 필드는 1-31 범위"},
+		{"0 4 * 13 *", "월 필드는 1-12 범위"},
+		{"0 4 * * 8", "요일 필드는 0-7 범위"},
+		{"*/0 * * * *", "분 필드의 간격"},
+		{"*/x * * * *", "분 필드의 간격"},
+		{"0 9-5 * * *", "시 필드의 범위"},
+		{"0 a-5 * * *", "시 필드의 범위"},
+		{"0 4 * * mon", "요일 필드의 값"},
+		{"0 4 1,,15 * *", "일 필드의 값"},
+	}
+	for _, c := range cases {
+		_, err := ParseCron(c.expr)
+		if err == nil {
+			t.Errorf("%q: 오류가 나야 합니다", c.expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%q: 오류 %q에 %q가 없습니다", c.expr, err, c.want)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	loc := time.FixedZone("KST", 9*3600)
+	base := time.Date(2026, 10, 17, 3, 59, 30, 0, loc) // 토요일
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 4 * * *", time.Date(2026, 10, 17, 4, 0, 0, 0, loc)},
+		{"@daily", time.Date(2026, 10, 18, 0, 0, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2026, 10, 17, 4, 0, 0, 0, loc)},
+		{"30 9 * * 1-5", time.Date(2026, 10, 19, 9, 30, 0, 0, loc)},
+		{"0 12 1 * 7", time.Date(2026, 10, 18, 12, 0, 0, 0, loc)}, // 일 또는 요일
+		{"5/20 3 * * *", time.Date(2026, 10, 18, 3, 5, 0, 0, loc)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, loc)},
+	}
+	for _, c := range cases {
+		cron, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("%q: %v", c.expr, err)
+		}
+		if got := cron.Next(base); !got.Equal(c.want) {
+			t.Errorf("%q: 다음 실행 %v, 기대 %v", c.expr, got, c.want)
+		}
+	}
+
+	// 존재하지 않는 날짜는 실행 시각이 없음
+	cron, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := cron.Next(base); !next.IsZero() {
+		t.Errorf("2월 30일 일정의 다음 실행 %v", next)
+	}
+}
@@ -0,0 +1,373 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"example.com/m/utils"
+)
+
+// 대기 중에도 이 간격마다 깨어나 시계 변경이나 절전 복귀를 반영
+const maxWait = time.Minute
+
+// 마지막 실행 결과
+const (
+	ResultStarted = "started" // 작업을 시작함
+	ResultSkipped = "skipped" // 다른 작업이 실행 중이라 건너뜀
+	ResultFailed  = "failed"  // 작업을 시작하지 못함
+)
+
+// Clock은 스케줄러가 사용하는 시간 소스입니다 (automation.Clock과 같은 형태)
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock은 실제 시간을 사용하는 기본 Clock입니다
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Schedule은 예약된 작업 하나입니다
+// Cron이 있으면 반복 실행하고, 없으면 At 시각에 한 번 실행한 뒤 비활성화됩니다
+type Schedule struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name,omitempty"`
+	Sequence      string    `json:"sequence"`           // 실행할 시퀀스 ID
+	Cron          string    `json:"cron,omitempty"`     // 반복 일정 ("0 4 * * *" = 매일 04:00)
+	At            time.Time `json:"at,omitzero"`        // 한 번만 실행할 시각
+	Duration      string    `json:"duration,omitempty"` // 실행 시간 ("2h10m", "3.5", 종료 시각 "06:00", 비어 있으면 제한 없음)
+	MaxIterations int       `json:"max_iterations,omitempty"`
+	Enabled       bool      `json:"enabled"`
+	LastRun       time.Time `json:"last_run,omitzero"`
+	LastResult    string    `json:"last_result,omitempty"`
+	LastError     string    `json:"last_error,omitempty"`
+	NextRun       time.Time `json:"next_run,omitzero"` // 다음 실행 예정 시각 (계산된 값)
+}
+
+// RunTime은 실행 시간 설정을 반환합니다 (0 값이면 제한 없음)
+func (s Schedule) RunTime() utils.RunTime {
+	runTime, _ := utils.ParseRunTime(s.Duration)
+	return runTime
+}
+
+// Validate는 일정 설정을 검사합니다
+func (s Schedule) Validate() error {
+	if s.Sequence == "" {
+		return fmt.Errorf("실행할 시퀀스가 지정되지 않았습니다")
+	}
+	if s.Cron == "" && s.At.IsZero() {
+		return fmt.Errorf("cron 일정이나 실행 시각이 필요합니다")
+	}
+	if s.Cron != "" && !s.At.IsZero() {
+		return fmt.Errorf("cron 일정과 실행 시각은 함께 지정할 수 없습니다")
+	}
+	if s.Cron != "" {
+		if _, err := ParseCron(s.Cron); err != nil {
+			return err
+		}
+	}
+	if _, err := utils.ParseRunTime(s.Duration); err != nil {
+		return err
+	}
+	if s.MaxIterations < 0 {
+		return fmt.Errorf("반복 횟수는 0 이상이어야 합니다")
+	}
+	return nil
+}
+
+// label은 로그에 표시할 일정 이름을 반환합니다
+func (s Schedule) label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Sequence
+}
+
+// next는 now 이후의 실행 예정 시각을 계산합니다 (없으면 0 값)
+func (s Schedule) next(now time.Time) time.Time {
+	if !s.Enabled {
+		return time.Time{}
+	}
+	if s.Cron != "" {
+		cron, err := ParseCron(s.Cron)
+		if err != nil {
+			return time.Time{}
+		}
+		return cron.Next(now)
+	}
+	// 지나간 일회성 일정은 실행하지 않음 (프로그램이 꺼져 있던 동안 놓친 경우 포함)
+	if s.At.After(now) {
+		return s.At
+	}
+	return time.Time{}
+}
+
+// Scheduler는 예약된 시각에 작업을 시작합니다
+// 시작할 때 다른 작업이 실행 중이면 그 회차는 건너뛰고 다음 일정을 기다립니다
+type Scheduler struct {
+	Clock Clock
+
+	// Launch는 일정의 작업을 시작합니다
+	Launch func(schedule Schedule) error
+	// Busy는 다른 작업이 실행 중인지 확인합니다
+	Busy func() bool
+	// OnChange는 일정 목록이 바뀔 때(추가, 수정, 삭제, 실행) 호출됩니다 (설정 저장용)
+	OnChange func(schedules []Schedule)
+
+	schedules []Schedule
+	mutex     sync.Mutex
+	wake      chan struct{}
+	quit      chan struct{}
+	running   bool
+}
+
+// New는 저장된 일정으로 스케줄러를 생성합니다 (clock이 nil이면 실제 시간 사용)
+func New(clock Clock, schedules []Schedule) *Scheduler {
+	if clock == nil {
+		clock = realClock{}
+	}
+	s := &Scheduler{
+		Clock: clock,
+		wake:  make(chan struct{}, 1),
+	}
+
+	now := clock.Now()
+	for _, schedule := range schedules {
+		if err := schedule.Validate(); err != nil {
+			log.Printf("경고: 잘못된 예약 '%s'을(를) 비활성화합니다: %v", schedule.ID, err)
+			schedule.Enabled = false
+		}
+		schedule.NextRun = schedule.next(now)
+		s.schedules = append(s.schedules, schedule)
+	}
+	return s
+}
+
+// Start는 일정 확인 루프를 시작합니다
+func (s *Scheduler) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.running {
+		return
+	}
+	s.running = true
+	s.quit = make(chan struct{})
+	go s.loop(s.quit)
+}
+
+// Stop은 일정 확인 루프를 중지합니다
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.running {
+		return
+	}
+	s.running = false
+	close(s.quit)
+}
+
+// loop는 다음 일정까지 기다렸다가 Tick을 호출합니다
+func (s *Scheduler) loop(quit chan struct{}) {
+	for {
+		wait := maxWait
+		if next := s.nextRun(); !next.IsZero() {
+			if until := next.Sub(s.Clock.Now()); until < wait {
+				wait = until
+			}
+		}
+
+		select {
+		case <-quit:
+			return
+		case <-s.wake:
+			// 일정이 바뀌어 대기 시간을 다시 계산
+		case <-s.Clock.After(wait):
+			s.Tick()
+		}
+	}
+}
+
+// nextRun은 가장 빠른 실행 예정 시각을 반환합니다
+func (s *Scheduler) nextRun() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var next time.Time
+	for _, schedule := range s.schedules {
+		if !schedule.NextRun.IsZero() && (next.IsZero() || schedule.NextRun.Before(next)) {
+			next = schedule.NextRun
+		}
+	}
+	return next
+}
+
+// Tick은 현재 시각에 실행할 일정을 모두 처리합니다
+// 여러 일정이 동시에 돌아오면 먼저 시작한 작업 외에는 건너뜁니다
+func (s *Scheduler) Tick() {
+	now := s.Clock.Now()
+
+	s.mutex.Lock()
+	var due []Schedule
+	for _, schedule := range s.schedules {
+		if !schedule.NextRun.IsZero() && !now.Before(schedule.NextRun) {
+			due = append(due, schedule)
+		}
+	}
+	s.mutex.Unlock()
+
+	if len(due) == 0 {
+		return
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextRun.Before(due[j].NextRun) })
+
+	for _, schedule := range due {
+		schedule.LastRun = now
+		schedule.LastError = ""
+
+		switch {
+		case s.Busy != nil && s.Busy():
+			schedule.LastResult = ResultSkipped
+			log.Printf("예약 작업 건너뜀: %s (다른 작업이 실행 중)", schedule.label())
+		case s.Launch == nil:
+			schedule.LastResult = ResultFailed
+			schedule.LastError = "작업 실행 함수가 설정되지 않았습니다"
+		default:
+			if err := s.Launch(schedule); err != nil {
+				schedule.LastResult = ResultFailed
+				schedule.LastError = err.Error()
+				log.Printf("예약 작업 시작 실패: %s: %v", schedule.label(), err)
+			} else {
+				schedule.LastResult = ResultStarted
+				log.Printf("예약 작업 시작: %s", schedule.label())
+			}
+		}
+
+		s.mutex.Lock()
+		if i := s.indexOf(schedule.ID); i >= 0 {
+			// 실행하는 동안 사용자가 바꾼 설정은 유지하고 실행 결과만 반영
+			current := &s.schedules[i]
+			current.LastRun = schedule.LastRun
+			current.LastResult = schedule.LastResult
+			current.LastError = schedule.LastError
+			// 일회성 일정은 한 번 처리하면 비활성화
+			if current.Cron == "" && current.At.Equal(schedule.At) {
+				current.Enabled = false
+			}
+			current.NextRun = current.next(now)
+		}
+		s.mutex.Unlock()
+	}
+
+	s.changed()
+}
+
+// List는 모든 일정을 반환합니다
+func (s *Scheduler) List() []Schedule {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Schedule{}, s.schedules...)
+}
+
+// Get은 ID로 일정을 찾습니다
+func (s *Scheduler) Get(id string) (Schedule, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if i := s.indexOf(id); i >= 0 {
+		return s.schedules[i], true
+	}
+	return Schedule{}, false
+}
+
+// Add는 새 일정을 추가합니다 (ID가 비어 있으면 새로 지정)
+func (s *Scheduler) Add(schedule Schedule) (Schedule, error) {
+	if err := schedule.Validate(); err != nil {
+		return schedule, err
+	}
+
+	s.mutex.Lock()
+	now := s.Clock.Now()
+	if schedule.ID == "" {
+		base := strconv.FormatInt(now.UnixNano(), 36)
+		schedule.ID = base
+		for n := 2; s.indexOf(schedule.ID) >= 0; n++ {
+			schedule.ID = fmt.Sprintf("%s-%d", base, n)
+		}
+	}
+	if s.indexOf(schedule.ID) >= 0 {
+		s.mutex.Unlock()
+		return schedule, fmt.Errorf("이미 존재하는 예약입니다: %s", schedule.ID)
+	}
+	schedule.NextRun = schedule.next(now)
+	s.schedules = append(s.schedules, schedule)
+	s.mutex.Unlock()
+
+	s.changed()
+	return schedule, nil
+}
+
+// Update는 같은 ID의 일정 설정을 바꿉니다 (마지막 실행 결과는 유지)
+func (s *Scheduler) Update(schedule Schedule) (Schedule, error) {
+	if err := schedule.Validate(); err != nil {
+		return schedule, err
+	}
+
+	s.mutex.Lock()
+	i := s.indexOf(schedule.ID)
+	if i < 0 {
+		s.mutex.Unlock()
+		return schedule, fmt.Errorf("예약을 찾을 수 없습니다: %s", schedule.ID)
+	}
+	previous := s.schedules[i]
+	schedule.LastRun = previous.LastRun
+	schedule.LastResult = previous.LastResult
+	schedule.LastError = previous.LastError
+	schedule.NextRun = schedule.next(s.Clock.Now())
+	s.schedules[i] = schedule
+	s.mutex.Unlock()
+
+	s.changed()
+	return schedule, nil
+}
+
+// Remove는 일정을 삭제합니다
+func (s *Scheduler) Remove(id string) error {
+	s.mutex.Lock()
+	i := s.indexOf(id)
+	if i < 0 {
+		s.mutex.Unlock()
+		return fmt.Errorf("예약을 찾을 수 없습니다: %s", id)
+	}
+	s.schedules = append(s.schedules[:i], s.schedules[i+1:]...)
+	s.mutex.Unlock()
+
+	s.changed()
+	return nil
+}
+
+// indexOf는 ID에 해당하는 일정의 위치를 반환합니다 (호출 전 잠금 필요)
+func (s *Scheduler) indexOf(id string) int {
+	for i, schedule := range s.schedules {
+		if schedule.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// changed는 루프를 깨워 대기 시간을 다시 계산하고 변경을 알립니다
+func (s *Scheduler) changed() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	if s.OnChange != nil {
+		s.OnChange(s.List())
+	}
+}
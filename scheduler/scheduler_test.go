@@ -0,0 +1,219 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/m/utils"
+)
+
+// fakeClock은 테스트에서 직접 시각을 정하는 Clock입니다 (루프 없이 Tick을 호출해 사용)
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time { return make(chan time.Time) }
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = t
+}
+
+var testStart = time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+
+func TestSchedulerNextRun(t *testing.T) {
+	clock := &fakeClock{now: testStart}
+	s := New(clock, []Schedule{
+		{ID: "once", Sequence: "a", At: testStart.Add(90 * time.Minute), Enabled: true},
+		{ID: "daily", Sequence: "b", Cron: "0 4 * * *", Enabled: true},
+		{ID: "off", Sequence: "c", Cron: "0 4 * * *"},
+	})
+
+	want := map[string]time.Time{
+		"once":  testStart.Add(90 * time.Minute),
+		"daily": time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+		"off":   {},
+	}
+	for _, schedule := range s.List() {
+		if !schedule.NextRun.Equal(want[schedule.ID]) {
+			t.Errorf("%s: 다음 실행 %v, 기대 %v", schedule.ID, schedule.NextRun, want[schedule.ID])
+		}
+	}
+	if next := s.nextRun(); !next.Equal(want["daily"]) {
+		t.Errorf("가장 빠른 실행 %v, 기대 %v", next, want["daily"])
+	}
+
+	// 실행 후 cron 일정은 다음 날로, 일회성 일정은 비활성화
+	var launched []string
+	s.Launch = func(schedule Schedule) error {
+		launched = append(launched, schedule.ID)
+		return nil
+	}
+	clock.Set(testStart.Add(2 * time.Hour))
+	s.Tick()
+	if !reflect.DeepEqual(launched, []string{"daily", "once"}) {
+		t.Fatalf("실행 순서 %v", launched)
+	}
+	daily, _ := s.Get("daily")
+	if !daily.NextRun.Equal(want["daily"].AddDate(0, 0, 1)) || daily.LastResult != ResultStarted {
+		t.Errorf("cron 일정: 다음 실행 %v, 결과 %q", daily.NextRun, daily.LastResult)
+	}
+	once, _ := s.Get("once")
+	if once.Enabled || !once.NextRun.IsZero() || once.LastResult != ResultStarted {
+		t.Errorf("일회성 일정: 활성 %v, 다음 실행 %v, 결과 %q", once.Enabled, once.NextRun, once.LastResult)
+	}
+}
+
+func TestSchedulerSkipsWhenBusy(t *testing.T) {
+	clock := &fakeClock{now: testStart}
+	s := New(clock, []Schedule{{ID: "daily", Sequence: "a", Cron: "0 4 * * *", Enabled: true}})
+	s.Busy = func() bool { return true }
+	s.Launch = func(schedule Schedule) error {
+		t.Fatalf("다른 작업이 실행 중인데 %s을(를) 시작했습니다", schedule.ID)
+		return nil
+	}
+
+	clock.Set(time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC))
+	s.Tick()
+
+	schedule, _ := s.Get("daily")
+	if schedule.LastResult != ResultSkipped || !schedule.LastRun.Equal(clock.Now()) {
+		t.Errorf("결과 %q, 마지막 실행 %v", schedule.LastResult, schedule.LastRun)
+	}
+	if !schedule.NextRun.Equal(time.Date(2026, 10, 18, 4, 0, 0, 0, time.UTC)) {
+		t.Errorf("건너뛴 뒤 다음 실행 %v", schedule.NextRun)
+	}
+}
+
+func TestSchedulerIgnoresPastOneShot(t *testing.T) {
+	clock := &fakeClock{now: testStart}
+	s := New(clock, []Schedule{{ID: "missed", Sequence: "a", At: testStart.Add(-time.Minute), Enabled: true}})
+	s.Launch = func(schedule Schedule) error {
+		t.Fatalf("지나간 일정 %s을(를) 시작했습니다", schedule.ID)
+		return nil
+	}
+
+	schedule, _ := s.Get("missed")
+	if !schedule.NextRun.IsZero() {
+		t.Fatalf("지나간 일정의 다음 실행 %v", schedule.NextRun)
+	}
+	s.Tick()
+	if schedule, _ := s.Get("missed"); schedule.LastResult != "" {
+		t.Errorf("지나간 일정이 처리되었습니다: %q", schedule.LastResult)
+	}
+
+	if _, err := s.Add(Schedule{ID: "late", Sequence: "a", At: testStart.Add(-time.Hour), Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if schedule, _ := s.Get("late"); !schedule.NextRun.IsZero() {
+		t.Errorf("지나간 시각으로 추가한 일정의 다음 실행 %v", schedule.NextRun)
+	}
+}
+
+func TestSchedulerSaveLoad(t *testing.T) {
+	clock := &fakeClock{now: testStart}
+	s := New(clock, nil)
+
+	// OnChange로 받은 목록을 설정 파일처럼 JSON으로 저장
+	var saved []byte
+	s.OnChange = func(schedules []Schedule) {
+		data, err := json.Marshal(schedules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		saved = data
+	}
+
+	if _, err := s.Add(Schedule{ID: "daily", Name: "매일", Sequence: "a", Cron: "0 4 * * *", Duration: "2h10m", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Add(Schedule{ID: "once", Sequence: "b", At: testStart.Add(time.Hour), MaxIterations: 5, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	s.Launch = func(Schedule) error { return nil }
+	clock.Set(time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC))
+	s.Tick()
+
+	var loaded []Schedule
+	if err := json.Unmarshal(saved, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	restored := New(clock, loaded)
+	got, want := restored.List(), s.List()
+	if len(got) != len(want) {
+		t.Fatalf("일정 %d개, 기대 %d개", len(got), len(want))
+	}
+	for i := range want {
+		if !schedulesEqual(got[i], want[i]) {
+			t.Errorf("다시 불러온 일정이 다릅니다:\n%+v\n%+v", got[i], want[i])
+		}
+	}
+
+	if err := s.Remove("once"); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(saved, &loaded); err != nil || len(loaded) != 1 || loaded[0].ID != "daily" {
+		t.Errorf("삭제 후 저장된 일정: %+v (%v)", loaded, err)
+	}
+}
+
+// schedulesEqual은 시각을 Equal로 비교합니다 (JSON을 거치면 위치 정보가 달라질 수 있음)
+func schedulesEqual(a, b Schedule) bool {
+	if !a.At.Equal(b.At) || !a.LastRun.Equal(b.LastRun) || !a.NextRun.Equal(b.NextRun) {
+		return false
+	}
+	a.At, a.LastRun, a.NextRun = time.Time{}, time.Time{}, time.Time{}
+	b.At, b.LastRun, b.NextRun = time.Time{}, time.Time{}, time.Time{}
+	return a == b
+}
+
+func TestScheduleRunTime(t *testing.T) {
+	cases := []struct {
+		duration string
+		want     utils.RunTime
+		ok       bool
+	}{
+		{"", utils.RunTime{}, true},
+		{"2h10m", utils.RunTime{Duration: 2*time.Hour + 10*time.Minute}, true},
+		{"1.5", utils.RunTime{Duration: 90 * time.Minute}, true},
+		{"06:00", utils.RunTime{EndAt: "06:00"}, true},
+		{"1s", utils.RunTime{}, false},
+		{"59s", utils.RunTime{}, false},
+		{"999h", utils.RunTime{}, false},
+		{"25", utils.RunTime{}, false},
+		{"-1h", utils.RunTime{}, false},
+		{"25:00", utils.RunTime{}, false},
+		{"soon", utils.RunTime{}, false},
+	}
+	for _, c := range cases {
+		schedule := Schedule{Sequence: "a", Cron: "0 4 * * *", Duration: c.duration}
+		err := schedule.Validate()
+		if (err == nil) != c.ok {
+			t.Errorf("%q: 오류 %v", c.duration, err)
+			continue
+		}
+		if c.ok && schedule.RunTime() != c.want {
+			t.Errorf("%q: 실행 시간 %+v, 기대 %+v", c.duration, schedule.RunTime(), c.want)
+		}
+	}
+
+	// 범위를 벗어난 실행 시간으로 저장된 일정은 비활성화
+	s := New(&fakeClock{now: testStart}, []Schedule{{ID: "long", Sequence: "a", Cron: "0 4 * * *", Duration: "999h", Enabled: true}})
+	if schedule, _ := s.Get("long"); schedule.Enabled || !schedule.NextRun.IsZero() {
+		t.Errorf("잘못된 일정이 활성화되어 있습니다: %+v", schedule)
+	}
+	if _, err := s.Add(Schedule{Sequence: "a", Cron: "0 4 * * *", Duration: "1s", Enabled: true}); err == nil {
+		t.Error("1초 실행 시간이 허용되었습니다")
+	}
+}
@@ -0,0 +1,114 @@
+package session
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"example.com/m/automation"
+	"example.com/m/telegram"
+)
+
+// 텔레그램 상태 카드 기본 갱신 주기 (메시지 수정 횟수 제한을 넘지 않도록 여유 있게)
+const defaultCardInterval = 30 * time.Second
+
+// startStatusCard는 텔레그램에 실시간 상태 카드를 보내고 작업이 끝날 때까지 갱신합니다
+// CardInterval마다, 그리고 작업 상태가 바뀔 때마다 카드를 고치며 끝나면 버튼을 없앤 최종 상태로 바꿉니다
+func (c *Controller) startStatusCard(bot *telegram.TelegramBot, run *automation.Run, planned time.Duration, done chan struct{}) {
+	refresh := make(chan struct{}, 1)
+	c.mutex.Lock()
+	c.cardRefresh = refresh
+	interval := c.CardInterval
+	c.mutex.Unlock()
+	if interval <= 0 {
+		interval = defaultCardInterval
+	}
+
+	go func() {
+		messageID, err := bot.SendStatusCard(c.liveStatus(run, planned))
+		if err != nil {
+			log.Printf("텔레그램 상태 카드 전송 실패: %v", err)
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				c.mutex.Lock()
+				if c.cardRefresh == refresh {
+					c.cardRefresh = nil
+				}
+				c.mutex.Unlock()
+				if err := bot.UpdateStatusCard(messageID, c.liveStatus(run, planned)); err != nil {
+					log.Printf("텔레그램 상태 카드 갱신 실패: %v", err)
+				}
+				return
+			case <-ticker.C:
+			case <-refresh:
+			}
+			if err := bot.UpdateStatusCard(messageID, c.liveStatus(run, planned)); err != nil {
+				log.Printf("텔레그램 상태 카드 갱신 실패: %v", err)
+			}
+		}
+	}()
+}
+
+// refreshStatusCard는 상태 카드를 곧바로 갱신하도록 요청합니다 (카드가 없거나 이미 요청했으면 무시)
+func (c *Controller) refreshStatusCard() {
+	c.mutex.Lock()
+	refresh := c.cardRefresh
+	c.mutex.Unlock()
+	if refresh == nil {
+		return
+	}
+	select {
+	case refresh <- struct{}{}:
+	default:
+	}
+}
+
+// liveStatus는 상태 카드에 표시할 현재 실행 상태를 만듭니다
+func (c *Controller) liveStatus(run *automation.Run, planned time.Duration) telegram.LiveStatus {
+	snapshot := c.state.snapshot()
+	status := telegram.LiveStatus{
+		ModeName:      run.Sequence.Name,
+		State:         snapshot.State.Name(),
+		StateCode:     string(snapshot.State),
+		Started:       snapshot.Started,
+		Elapsed:       run.Active(),
+		Limit:         planned,
+		Iterations:    run.Iterations(),
+		MaxIterations: run.MaxIterations(),
+		Paused:        snapshot.State == StatePaused,
+		Finished:      !snapshot.State.Active(),
+	}
+	if status.Finished {
+		status.Reason = snapshot.Reason
+	}
+	return status
+}
+
+// HandleTelegramCallback은 상태 카드의 버튼 입력을 처리하고 버튼을 누른 사람에게 보여 줄 알림을 반환합니다
+func (c *Controller) HandleTelegramCallback(data string) (string, error) {
+	switch data {
+	case telegram.CallbackStop:
+		if !c.Stop(TriggerTelegram, "텔레그램 버튼으로 작업을 중지했습니다") {
+			return "", fmt.Errorf("실행 중인 작업이 없습니다")
+		}
+		return "⏹️ 작업을 중지했습니다", nil
+	case telegram.CallbackPause:
+		if !c.Pause(TriggerTelegram, "텔레그램 버튼으로 작업을 일시정지했습니다") {
+			return "", fmt.Errorf("실행 중인 작업이 없습니다")
+		}
+		return "⏸️ 작업을 일시정지했습니다", nil
+	case telegram.CallbackResume:
+		if !c.Resume(TriggerTelegram, "텔레그램 버튼으로 작업을 재개했습니다") {
+			return "", fmt.Errorf("일시정지된 작업이 없습니다")
+		}
+		return "▶️ 작업을 재개했습니다", nil
+	default:
+		return "", fmt.Errorf("알 수 없는 버튼입니다: %s", data)
+	}
+}
@@ -0,0 +1,192 @@
+package session
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/m/telegram"
+	"example.com/m/utils"
+)
+
+// 로그 명령으로 보낼 줄 수
+const (
+	defaultLogLines = 20
+	maxLogLines     = 100
+	maxMessageRunes = 3500 // 텔레그램 메시지 길이 제한(4096자)보다 여유 있게
+)
+
+// TelegramCommands는 텔레그램으로 받을 원격 제어 명령을 반환합니다
+// 화면의 버튼과 같은 컨트롤러 메서드를 사용합니다
+func (c *Controller) TelegramCommands() map[string]telegram.Command {
+	return map[string]telegram.Command{
+		"status":     {Description: "현재 작업 상태", Handle: c.commandStatus},
+		"start":      {Description: "작업 시작 (/start [모드] [실행 시간], 예: /start 2 1h30m)", Handle: c.commandStart},
+		"stop":       {Description: "작업 중지", Handle: c.commandStop},
+		"pause":      {Description: "작업 일시정지", Handle: c.commandPause},
+		"resume":     {Description: "작업 재개", Handle: c.commandResume},
+		"modes":      {Description: "실행할 수 있는 모드(시퀀스) 목록", Handle: c.commandModes},
+		"logs":       {Description: "최근 로그 (/logs 20)", Handle: c.commandLogs},
+		"screenshot": {Description: "현재 화면 캡처", Handle: c.commandScreenshot},
+	}
+}
+
+// commandStatus는 현재 상태를 보여 줍니다
+func (c *Controller) commandStatus(args []string) (string, error) {
+	status := c.Status()
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "📊 <b>상태:</b> %s\n", status.State.Name())
+	fmt.Fprintf(&text, "🎮 <b>모드:</b> %s\n", html.EscapeString(c.SequenceName()))
+	if status.Running {
+		fmt.Fprintf(&text, "⏱️ <b>경과 시간:</b> %s\n", formatElapsed(c.Timer.GetElapsedTime()))
+	}
+	if status.Iteration != nil {
+		fmt.Fprintf(&text, "🔁 <b>반복:</b> %d회", status.Iteration.Count)
+		if status.Iteration.MaxIterations > 0 {
+			fmt.Fprintf(&text, " / %d회", status.Iteration.MaxIterations)
+		}
+		text.WriteString("\n")
+	}
+	if status.Playlist != nil {
+		fmt.Fprintf(&text, "📋 <b>재생 목록:</b> %d/%d단계 (%s)\n", status.Playlist.Step, status.Playlist.Total, PlaylistStateName(status.Playlist.State))
+	}
+	if status.Reason != "" && !status.Running {
+		fmt.Fprintf(&text, "💬 <b>마지막 종료 사유:</b> %s\n", html.EscapeString(status.Reason))
+	}
+	fmt.Fprintf(&text, "⏰ <b>기본 실행 시간:</b> %s", c.Config.RunTime.Label())
+	return text.String(), nil
+}
+
+// commandStart는 작업을 시작합니다
+// 모드는 모드 번호(1-4)나 시퀀스 ID이며, 실행 시간을 생략하면 설정의 실행 시간을 사용합니다
+func (c *Controller) commandStart(args []string) (string, error) {
+	var options StartOptions
+	if len(args) > 0 {
+		options.Sequence = args[0]
+		if mode, err := strconv.Atoi(args[0]); err == nil {
+			if options.Sequence = ModeSequenceID(mode); options.Sequence == "" {
+				return "", fmt.Errorf("알 수 없는 모드 번호입니다: %d (/modes로 확인)", mode)
+			}
+		}
+		if _, ok := c.Sequences.Get(options.Sequence); !ok {
+			return "", fmt.Errorf("알 수 없는 모드입니다: %s (/modes로 확인)", args[0])
+		}
+	}
+	if len(args) > 1 {
+		runTime, err := utils.ParseRunTime(args[1])
+		if err != nil {
+			return "", err
+		}
+		options.RunTime = &runTime
+	}
+
+	if err := c.Start(TriggerTelegram, options); err != nil {
+		return "", err
+	}
+
+	runTime := c.Config.RunTime
+	if options.RunTime != nil {
+		runTime = *options.RunTime
+	}
+	return fmt.Sprintf("▶️ <b>%s</b> 작업을 시작했습니다 (%s)", html.EscapeString(c.SequenceName()), runTime.Label()), nil
+}
+
+// commandStop은 작업을 중지합니다
+func (c *Controller) commandStop(args []string) (string, error) {
+	if !c.Stop(TriggerTelegram, "텔레그램 명령으로 작업을 중지했습니다") {
+		return "", fmt.Errorf("실행 중인 작업이 없습니다")
+	}
+	return "⏹️ 작업을 중지했습니다", nil
+}
+
+// commandPause는 작업을 일시정지합니다
+func (c *Controller) commandPause(args []string) (string, error) {
+	if !c.Pause(TriggerTelegram, "텔레그램 명령으로 작업을 일시정지했습니다") {
+		return "", fmt.Errorf("실행 중인 작업이 없습니다")
+	}
+	return "⏸️ 작업을 일시정지했습니다", nil
+}
+
+// commandResume은 일시정지된 작업을 재개합니다
+func (c *Controller) commandResume(args []string) (string, error) {
+	if !c.Resume(TriggerTelegram, "텔레그램 명령으로 작업을 재개했습니다") {
+		return "", fmt.Errorf("일시정지된 작업이 없습니다")
+	}
+	return "▶️ 작업을 재개했습니다", nil
+}
+
+// commandModes는 실행할 수 있는 시퀀스 목록을 보여 줍니다
+func (c *Controller) commandModes(args []string) (string, error) {
+	selected := c.SequenceID()
+
+	var text strings.Builder
+	text.WriteString("🎮 <b>모드 목록</b>\n\n")
+	for _, seq := range c.Sequences.List() {
+		label := ""
+		for _, mode := range []int{ModeDaeyaEnter, ModeDaeyaParty, ModeKanchenEnter, ModeKanchenParty} {
+			if ModeSequenceID(mode) == seq.ID {
+				label = fmt.Sprintf("%d. ", mode)
+			}
+		}
+		marker := ""
+		if seq.ID == selected {
+			marker = " ✅"
+		}
+		fmt.Fprintf(&text, "%s<b>%s</b> - <code>%s</code>%s\n", label, html.EscapeString(seq.Name), html.EscapeString(seq.ID), marker)
+	}
+	text.WriteString("\n예: /start 1 2h10m")
+	return text.String(), nil
+}
+
+// commandLogs는 로그 파일의 마지막 몇 줄을 보여 줍니다
+func (c *Controller) commandLogs(args []string) (string, error) {
+	count := defaultLogLines
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("줄 수는 1 이상의 숫자여야 합니다: %s", args[0])
+		}
+		count = min(n, maxLogLines)
+	}
+
+	content, err := os.ReadFile(c.Config.GetLogFilePath())
+	if err != nil {
+		return "", fmt.Errorf("로그 파일을 읽을 수 없습니다: %v", err)
+	}
+	lines := tailLines(string(content), count)
+	if len(lines) == 0 {
+		return "📄 로그가 비어 있습니다", nil
+	}
+
+	// 메시지 길이 제한을 넘지 않도록 오래된 줄부터 생략
+	body := html.EscapeString(strings.Join(lines, "\n"))
+	for len([]rune(body)) > maxMessageRunes && len(lines) > 1 {
+		lines = lines[1:]
+		body = html.EscapeString(strings.Join(lines, "\n"))
+	}
+	return fmt.Sprintf("📄 <b>최근 로그 %d줄</b>\n<pre>%s</pre>", len(lines), body), nil
+}
+
+// tailLines는 빈 줄을 제외한 마지막 n줄을 반환합니다
+func tailLines(content string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// formatElapsed는 경과 시간을 "01:02:03" 형식으로 반환합니다
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
@@ -0,0 +1,660 @@
+package session
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"sync"
+	"time"
+
+	"example.com/m/automation"
+	"example.com/m/config"
+	"example.com/m/history"
+	"example.com/m/notify"
+	"example.com/m/telegram"
+	"example.com/m/utils"
+)
+
+// 모드 타입 상수 (화면의 모드 버튼 번호)
+const (
+	ModeNone = iota
+	ModeDaeyaEnter
+	ModeDaeyaParty
+	ModeKanchenEnter
+	ModeKanchenParty
+)
+
+// 기본 모드 - 대야 (입장)
+const DefaultMode = ModeDaeyaEnter
+
+// 작업 상태 변경 주체
+const (
+	TriggerUI       = "ui"
+	TriggerAPI      = "api"
+	TriggerHotkey   = "hotkey"
+	TriggerAutoStop = "auto_stop"
+	TriggerSchedule = "schedule"
+	TriggerPlaylist = "playlist"
+	TriggerTelegram = "telegram"
+)
+
+// 작업 상태 이벤트 페이로드
+type OperationStatusPayload struct {
+	Running bool   `json:"running"`
+	Paused  bool   `json:"paused,omitempty"`
+	Trigger string `json:"trigger,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Result  string `json:"result,omitempty"` // 작업이 끝난 경우의 결과 (automation.RunStatus)
+}
+
+// 반복 이벤트 페이로드 - 가장 바깥쪽 반복 한 바퀴가 끝날 때마다 전송
+type IterationPayload struct {
+	Count         int   `json:"count"`
+	MaxIterations int   `json:"maxIterations,omitempty"`
+	LastMs        int64 `json:"lastMs"`
+	AverageMs     int64 `json:"averageMs"`
+	MinMs         int64 `json:"minMs"`
+	MaxMs         int64 `json:"maxMs"`
+}
+
+// newIterationPayload는 반복 통계를 이벤트 페이로드로 변환합니다
+func newIterationPayload(stats automation.IterationStats, maxIterations int) IterationPayload {
+	return IterationPayload{
+		Count:         stats.Count,
+		MaxIterations: maxIterations,
+		LastMs:        stats.Last.Milliseconds(),
+		AverageMs:     stats.Average().Milliseconds(),
+		MinMs:         stats.Min.Milliseconds(),
+		MaxMs:         stats.Max.Milliseconds(),
+	}
+}
+
+// 작업 오류 이벤트 페이로드 - 키 입력 실패 등으로 작업이 중지되면 전송
+type ErrorPayload struct {
+	Sequence string `json:"sequence"`
+	Message  string `json:"message"`
+}
+
+// 모드 변경 이벤트 페이로드
+type ModePayload struct {
+	Mode int `json:"mode"`
+}
+
+// 로그 이벤트 페이로드
+type LogPayload struct {
+	Message string `json:"message"`
+}
+
+// Status는 /api/status로 보여 주는 현재 상태입니다
+type Status struct {
+	StateSnapshot
+	Running   bool                       `json:"running"`
+	Paused    bool                       `json:"paused"`
+	Mode      int                        `json:"mode"`
+	Sequence  string                     `json:"sequence"`
+	Iteration *IterationPayload          `json:"iteration,omitempty"`
+	Playlist  *automation.PlaylistStatus `json:"playlist,omitempty"`
+}
+
+// StartOptions는 작업 시작 옵션입니다 (비어 있는 항목은 현재 설정 사용)
+type StartOptions struct {
+	Sequence      string         // 실행할 시퀀스 ID (비어 있으면 선택된 시퀀스)
+	RunTime       *utils.RunTime // 실행 시간 (nil이면 설정의 실행 시간, 0 값이면 제한 없음)
+	MaxIterations *int           // 반복 횟수 제한 (nil이면 설정의 반복 횟수)
+}
+
+// Controller는 작업 실행을 관리합니다
+// 모드 선택, 타이머, 키보드 실행, 실행 기록과 알림을 한 곳에서 처리하며
+// 웹뷰 바인딩, HTTP API, 단축키, 예약 작업은 모두 이 타입을 통해 작업을 시작하고 중지합니다
+type Controller struct {
+	Config    *config.AppConfig
+	Sequences *automation.SequenceLibrary
+	Keyboard  *automation.KeyboardManager
+	Timer     *utils.TimerManager
+	History   *history.Store // nil이면 실행 기록을 저장하지 않음
+
+	// Emit은 화면에 이벤트를 보냅니다 (nil이면 보내지 않음)
+	Emit func(eventType string, payload interface{})
+	// CardInterval은 텔레그램 상태 카드 갱신 주기입니다
+	CardInterval time.Duration
+
+	state         *stateMachine
+	mode          int
+	sequenceID    string
+	maxIterations int           // 반복 횟수 제한 (0이면 시간으로만 종료)
+	stopTrigger   string        // 사용자 중지를 요청한 주체
+	done          chan struct{} // 실행 중인 작업의 정리가 끝나면 닫힘
+	playlist      *automation.PlaylistRun
+	cardRefresh   chan struct{} // 텔레그램 상태 카드 즉시 갱신 요청 (카드가 없으면 nil)
+	mutex         sync.Mutex
+}
+
+// NewController는 기본 모드를 선택한 컨트롤러를 생성합니다
+func NewController(cfg *config.AppConfig, sequences *automation.SequenceLibrary, km *automation.KeyboardManager, tm *utils.TimerManager) *Controller {
+	c := &Controller{
+		Config:       cfg,
+		Sequences:    sequences,
+		Keyboard:     km,
+		Timer:        tm,
+		CardInterval: defaultCardInterval,
+		state:        newStateMachine(km.Clock.Now),
+		mode:         DefaultMode,
+		sequenceID:   ModeSequenceID(DefaultMode),
+	}
+	// 상태가 바뀔 때마다 화면과 텔레그램 상태 카드에 알림
+	c.state.onChange = func(transition Transition) {
+		c.emit("operationState", transition)
+		c.refreshStatusCard()
+	}
+	km.OnError = c.runFailed
+	return c
+}
+
+// Start는 작업을 시작합니다
+// 다른 작업이나 재생 목록이 실행 중이면 automation.ErrAlreadyRunning을 반환하고,
+// 그 밖의 이유로 시작하지 못하면 화면에도 실패 이유를 알립니다
+func (c *Controller) Start(trigger string, options StartOptions) error {
+	if c.Busy() {
+		return automation.ErrAlreadyRunning
+	}
+
+	err := c.start(trigger, options)
+	if err != nil && err != automation.ErrAlreadyRunning {
+		c.emit("operationStatus", OperationStatusPayload{Running: false, Trigger: trigger, Reason: err.Error()})
+	}
+	return err
+}
+
+// start는 옵션을 확인하고 작업을 시작합니다
+func (c *Controller) start(trigger string, options StartOptions) error {
+	sequenceID := options.Sequence
+	if sequenceID == "" {
+		sequenceID = c.SequenceID()
+	}
+	sequence, ok := c.Sequences.Get(sequenceID)
+	if !ok {
+		return fmt.Errorf("시퀀스를 찾을 수 없습니다: %s", sequenceID)
+	}
+
+	runTime := c.Config.RunTime
+	if options.RunTime != nil {
+		runTime = *options.RunTime
+	}
+	// 실행 시간 계산 (종료 시각이면 지금부터 그 시각까지)
+	duration, err := runTime.Resolve(c.Keyboard.Clock.Now())
+	if err != nil {
+		return err
+	}
+
+	maxIterations := c.MaxIterations()
+	if options.MaxIterations != nil {
+		maxIterations = *options.MaxIterations
+	}
+	if maxIterations < 0 {
+		return fmt.Errorf("반복 횟수는 0 이상이어야 합니다")
+	}
+
+	c.SelectSequence(sequence.ID)
+	_, _, err = c.begin(sequence, duration, maxIterations, trigger)
+	return err
+}
+
+// begin은 시퀀스 실행을 시작하고 끝날 때까지 감시합니다
+// duration이 0보다 크면 그 시간(일시정지 제외)이 지났을 때, maxIterations가 0보다 크면 그만큼 반복했을 때 자동으로 종료
+// 작업 정리가 끝나면 닫히는 채널을 함께 반환합니다
+func (c *Controller) begin(sequence automation.KeySequence, duration time.Duration, maxIterations int, trigger string) (*automation.Run, chan struct{}, error) {
+	if err := c.state.to(StateStarting, trigger, ""); err != nil {
+		return nil, nil, automation.ErrAlreadyRunning
+	}
+
+	run, err := c.Keyboard.Start(sequence, automation.RunOptions{
+		Timeout:       duration,
+		MaxIterations: maxIterations,
+		OnIteration: func(stats automation.IterationStats) {
+			c.emit("iteration", newIterationPayload(stats, maxIterations))
+		},
+	})
+	if err != nil {
+		c.state.to(StateFailed, trigger, err.Error())
+		return nil, nil, err
+	}
+
+	// 타이머 시작
+	c.Timer.Start()
+
+	done := make(chan struct{})
+	c.mutex.Lock()
+	c.stopTrigger = ""
+	c.done = done
+	c.mutex.Unlock()
+
+	// 감시를 시작하기 전에 바꿔야 짧은 실행이 끝나도 starting에서 바로 completed로 가지 않음
+	c.state.to(StateRunning, trigger, "")
+	c.emit("operationStatus", OperationStatusPayload{Running: true, Trigger: trigger})
+
+	// 실행이 끝나면 결과에 따라 정리
+	go c.watch(run, duration, trigger, done)
+
+	// 진행 상황 알림과 생존 신호
+	go c.startProgress(run, duration, done)
+
+	// 시작 알림 전송 (재생 목록의 단계는 끝날 때 한 번에 요약)
+	if c.ActivePlaylist() == nil {
+		details := notify.Details{Mode: sequence.Name, Duration: duration, Start: c.Keyboard.Clock.Now()}
+		if duration > 0 {
+			details.End = details.Start.Add(duration)
+		}
+		notify.Send(c.notifyTargets(), notify.EventStart, func(notifier notify.Notifier) error {
+			// 텔레그램은 시작 알림 대신 실시간 상태 카드를 보냄
+			if bot, ok := notifier.(*telegram.TelegramBot); ok {
+				c.startStatusCard(bot, run, duration, done)
+				return nil
+			}
+			return notifier.SendStartNotification(details)
+		})
+	}
+
+	return run, done, nil
+}
+
+// watch는 실행 결과에 따라 타이머, 화면, 알림, 실행 기록을 정리합니다
+// 실행 기록을 저장한 뒤에 상태를 바꾸므로, 끝난 상태가 되면 기록도 조회할 수 있습니다
+func (c *Controller) watch(run *automation.Run, planned time.Duration, trigger string, done chan struct{}) {
+	defer close(done)
+
+	result := run.Result()
+	modeName := run.Sequence.Name
+	stopTrigger := c.lastStopTrigger()
+
+	// 타이머 중지
+	c.Timer.Stop()
+
+	status := OperationStatusPayload{Running: false, Result: string(result.Status)}
+	next := StateCompleted
+
+	switch result.Status {
+	case automation.RunTimedOut, automation.RunCompleted:
+		if result.Status == automation.RunTimedOut {
+			status.Trigger = TriggerAutoStop
+		}
+		status.Reason = result.Reason
+		log.Printf("작업 완료: %s 모드, %v 실행", modeName, result.Active.Round(time.Second))
+
+		// 완료 알림 전송 (화면 캡처 첨부)
+		if c.ActivePlaylist() == nil {
+			targets := c.notifyTargets()
+			screenshot := c.captureForNotification(targets, notify.EventCompletion)
+			notify.Send(targets, notify.EventCompletion, func(notifier notify.Notifier) error {
+				if err := notifier.SendCompletionNotification(runDetails(run, result)); err != nil {
+					return err
+				}
+				return sendScreenshot(notifier, screenshot, fmt.Sprintf("📸 %s 완료 화면", html.EscapeString(modeName)))
+			})
+		}
+	case automation.RunStoppedByError:
+		next = StateFailed
+		status.Reason = fmt.Sprintf("오류로 작업이 중지되었습니다: %s", result.Reason)
+		log.Printf("작업 오류: %s 모드, %s", modeName, result.Reason)
+	case automation.RunStoppedByUser:
+		status.Trigger = stopTrigger
+		status.Reason = result.Reason
+		log.Printf("작업 중지: %s 모드, %v 실행", modeName, result.Active.Round(time.Second))
+	}
+
+	c.record(run, result, planned, trigger, stopTrigger)
+	if err := c.state.to(next, status.Trigger, status.Reason); err != nil {
+		log.Printf("경고: %v", err)
+	}
+	c.emit("operationStatus", status)
+}
+
+// runFailed는 실행이 오류로 끝났을 때 키보드 관리자가 호출합니다
+// 타이머, 작업 상태, 실행 기록은 watch가 정리하고, 여기서는 오류를 알립니다 (재생 목록은 남은 단계도 취소됨)
+func (c *Controller) runFailed(run *automation.Run, err error) {
+	message := run.Result().Reason
+	if err != nil {
+		message = err.Error()
+	}
+	log.Printf("오류로 작업을 중지했습니다: %s: %s", run.Sequence.Name, message)
+	c.emit("operationError", ErrorPayload{Sequence: run.Sequence.Name, Message: message})
+
+	// 오류 알림 전송 (오류가 난 화면 첨부)
+	details := runDetails(run, run.Result())
+	details.Error = message
+	targets := c.notifyTargets()
+	screenshot := c.captureForNotification(targets, notify.EventError)
+	notify.Send(targets, notify.EventError, func(notifier notify.Notifier) error {
+		if err := notifier.SendErrorNotification(details); err != nil {
+			return err
+		}
+		return sendScreenshot(notifier, screenshot, fmt.Sprintf("📸 %s 오류 화면", html.EscapeString(run.Sequence.Name)))
+	})
+}
+
+// runDetails는 끝난 작업의 알림 정보를 만듭니다
+func runDetails(run *automation.Run, result automation.RunResult) notify.Details {
+	return notify.Details{
+		Mode:       run.Sequence.Name,
+		Duration:   result.Active,
+		Start:      result.Started,
+		End:        result.Finished,
+		Iterations: result.Iterations,
+	}
+}
+
+// record는 끝난 작업을 실행 기록에 저장합니다
+func (c *Controller) record(run *automation.Run, result automation.RunResult, planned time.Duration, trigger, stopTrigger string) {
+	if c.History == nil {
+		return
+	}
+
+	session := history.Session{
+		Mode:           run.Sequence.ID,
+		ModeName:       run.Sequence.Name,
+		Trigger:        trigger,
+		StartedAt:      result.Started,
+		EndedAt:        result.Finished,
+		PlannedSeconds: int64(planned / time.Second),
+		ActualSeconds:  int64(result.Active / time.Second),
+		Status:         string(result.Status),
+		Iterations:     result.Iterations,
+		KeysSent:       result.KeysSent,
+
+		PlannedIterations: run.MaxIterations(),
+	}
+	switch result.Status {
+	case automation.RunStoppedByUser:
+		session.StopTrigger = stopTrigger
+		session.StopReason = result.Reason
+	case automation.RunTimedOut:
+		session.StopTrigger = TriggerAutoStop
+	case automation.RunStoppedByError:
+		session.Errors = append(session.Errors, result.Reason)
+	}
+
+	if _, err := c.History.Add(session); err != nil {
+		log.Printf("실행 기록 저장 실패: %v", err)
+	}
+}
+
+// Stop은 실행 중인 작업(재생 목록 포함)을 중지하고 정리가 끝날 때까지 기다립니다
+// 중지할 작업이 없으면 false를 반환합니다
+func (c *Controller) Stop(trigger, reason string) bool {
+	// 재생 목록의 남은 단계 취소 (단계 사이에 대기 중이어도 중지)
+	playlist := c.ActivePlaylist()
+	if playlist != nil {
+		playlist.Stop(reason)
+	}
+
+	if err := c.state.to(StateStopping, trigger, reason); err != nil {
+		if playlist != nil {
+			<-playlist.Done()
+			return true
+		}
+		return false
+	}
+
+	c.mutex.Lock()
+	c.stopTrigger = trigger
+	done := c.done
+	c.mutex.Unlock()
+
+	c.Keyboard.StopOperation(reason)
+	if done != nil {
+		<-done
+	}
+	return true
+}
+
+// Pause는 시퀀스를 현재 단계에서 멈추고 타이머를 고정합니다 (일시정지된 시간은 자동 종료 시간에서 제외)
+func (c *Controller) Pause(trigger, reason string) bool {
+	// 이미 끝났거나 중지 중인 실행은 일시정지하지 않고, 실행을 멈춘 뒤에만 상태를 바꿈
+	if c.state.state() != StateRunning || !c.Keyboard.Pause() {
+		return false
+	}
+	if err := c.state.to(StatePaused, trigger, reason); err != nil {
+		// 그사이 중지가 요청되었으면 실행을 되돌려 중지가 진행되게 함
+		c.Keyboard.Resume()
+		return false
+	}
+	c.Timer.Pause()
+
+	c.emit("operationStatus", OperationStatusPayload{Running: true, Paused: true, Trigger: trigger, Reason: reason})
+	log.Printf("작업 일시정지: %s 모드 (%s)", c.SequenceName(), trigger)
+	return true
+}
+
+// Resume은 일시정지된 단계부터 이어서 실행합니다
+func (c *Controller) Resume(trigger, reason string) bool {
+	if c.state.state() != StatePaused || !c.Keyboard.Resume() {
+		return false
+	}
+	// 그사이 중지가 요청되었으면 중지가 끝난 상태를 정리하므로 재개를 알리지 않음
+	if err := c.state.to(StateRunning, trigger, reason); err != nil {
+		return false
+	}
+	pausedFor, _ := c.Timer.Resume()
+
+	c.emit("operationStatus", OperationStatusPayload{Running: true, Trigger: trigger, Reason: reason})
+	log.Printf("작업 재개: %s 모드 (%s, %v 일시정지)", c.SequenceName(), trigger, pausedFor.Round(time.Second))
+	return true
+}
+
+// IsPaused는 작업이 일시정지 상태인지 확인합니다
+func (c *Controller) IsPaused() bool {
+	return c.state.state() == StatePaused
+}
+
+// State는 현재 작업 상태를 반환합니다
+func (c *Controller) State() State {
+	return c.state.state()
+}
+
+// Reset은 타이머, 모드, 실행 시간을 기본값으로 되돌립니다
+// 일시정지된 작업은 중지하며, 실행 중인 작업이 있으면 automation.ErrAlreadyRunning을 반환합니다
+func (c *Controller) Reset(trigger string) error {
+	if c.IsPaused() {
+		c.Stop(trigger, "")
+	}
+	if c.Busy() {
+		return automation.ErrAlreadyRunning
+	}
+	if c.State() != StateIdle {
+		c.state.to(StateIdle, trigger, "")
+	}
+
+	// 타이머 재설정
+	c.Timer.Reset()
+
+	// 모드 초기화 - 대야 입장(기본값)으로 설정
+	c.SelectMode(DefaultMode)
+	c.emit("resetMode", ModePayload{Mode: DefaultMode})
+
+	// 시간 설정 초기화 - 3시간 10분(기본값)으로 설정
+	if err := c.Config.SetRunTime(utils.DefaultRunTime); err != nil {
+		log.Printf("설정 저장 실패: %v", err)
+	}
+	c.emit("resetRunTime", map[string]string{"value": utils.DefaultRunTime.String()})
+
+	// 타이머 값 초기화
+	c.emit("resetTimer", nil)
+	return nil
+}
+
+// Busy는 작업이나 재생 목록이 실행 중인지 확인합니다
+func (c *Controller) Busy() bool {
+	return c.State().Active() || c.ActivePlaylist() != nil
+}
+
+// Status는 현재 상태를 반환합니다
+func (c *Controller) Status() Status {
+	snapshot := c.state.snapshot()
+	c.mutex.Lock()
+	status := Status{
+		StateSnapshot: snapshot,
+		Running:       snapshot.State.Active(),
+		Paused:        snapshot.State == StatePaused,
+		Mode:          c.mode,
+		Sequence:      c.sequenceID,
+	}
+	c.mutex.Unlock()
+
+	if run := c.Keyboard.Current(); run != nil {
+		iteration := newIterationPayload(run.IterationStats(), run.MaxIterations())
+		status.Iteration = &iteration
+	}
+	if playlist := c.ActivePlaylist(); playlist != nil {
+		playlistStatus := playlist.Status()
+		status.Playlist = &playlistStatus
+	}
+	return status
+}
+
+// SelectMode는 모드 번호에 해당하는 기본 시퀀스를 선택합니다
+func (c *Controller) SelectMode(mode int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.mode = mode
+	c.sequenceID = ModeSequenceID(mode)
+}
+
+// SelectSequence는 시퀀스를 선택합니다 (기본 시퀀스면 모드 번호도 함께 갱신)
+// 알 수 없는 시퀀스면 false를 반환합니다
+func (c *Controller) SelectSequence(sequenceID string) bool {
+	if _, ok := c.Sequences.Get(sequenceID); !ok {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sequenceID = sequenceID
+	c.mode = ModeNone
+	for _, mode := range []int{ModeDaeyaEnter, ModeDaeyaParty, ModeKanchenEnter, ModeKanchenParty} {
+		if ModeSequenceID(mode) == sequenceID {
+			c.mode = mode
+		}
+	}
+	return true
+}
+
+// Mode는 선택된 모드 번호를 반환합니다 (기본 시퀀스가 아니면 ModeNone)
+func (c *Controller) Mode() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.mode
+}
+
+// SequenceID는 선택된 시퀀스 ID를 반환합니다
+func (c *Controller) SequenceID() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.sequenceID
+}
+
+// SequenceName은 선택된 시퀀스 이름을 반환합니다
+func (c *Controller) SequenceName() string {
+	if seq, ok := c.Sequences.Get(c.SequenceID()); ok {
+		return seq.Name
+	}
+	return ModeName(c.Mode())
+}
+
+// SetRunTime은 실행 시간 설정을 바꾸고 저장합니다 ("3h10m", "04:30" 등)
+func (c *Controller) SetRunTime(value string) (utils.RunTime, error) {
+	runTime, err := utils.ParseRunTime(value)
+	if err != nil {
+		return runTime, err
+	}
+	if runTime.IsZero() {
+		return runTime, fmt.Errorf("실행 시간을 입력하세요")
+	}
+	if _, err := runTime.Resolve(c.Keyboard.Clock.Now()); err != nil {
+		return runTime, err
+	}
+
+	if err := c.Config.SetRunTime(runTime); err != nil {
+		return runTime, fmt.Errorf("설정 저장 실패: %v", err)
+	}
+	log.Printf("실행 시간 설정: %s", runTime.Label())
+	return runTime, nil
+}
+
+// SetMaxIterations는 반복 횟수 제한을 바꿉니다 (0이면 시간으로만 종료)
+func (c *Controller) SetMaxIterations(count int) error {
+	if count < 0 {
+		return fmt.Errorf("반복 횟수는 0 이상이어야 합니다")
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.maxIterations = count
+	return nil
+}
+
+// MaxIterations는 반복 횟수 제한을 반환합니다
+func (c *Controller) MaxIterations() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.maxIterations
+}
+
+// lastStopTrigger는 마지막으로 중지를 요청한 주체를 반환합니다
+func (c *Controller) lastStopTrigger() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stopTrigger
+}
+
+// notifyTargets는 알림을 보낼 대상 목록을 반환합니다
+func (c *Controller) notifyTargets() []notify.Target {
+	if c.Config == nil {
+		return nil
+	}
+	return c.Config.NotifyTargets()
+}
+
+// telegram은 텔레그램 봇을 반환합니다 (꺼져 있으면 nil)
+func (c *Controller) telegram() *telegram.TelegramBot {
+	if c.Config == nil || !c.Config.TelegramEnabled {
+		return nil
+	}
+	return c.Config.TelegramBot
+}
+
+// emit은 화면에 이벤트를 보냅니다
+func (c *Controller) emit(eventType string, payload interface{}) {
+	if c.Emit != nil {
+		c.Emit(eventType, payload)
+	}
+}
+
+// ModeName은 모드 이름을 반환합니다
+func ModeName(mode int) string {
+	switch mode {
+	case ModeDaeyaEnter:
+		return "대야 (입장)"
+	case ModeDaeyaParty:
+		return "대야 (파티)"
+	case ModeKanchenEnter:
+		return "칸첸 (입장)"
+	case ModeKanchenParty:
+		return "칸첸 (파티)"
+	default:
+		return "알 수 없음"
+	}
+}
+
+// ModeSequenceID는 모드 번호에 해당하는 기본 시퀀스 ID를 반환합니다
+func ModeSequenceID(mode int) string {
+	switch mode {
+	case ModeDaeyaEnter:
+		return "daeya-entrance"
+	case ModeDaeyaParty:
+		return "daeya-party"
+	case ModeKanchenEnter:
+		return "kanchen-entrance"
+	case ModeKanchenParty:
+		return "kanchen-party"
+	default:
+		return ""
+	}
+}
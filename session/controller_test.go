@@ -0,0 +1,237 @@
+//go:build headless
+
+package session
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/m/automation"
+	"example.com/m/config"
+	"example.com/m/utils"
+)
+
+// transitionLog는 컨트롤러가 보낸 operationState 이벤트를 모읍니다
+type transitionLog struct {
+	mutex sync.Mutex
+	list  []Transition
+}
+
+func (l *transitionLog) emit(eventType string, payload interface{}) {
+	if eventType != "operationState" {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.list = append(l.list, payload.(Transition))
+}
+
+// states는 받은 전이를 "이전>다음" 형식으로 반환합니다
+func (l *transitionLog) states() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var states []string
+	for _, transition := range l.list {
+		states = append(states, string(transition.From)+">"+string(transition.State))
+	}
+	return states
+}
+
+func (l *transitionLog) last() Transition {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.list[len(l.list)-1]
+}
+
+// newStateTestController는 RecordingDriver와 ManualClock으로 키 입력 없이 실행하는 컨트롤러를 생성합니다
+func newStateTestController(t *testing.T) (*Controller, *transitionLog) {
+	t.Helper()
+	sequences := automation.NewSequenceLibrary(t.TempDir())
+	if err := sequences.InstallDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sequences.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := automation.NewManualClock(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	km := automation.NewKeyboardManagerWithDriver(automation.NewRecordingDriver(clock), clock)
+	c := NewController(&config.AppConfig{RunTime: utils.DefaultRunTime}, sequences, km, utils.NewTimerManager())
+	events := &transitionLog{}
+	c.Emit = events.emit
+	return c, events
+}
+
+// startUnlimited는 실행 시간 제한 없이 작업을 시작합니다
+func startUnlimited(t *testing.T, c *Controller) {
+	t.Helper()
+	unlimited := utils.RunTime{}
+	if err := c.Start(TriggerUI, StartOptions{RunTime: &unlimited}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertStates(t *testing.T, events *transitionLog, want ...string) {
+	t.Helper()
+	got := events.states()
+	if len(got) != len(want) {
+		t.Fatalf("상태 전이 %v, 기대 %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("상태 전이 %v, 기대 %v", got, want)
+		}
+	}
+}
+
+func TestControllerStartStop(t *testing.T) {
+	c, events := newStateTestController(t)
+	startUnlimited(t, c)
+	if c.State() != StateRunning {
+		t.Fatalf("시작 후 상태 %s", c.State())
+	}
+
+	if !c.Stop(TriggerHotkey, "사용자 중지") {
+		t.Fatal("실행 중인 작업을 중지하지 못했습니다")
+	}
+	assertStates(t, events, "idle>starting", "starting>running", "running>stopping", "stopping>completed")
+	if c.State() != StateCompleted {
+		t.Fatalf("중지 후 상태 %s", c.State())
+	}
+	if last := events.last(); last.Trigger != TriggerHotkey {
+		t.Errorf("마지막 전이 주체 %q", last.Trigger)
+	}
+	status := c.Status()
+	if status.Reason != "사용자 중지" || status.Finished.IsZero() || status.Running {
+		t.Errorf("중지 후 상태: %+v", status.StateSnapshot)
+	}
+}
+
+func TestControllerAutoStop(t *testing.T) {
+	c, events := newStateTestController(t)
+	limit := utils.RunTime{Duration: 10 * time.Minute}
+	if err := c.Start(TriggerAPI, StartOptions{RunTime: &limit}); err != nil {
+		t.Fatal(err)
+	}
+
+	c.mutex.Lock()
+	done := c.done
+	c.mutex.Unlock()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("실행 시간이 지났는데 작업이 끝나지 않았습니다")
+	}
+
+	assertStates(t, events, "idle>starting", "starting>running", "running>completed")
+	if c.State() != StateCompleted {
+		t.Fatalf("자동 종료 후 상태 %s", c.State())
+	}
+	if last := events.last(); last.Trigger != TriggerAutoStop {
+		t.Errorf("마지막 전이 주체 %q, 기대 %q", last.Trigger, TriggerAutoStop)
+	}
+}
+
+func TestControllerReset(t *testing.T) {
+	c, events := newStateTestController(t)
+	startUnlimited(t, c)
+
+	// 실행 중인 작업은 재설정할 수 없음
+	if err := c.Reset(TriggerUI); err != automation.ErrAlreadyRunning {
+		t.Fatalf("실행 중 재설정: %v", err)
+	}
+
+	// 일시정지된 작업은 중지한 뒤 재설정
+	if !c.Pause(TriggerUI, "") {
+		t.Fatal("일시정지하지 못했습니다")
+	}
+	if err := c.Reset(TriggerUI); err != nil {
+		t.Fatal(err)
+	}
+	assertStates(t, events,
+		"idle>starting", "starting>running", "running>paused",
+		"paused>stopping", "stopping>completed", "completed>idle")
+	if c.State() != StateIdle || c.Busy() {
+		t.Fatalf("재설정 후 상태 %s", c.State())
+	}
+	if status := c.Status(); status.Reason != "" {
+		t.Errorf("재설정 후 남은 이유 %q", status.Reason)
+	}
+}
+
+func TestControllerInvalidTransitions(t *testing.T) {
+	c, events := newStateTestController(t)
+
+	// 대기 중에는 일시정지, 재개, 중지할 작업이 없음
+	if c.Pause(TriggerUI, "") || c.Resume(TriggerUI, "") || c.Stop(TriggerUI, "") {
+		t.Fatal("대기 중인데 작업 상태가 바뀌었습니다")
+	}
+	assertStates(t, events)
+
+	startUnlimited(t, c)
+	// 실행 중에는 다시 시작하거나 재개할 수 없음
+	if err := c.Start(TriggerAPI, StartOptions{}); err != automation.ErrAlreadyRunning {
+		t.Fatalf("실행 중 시작: %v", err)
+	}
+	if c.Resume(TriggerUI, "") {
+		t.Fatal("일시정지하지 않은 작업을 재개했습니다")
+	}
+	if !c.Pause(TriggerUI, "") || c.Pause(TriggerUI, "") {
+		t.Fatal("일시정지는 한 번만 되어야 합니다")
+	}
+	c.Stop(TriggerUI, "")
+	assertStates(t, events, "idle>starting", "starting>running", "running>paused", "paused>stopping", "stopping>completed")
+
+	// 상태 기계가 거부하는 전이는 상태와 이벤트를 바꾸지 않음
+	invalid := []struct{ from, to State }{
+		{StateCompleted, StateRunning},
+		{StateCompleted, StatePaused},
+		{StateCompleted, StateStopping},
+		{StateIdle, StateRunning},
+		{StateIdle, StateCompleted},
+		{StateStarting, StatePaused},
+		{StateStopping, StateRunning},
+		{StateFailed, StateCompleted},
+	}
+	for _, tc := range invalid {
+		machine := newStateMachine(time.Now)
+		machine.current.State = tc.from
+		changed := false
+		machine.onChange = func(Transition) { changed = true }
+		if err := machine.to(tc.to, TriggerUI, ""); err == nil {
+			t.Errorf("%s에서 %s(으)로 바뀌었습니다", tc.from, tc.to)
+		}
+		if machine.state() != tc.from || changed {
+			t.Errorf("%s에서 %s(으)로 거부된 전이가 상태를 바꿨습니다", tc.from, tc.to)
+		}
+	}
+}
+
+func TestControllerPauseAfterRunFinished(t *testing.T) {
+	c, events := newStateTestController(t)
+	limit := utils.RunTime{Duration: time.Minute}
+	if err := c.Start(TriggerAPI, StartOptions{RunTime: &limit}); err != nil {
+		t.Fatal(err)
+	}
+	c.mutex.Lock()
+	done := c.done
+	c.mutex.Unlock()
+	<-done
+
+	if c.Pause(TriggerUI, "") || c.State() != StateCompleted {
+		t.Fatalf("끝난 작업의 일시정지 후 상태 %s", c.State())
+	}
+
+	// 실행은 끝났지만 감시 고루틴이 아직 상태를 바꾸지 않은 경우
+	c.state.mutex.Lock()
+	c.state.current.State = StateRunning
+	c.state.mutex.Unlock()
+	if c.Pause(TriggerUI, "") {
+		t.Fatal("끝난 실행을 일시정지했습니다")
+	}
+	if c.State() != StateRunning || c.Timer.IsPaused() {
+		t.Fatalf("상태 %s, 타이머 일시정지 %v", c.State(), c.Timer.IsPaused())
+	}
+	assertStates(t, events, "idle>starting", "starting>running", "running>completed")
+}
@@ -0,0 +1,163 @@
+package session
+
+import (
+	"fmt"
+	"log"
+
+	"example.com/m/automation"
+	"example.com/m/notify"
+	"example.com/m/telegram"
+)
+
+// StartPlaylist는 재생 목록을 시작합니다
+// 단계마다 작업을 시작하고 끝날 때까지 기다린 뒤 다음 단계로 진행합니다
+func (c *Controller) StartPlaylist(steps []automation.PlaylistStep) error {
+	for i, step := range steps {
+		if _, ok := c.Sequences.Get(step.Sequence); !ok {
+			return fmt.Errorf("%d번째 단계: 알 수 없는 시퀀스입니다: %s", i+1, step.Sequence)
+		}
+	}
+	if c.Busy() {
+		return automation.ErrAlreadyRunning
+	}
+
+	playlist, err := automation.NewPlaylistRun(steps, c.Keyboard.Clock)
+	if err != nil {
+		return err
+	}
+
+	// 첫 단계가 시작되기 전에 설정해야 단계별 텔레그램 알림이 생략됨
+	c.mutex.Lock()
+	c.playlist = playlist
+	c.mutex.Unlock()
+
+	playlist.Start(func(step automation.PlaylistStep) (string, automation.RunResult, error) {
+		return c.runPlaylistStep(playlist, step)
+	}, c.finishPlaylist)
+	log.Printf("재생 목록 시작: %d단계", len(steps))
+	c.emit("logMessage", LogPayload{Message: fmt.Sprintf("재생 목록을 시작합니다 (%d단계)", len(steps))})
+	return nil
+}
+
+// Playlist는 마지막으로 시작한 재생 목록을 반환합니다 (없으면 nil)
+func (c *Controller) Playlist() *automation.PlaylistRun {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.playlist
+}
+
+// ActivePlaylist는 실행 중인 재생 목록을 반환합니다 (없거나 끝났으면 nil)
+func (c *Controller) ActivePlaylist() *automation.PlaylistRun {
+	if playlist := c.Playlist(); playlist != nil && !playlist.Finished() {
+		return playlist
+	}
+	return nil
+}
+
+// runPlaylistStep은 재생 목록 단계 하나를 실행하고 작업 정리(기록 저장 등)가 끝날 때까지 기다립니다
+func (c *Controller) runPlaylistStep(playlist *automation.PlaylistRun, step automation.PlaylistStep) (string, automation.RunResult, error) {
+	sequence, ok := c.Sequences.Get(step.Sequence)
+	if !ok {
+		return step.Sequence, automation.RunResult{}, fmt.Errorf("알 수 없는 시퀀스입니다: %s", step.Sequence)
+	}
+
+	// 종료 시각이면 단계를 시작하는 시각부터 계산
+	duration, err := step.RunTime().Resolve(c.Keyboard.Clock.Now())
+	if err != nil {
+		return sequence.Name, automation.RunResult{}, err
+	}
+
+	c.SelectSequence(sequence.ID)
+	run, done, err := c.begin(sequence, duration, step.Iterations, TriggerPlaylist)
+	if err != nil {
+		return sequence.Name, automation.RunResult{}, err
+	}
+
+	// 단계를 시작하는 사이에 재생 목록이 중지된 경우
+	if playlist.Stopped() {
+		c.mutex.Lock()
+		c.stopTrigger = TriggerPlaylist
+		c.mutex.Unlock()
+		run.Stop(playlist.Status().Reason)
+	}
+
+	result := run.Result()
+	<-done
+	return sequence.Name, result, nil
+}
+
+// finishPlaylist는 재생 목록 결과를 기록하고 텔레그램 요약 알림을 보냅니다
+func (c *Controller) finishPlaylist(status automation.PlaylistStatus) {
+	message := fmt.Sprintf("재생 목록 종료: %d/%d단계 실행 (%s)", len(status.Results), status.Total, PlaylistStateName(status.State))
+	if status.Reason != "" {
+		message += " - " + status.Reason
+	}
+	log.Println(message)
+	c.emit("logMessage", LogPayload{Message: message})
+
+	reports := make([]telegram.PlaylistStepReport, 0, len(status.Results))
+	details := notify.Details{
+		Mode:     fmt.Sprintf("재생 목록 (%d/%d단계, %s)", len(status.Results), status.Total, PlaylistStateName(status.State)),
+		Duration: status.Active(),
+		Start:    status.Started,
+		End:      status.Finished,
+	}
+	for _, result := range status.Results {
+		reports = append(reports, telegram.PlaylistStepReport{
+			Name:       result.Name,
+			Result:     RunStatusName(result.Status),
+			Status:     string(result.Status),
+			Duration:   result.Active,
+			Iterations: result.Iterations,
+		})
+		details.Iterations += result.Iterations
+	}
+
+	// 완료 알림 전송 (텔레그램은 단계별 요약, 다른 대상은 재생 목록 전체를 한 작업으로 알림)
+	targets := c.notifyTargets()
+	screenshot := c.captureForNotification(targets, notify.EventCompletion)
+	notify.Send(targets, notify.EventCompletion, func(notifier notify.Notifier) error {
+		var err error
+		if bot, ok := notifier.(*telegram.TelegramBot); ok {
+			err = bot.SendPlaylistSummary(PlaylistStateName(status.State), string(status.State), reports, status.Total, status.Reason)
+		} else {
+			err = notifier.SendCompletionNotification(details)
+		}
+		if err != nil {
+			return err
+		}
+		return sendScreenshot(notifier, screenshot, "📸 재생 목록 종료 화면")
+	})
+}
+
+// PlaylistStateName은 재생 목록 상태 이름을 반환합니다
+func PlaylistStateName(state automation.PlaylistState) string {
+	switch state {
+	case automation.PlaylistCompleted:
+		return "모두 완료"
+	case automation.PlaylistStopped:
+		return "사용자 중지"
+	case automation.PlaylistFailed:
+		return "오류로 중단"
+	case automation.PlaylistWaiting:
+		return "다음 단계 대기 중"
+	default:
+		return "실행 중"
+	}
+}
+
+// RunStatusName은 실행 결과 이름을 반환합니다
+func RunStatusName(status automation.RunStatus) string {
+	switch status {
+	case automation.RunCompleted:
+		return "정상 완료"
+	case automation.RunTimedOut:
+		return "시간 종료"
+	case automation.RunStoppedByUser:
+		return "사용자 중지"
+	case automation.RunStoppedByError:
+		return "오류"
+	default:
+		return "시작 실패"
+	}
+}
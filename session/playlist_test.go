@@ -0,0 +1,170 @@
+//go:build headless
+
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/m/automation"
+	"example.com/m/history"
+	"example.com/m/notify"
+)
+
+// webhookEvents는 웹훅 알림으로 받은 본문을 모읍니다
+type webhookEvents struct {
+	mutex    sync.Mutex
+	messages []notify.Message
+	received chan struct{}
+}
+
+func newWebhookServer(t *testing.T) (*httptest.Server, *webhookEvents) {
+	t.Helper()
+	events := &webhookEvents{received: make(chan struct{}, 16)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message notify.Message
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("웹훅 본문 해석 실패: %v", err)
+		}
+		events.mutex.Lock()
+		events.messages = append(events.messages, message)
+		events.mutex.Unlock()
+		events.received <- struct{}{}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, events
+}
+
+func (e *webhookEvents) list() []notify.Message {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]notify.Message(nil), e.messages...)
+}
+
+func TestControllerPlaylist(t *testing.T) {
+	c, _ := newStateTestController(t)
+	srv, webhook := newWebhookServer(t)
+	c.Config.Notifiers = []notify.Config{{Type: notify.TypeWebhook, Enabled: true, URL: srv.URL}}
+	c.History = history.NewStore(filepath.Join(t.TempDir(), "history.jsonl"))
+
+	var logMutex sync.Mutex
+	var logs []string
+	c.Emit = func(eventType string, payload interface{}) {
+		if eventType == "logMessage" {
+			logMutex.Lock()
+			logs = append(logs, payload.(LogPayload).Message)
+			logMutex.Unlock()
+		}
+	}
+
+	steps := []automation.PlaylistStep{
+		{Sequence: "daeya-entrance", Iterations: 1, Pause: "1m"},
+		{Sequence: "daeya-party", Duration: "5m"},
+	}
+	if err := c.StartPlaylist(steps); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.StartPlaylist(steps); err != automation.ErrAlreadyRunning {
+		t.Errorf("재생 목록 실행 중 다시 시작: %v", err)
+	}
+
+	select {
+	case <-c.Playlist().Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("재생 목록이 끝나지 않았습니다")
+	}
+
+	status := c.Playlist().Status()
+	if status.State != automation.PlaylistCompleted || len(status.Results) != 2 {
+		t.Fatalf("최종 상태 %+v", status)
+	}
+	if status.Results[0].Status != automation.RunCompleted || status.Results[1].Status != automation.RunTimedOut {
+		t.Errorf("단계 결과 %+v", status.Results)
+	}
+	if c.ActivePlaylist() != nil || c.Busy() {
+		t.Error("재생 목록이 끝난 뒤에도 실행 중입니다")
+	}
+
+	logMutex.Lock()
+	summary := logs[len(logs)-1]
+	logMutex.Unlock()
+	if summary != "재생 목록 종료: 2/2단계 실행 (모두 완료)" {
+		t.Errorf("마지막 로그 %q", summary)
+	}
+
+	// 단계별 시작/완료 알림 없이 재생 목록 전체 완료 알림 한 번만 전송
+	select {
+	case <-webhook.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("완료 알림을 받지 못했습니다")
+	}
+	time.Sleep(100 * time.Millisecond)
+	messages := webhook.list()
+	if len(messages) != 1 || messages[0].Event != notify.EventCompletion {
+		t.Fatalf("웹훅 알림 %+v", messages)
+	}
+	if !strings.Contains(messages[0].Mode, "재생 목록 (2/2단계, 모두 완료)") {
+		t.Errorf("완료 알림 모드 %q", messages[0].Mode)
+	}
+
+	// 단계마다 실행 기록 저장
+	sessions := c.History.Sessions()
+	if len(sessions) != 2 || sessions[0].Mode != "daeya-entrance" || sessions[1].Mode != "daeya-party" {
+		t.Fatalf("실행 기록 %+v", sessions)
+	}
+	for _, session := range sessions {
+		if session.Trigger != TriggerPlaylist {
+			t.Errorf("%s 실행 주체 %q", session.Mode, session.Trigger)
+		}
+	}
+}
+
+func TestControllerPlaylistStop(t *testing.T) {
+	c, _ := newStateTestController(t)
+	steps := []automation.PlaylistStep{
+		{Sequence: "daeya-entrance"},
+		{Sequence: "daeya-party", Iterations: 1},
+	}
+	// Duration과 Iterations가 모두 없는 단계는 허용되지 않음
+	if err := c.StartPlaylist(steps); err == nil {
+		t.Fatal("제한 없는 단계가 허용되었습니다")
+	}
+	if err := c.StartPlaylist([]automation.PlaylistStep{{Sequence: "unknown", Iterations: 1}}); err == nil {
+		t.Fatal("알 수 없는 시퀀스가 허용되었습니다")
+	}
+
+	// 1단계가 실행되면 바로 중지 (긴 실행 시간이라 중지 전에 끝나지 않음)
+	stopped := make(chan bool, 1)
+	c.Emit = func(eventType string, payload interface{}) {
+		if transition, ok := payload.(Transition); ok && eventType == "operationState" && transition.State == StateRunning {
+			go func() { stopped <- c.Stop(TriggerUI, "사용자 중지") }()
+		}
+	}
+	steps[0].Duration = "23h"
+	if err := c.StartPlaylist(steps); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ok := <-stopped:
+		if !ok {
+			t.Fatal("재생 목록을 중지하지 못했습니다")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("재생 목록이 중지되지 않았습니다")
+	}
+	<-c.Playlist().Done()
+
+	status := c.Playlist().Status()
+	if status.State != automation.PlaylistStopped || len(status.Results) != 1 {
+		t.Fatalf("중지 뒤 상태 %+v", status)
+	}
+	if status.Results[0].Status != automation.RunStoppedByUser {
+		t.Errorf("1단계 결과 %+v", status.Results[0])
+	}
+}
@@ -0,0 +1,93 @@
+package session
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"example.com/m/automation"
+	"example.com/m/notify"
+)
+
+// startProgress는 작업이 끝날 때까지 진행 상황 알림과 생존 신호를 보냅니다
+// 실행 시간은 타이머 관리자 기준이라 일시정지한 시간은 빠지며, 일시정지 중에는 진행 알림 없이 생존 신호만 보냅니다
+// 작업이 끝나면 마지막 상태로 생존 신호를 한 번 더 보내 감시 도구가 정상 종료와 멈춤을 구분할 수 있게 합니다
+func (c *Controller) startProgress(run *automation.Run, planned time.Duration, done chan struct{}) {
+	if c.Config == nil {
+		return
+	}
+	progressInterval := c.Config.ProgressInterval
+	heartbeat := c.Config.Heartbeat
+	if progressInterval <= 0 && !heartbeat.Enabled() {
+		return
+	}
+
+	var progress, beat <-chan time.Time
+	if progressInterval > 0 {
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		progress = ticker.C
+	}
+	if heartbeat.Enabled() {
+		ticker := time.NewTicker(heartbeat.Period())
+		defer ticker.Stop()
+		beat = ticker.C
+		c.sendHeartbeat(heartbeat, run, planned, false)
+	}
+
+	for {
+		select {
+		case <-done:
+			if heartbeat.Enabled() {
+				c.sendHeartbeat(heartbeat, run, planned, true)
+			}
+			return
+		case <-progress:
+			if c.Timer.IsPaused() {
+				continue
+			}
+			details := c.progressDetails(run, planned)
+			notify.Send(c.notifyTargets(), notify.EventProgress, func(notifier notify.Notifier) error {
+				return notifier.SendProgressNotification(details)
+			})
+		case <-beat:
+			c.sendHeartbeat(heartbeat, run, planned, false)
+		}
+	}
+}
+
+// progressDetails는 실행 중인 작업의 진행 상황을 만듭니다
+func (c *Controller) progressDetails(run *automation.Run, planned time.Duration) notify.Details {
+	now := time.Now()
+	details := notify.Details{
+		Mode:       run.Sequence.Name,
+		Duration:   c.Timer.GetElapsedTime(),
+		Start:      c.state.snapshot().Started,
+		Iterations: run.Iterations(),
+	}
+	if planned > 0 {
+		details.Remaining = max(planned-details.Duration, 0)
+		details.End = now.Add(details.Remaining)
+	}
+	return details
+}
+
+// sendHeartbeat는 생존 신호를 보냅니다 (final이면 끝난 작업의 마지막 신호)
+func (c *Controller) sendHeartbeat(heartbeat notify.Heartbeat, run *automation.Run, planned time.Duration, final bool) {
+	details := c.progressDetails(run, planned)
+	beat := notify.Beat{
+		Time:       time.Now(),
+		State:      string(c.state.snapshot().State),
+		Mode:       details.Mode,
+		Elapsed:    int64(details.Duration / time.Second),
+		Remaining:  int64(details.Remaining / time.Second),
+		Iterations: details.Iterations,
+		PID:        os.Getpid(),
+	}
+	if !final {
+		beat.Next = beat.Time.Add(heartbeat.Period())
+	}
+	if err := heartbeat.Send(beat); err != nil {
+		log.Printf("경고: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"log"
+	"time"
+
+	"example.com/m/automation"
+	"example.com/m/notify"
+)
+
+// Screenshot은 설정된 영역의 화면을 캡처하고 최대 너비에 맞게 줄입니다
+func (c *Controller) Screenshot() (image.Image, error) {
+	if c.Keyboard.Screen == nil {
+		return nil, fmt.Errorf("화면을 캡처할 수 없는 환경입니다")
+	}
+
+	settings := c.Config.Screenshot
+	img, err := c.Keyboard.Screen.Capture(settings.Region())
+	if err != nil {
+		return nil, fmt.Errorf("화면 캡처 실패: %v", err)
+	}
+	return automation.ScaleToWidth(img, settings.MaxWidth), nil
+}
+
+// captureForNotification은 event 알림에 첨부할 화면을 캡처합니다
+// 꺼져 있거나, 사진을 받을 대상이 없거나, 캡처에 실패하면 nil을 반환합니다
+// 알림을 보내는 동안 화면이 바뀌지 않도록 작업이 끝난 직후에 호출합니다
+func (c *Controller) captureForNotification(targets []notify.Target, event notify.Event) image.Image {
+	if !c.Config.Screenshot.Enabled {
+		return nil
+	}
+	wanted := false
+	for _, target := range targets {
+		if _, ok := target.Notifier.(notify.PhotoSender); ok && target.Wants(event) {
+			wanted = true
+		}
+	}
+	if !wanted {
+		return nil
+	}
+
+	img, err := c.Screenshot()
+	if err != nil {
+		log.Printf("알림용 %v", err)
+		return nil
+	}
+	return img
+}
+
+// sendScreenshot은 사진을 받을 수 있는 대상에 캡처한 화면을 보냅니다 (img가 nil이면 무시)
+func sendScreenshot(notifier notify.Notifier, img image.Image, caption string) error {
+	sender, ok := notifier.(notify.PhotoSender)
+	if img == nil || !ok {
+		return nil
+	}
+	if err := sender.SendPhoto(img, caption); err != nil {
+		return fmt.Errorf("화면 캡처 전송 실패: %v", err)
+	}
+	return nil
+}
+
+// commandScreenshot은 현재 화면을 캡처해 보냅니다 (알림 설정과 관계없이 항상 전송)
+func (c *Controller) commandScreenshot(args []string) (string, error) {
+	bot := c.telegram()
+	if bot == nil {
+		return "", fmt.Errorf("텔레그램 알림이 꺼져 있습니다")
+	}
+	img, err := c.Screenshot()
+	if err != nil {
+		return "", err
+	}
+
+	caption := fmt.Sprintf("📸 <b>현재 화면</b> (%s, %s)", html.EscapeString(c.State().Name()), time.Now().Format("15:04:05"))
+	if err := bot.SendPhoto(img, caption); err != nil {
+		return "", fmt.Errorf("화면 캡처 전송 실패: %v", err)
+	}
+	return "", nil
+}
@@ -0,0 +1,157 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State는 작업의 진행 상태입니다
+type State string
+
+// 작업 상태 상수
+const (
+	StateIdle      State = "idle"      // 실행한 적 없거나 재설정됨
+	StateStarting  State = "starting"  // 시작하는 중
+	StateRunning   State = "running"   // 실행 중
+	StatePaused    State = "paused"    // 일시정지됨
+	StateStopping  State = "stopping"  // 중지를 요청하고 정리를 기다리는 중
+	StateCompleted State = "completed" // 끝남 (완료, 시간 종료, 사용자 중지)
+	StateFailed    State = "failed"    // 시작하지 못했거나 오류로 중지됨
+)
+
+// 허용되는 상태 전이
+var transitions = map[State][]State{
+	StateIdle:      {StateStarting},
+	StateStarting:  {StateRunning, StateFailed},
+	StateRunning:   {StatePaused, StateStopping, StateCompleted, StateFailed},
+	StatePaused:    {StateRunning, StateStopping, StateCompleted, StateFailed},
+	StateStopping:  {StateCompleted, StateFailed},
+	StateCompleted: {StateStarting, StateIdle},
+	StateFailed:    {StateStarting, StateIdle},
+}
+
+// Active는 작업이 실행 중인 상태(시작 중, 일시정지, 중지 중 포함)인지 확인합니다
+func (s State) Active() bool {
+	switch s {
+	case StateStarting, StateRunning, StatePaused, StateStopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// Name은 화면과 알림에 표시할 상태 이름을 반환합니다
+func (s State) Name() string {
+	switch s {
+	case StateStarting:
+		return "시작 중"
+	case StateRunning:
+		return "실행 중"
+	case StatePaused:
+		return "일시정지"
+	case StateStopping:
+		return "중지 중"
+	case StateCompleted:
+		return "완료"
+	case StateFailed:
+		return "오류"
+	default:
+		return "대기 중"
+	}
+}
+
+// Transition은 상태 변경 한 번입니다 (operationState 이벤트 페이로드)
+type Transition struct {
+	From    State     `json:"from"`
+	State   State     `json:"state"`
+	Trigger string    `json:"trigger,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// StateSnapshot은 현재 상태와 마지막 실행의 시각입니다
+type StateSnapshot struct {
+	State    State     `json:"state"`
+	Since    time.Time `json:"since,omitzero"`    // 현재 상태가 된 시각
+	Reason   string    `json:"reason,omitempty"`  // 중지 또는 실패 이유
+	Trigger  string    `json:"trigger,omitempty"` // 현재 상태로 바꾼 주체
+	Started  time.Time `json:"started,omitzero"`  // 마지막 작업을 시작한 시각
+	Finished time.Time `json:"finished,omitzero"` // 마지막 작업이 끝난 시각
+}
+
+// stateMachine은 작업 상태와 허용된 전이를 관리합니다
+type stateMachine struct {
+	now      func() time.Time
+	onChange func(Transition)
+	current  StateSnapshot
+	mutex    sync.Mutex
+}
+
+// newStateMachine은 idle 상태에서 시작하는 상태 기계를 생성합니다
+func newStateMachine(now func() time.Time) *stateMachine {
+	return &stateMachine{now: now, current: StateSnapshot{State: StateIdle}}
+}
+
+// to는 상태를 바꿉니다
+// 허용되지 않는 전이면 상태를 바꾸지 않고 오류를 반환합니다
+func (m *stateMachine) to(state State, trigger, reason string) error {
+	m.mutex.Lock()
+	from := m.current.State
+	if !canTransition(from, state) {
+		m.mutex.Unlock()
+		return fmt.Errorf("작업 상태를 %s에서 %s(으)로 바꿀 수 없습니다", from, state)
+	}
+
+	now := m.now()
+	m.current.State = state
+	m.current.Since = now
+	m.current.Trigger = trigger
+	switch state {
+	case StateStarting:
+		m.current.Started = now
+		m.current.Finished = time.Time{}
+		m.current.Reason = ""
+	case StateIdle:
+		m.current.Reason = ""
+	case StateStopping, StateCompleted, StateFailed:
+		// 중지를 요청한 이유는 작업이 끝난 뒤에도 유지
+		if reason != "" {
+			m.current.Reason = reason
+		}
+		if state != StateStopping {
+			m.current.Finished = now
+		}
+	}
+	onChange := m.onChange
+	m.mutex.Unlock()
+
+	if onChange != nil {
+		onChange(Transition{From: from, State: state, Trigger: trigger, Reason: reason, At: now})
+	}
+	return nil
+}
+
+// state는 현재 상태를 반환합니다
+func (m *stateMachine) state() State {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.current.State
+}
+
+// snapshot은 현재 상태와 시각을 반환합니다
+func (m *stateMachine) snapshot() StateSnapshot {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.current
+}
+
+// canTransition은 from에서 to로 바꿀 수 있는지 확인합니다
+func canTransition(from, to State) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
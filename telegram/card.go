@@ -0,0 +1,148 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/m/notify"
+)
+
+// 상태 카드 버튼의 콜백 데이터
+const (
+	CallbackPause  = "pause"
+	CallbackResume = "resume"
+	CallbackStop   = "stop"
+)
+
+// InlineButton은 메시지 아래에 붙는 인라인 키보드 버튼입니다
+type InlineButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// inlineKeyboard는 reply_markup으로 보내는 인라인 키보드입니다
+type inlineKeyboard struct {
+	InlineKeyboard [][]InlineButton `json:"inline_keyboard"`
+}
+
+// CallbackQuery는 인라인 키보드 버튼을 눌렀을 때 받는 업데이트입니다
+type CallbackQuery struct {
+	ID      string           `json:"id"`
+	Message *IncomingMessage `json:"message,omitempty"` // 버튼이 붙어 있던 메시지
+	Data    string           `json:"data"`
+}
+
+// SendMessageWithKeyboard는 인라인 키보드가 붙은 메시지를 보내고 메시지 ID를 반환합니다
+func (tb *TelegramBot) SendMessageWithKeyboard(text string, keyboard [][]InlineButton) (int64, error) {
+	if tb.ChatID == "" {
+		return 0, fmt.Errorf("텔레그램 설정이 완료되지 않았습니다")
+	}
+
+	var sent IncomingMessage
+	err := tb.call("sendMessage", map[string]interface{}{
+		"chat_id":      tb.ChatID,
+		"text":         text,
+		"parse_mode":   "HTML",
+		"reply_markup": inlineKeyboard{InlineKeyboard: keyboard},
+	}, &sent)
+	return sent.MessageID, err
+}
+
+// EditMessageText는 보낸 메시지의 내용과 인라인 키보드를 바꿉니다 (keyboard가 비어 있으면 버튼 제거)
+// 내용이 같아 바뀌지 않은 경우는 오류로 보지 않습니다
+func (tb *TelegramBot) EditMessageText(messageID int64, text string, keyboard [][]InlineButton) error {
+	if keyboard == nil {
+		keyboard = [][]InlineButton{}
+	}
+	err := tb.call("editMessageText", map[string]interface{}{
+		"chat_id":      tb.ChatID,
+		"message_id":   messageID,
+		"text":         text,
+		"parse_mode":   "HTML",
+		"reply_markup": inlineKeyboard{InlineKeyboard: keyboard},
+	}, nil)
+	if err != nil && strings.Contains(err.Error(), "message is not modified") {
+		return nil
+	}
+	return err
+}
+
+// AnswerCallbackQuery는 버튼 입력에 응답합니다 (text는 잠깐 표시되는 알림, 비어 있어도 됨)
+func (tb *TelegramBot) AnswerCallbackQuery(queryID, text string) error {
+	return tb.call("answerCallbackQuery", map[string]interface{}{
+		"callback_query_id": queryID,
+		"text":              text,
+	}, nil)
+}
+
+// LiveStatus는 실시간 상태 카드에 표시할 내용입니다
+type LiveStatus struct {
+	ModeName      string
+	State         string // 표시할 상태 이름 ("실행 중", "일시정지" 등)
+	StateCode     string // 상태 코드 ("running", "paused" 등)
+	Started       time.Time
+	Elapsed       time.Duration // 일시정지를 제외한 실행 시간
+	Limit         time.Duration // 실행 시간 제한 (0이면 제한 없음)
+	Iterations    int
+	MaxIterations int
+	Paused        bool
+	Finished      bool   // 끝난 작업이면 버튼 없이 표시
+	Reason        string // 끝난 이유
+}
+
+// SendStatusCard는 실시간 상태 카드를 보내고 메시지 ID를 반환합니다
+func (tb *TelegramBot) SendStatusCard(status LiveStatus) (int64, error) {
+	text, err := tb.render(TemplateStatus, status.messageData())
+	if err != nil {
+		return 0, err
+	}
+	return tb.SendMessageWithKeyboard(text, tb.statusCardKeyboard(status))
+}
+
+// UpdateStatusCard는 보낸 상태 카드를 현재 상태로 바꿉니다
+func (tb *TelegramBot) UpdateStatusCard(messageID int64, status LiveStatus) error {
+	text, err := tb.render(TemplateStatus, status.messageData())
+	if err != nil {
+		return err
+	}
+	return tb.EditMessageText(messageID, text, tb.statusCardKeyboard(status))
+}
+
+// messageData는 상태 카드 템플릿에 넘길 값을 만듭니다
+func (status LiveStatus) messageData() MessageData {
+	data := MessageData{
+		Details: notify.Details{
+			Mode:       status.ModeName,
+			Duration:   status.Elapsed,
+			Start:      status.Started,
+			Iterations: status.Iterations,
+		},
+		State:         status.State,
+		StateCode:     status.StateCode,
+		Reason:        status.Reason,
+		MaxIterations: status.MaxIterations,
+		Paused:        status.Paused,
+		Finished:      status.Finished,
+	}
+	if status.Limit > 0 && !status.Finished {
+		data.Remaining = max(status.Limit-status.Elapsed, 0)
+	}
+	return data
+}
+
+// statusCardKeyboard는 상태에 맞는 버튼을 만듭니다
+func (tb *TelegramBot) statusCardKeyboard(status LiveStatus) [][]InlineButton {
+	if status.Finished {
+		return nil
+	}
+	language := tb.templates().Language()
+	toggle := CallbackPause
+	if status.Paused {
+		toggle = CallbackResume
+	}
+	return [][]InlineButton{{
+		{Text: buttonLabel(language, toggle), CallbackData: toggle},
+		{Text: buttonLabel(language, CallbackStop), CallbackData: CallbackStop},
+	}}
+}
@@ -0,0 +1,287 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 롱 폴링 기본값
+const (
+	defaultPollTimeout = 30 * time.Second // getUpdates 한 번의 최대 대기 시간
+	defaultRetryDelay  = 5 * time.Second  // 오류가 나거나 봇이 설정되지 않았을 때 다시 시도하기 전 대기 시간
+)
+
+// Update는 getUpdates로 받은 업데이트 하나입니다
+type Update struct {
+	UpdateID      int64            `json:"update_id"`
+	Message       *IncomingMessage `json:"message,omitempty"`
+	CallbackQuery *CallbackQuery   `json:"callback_query,omitempty"`
+}
+
+// IncomingMessage는 봇이 받은 메시지입니다
+type IncomingMessage struct {
+	MessageID int64  `json:"message_id"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text"`
+}
+
+// Chat은 메시지를 보낸 채팅입니다
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+// GetUpdates는 offset 이후의 업데이트를 최대 timeout 동안 기다려 받습니다 (롱 폴링)
+func (tb *TelegramBot) GetUpdates(offset int64, timeout time.Duration) ([]Update, error) {
+	// 서버가 timeout 동안 응답을 보류하므로 그보다 길게 기다림
+	client := &http.Client{Timeout: timeout + 10*time.Second}
+
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"offset":          offset,
+		"timeout":         int(timeout / time.Second),
+		"allowed_updates": []string{"message", "callback_query"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("요청 인코딩 실패: %v", err)
+	}
+
+	// 롱 폴링은 수신 루프가 다시 시도하므로 재시도와 전송 통계 없이 한 번만 요청
+	var updates []Update
+	if err := tb.request(client, "getUpdates", "application/json", jsonData, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// Command는 텔레그램으로 받을 수 있는 명령 하나입니다
+type Command struct {
+	Description string // /help에 표시할 설명
+	// Handle은 명령 인자를 받아 답장할 메시지(HTML)를 반환합니다
+	Handle func(args []string) (string, error)
+}
+
+// CommandListener는 getUpdates를 롱 폴링하여 설정된 채팅에서 받은 명령을 처리합니다
+// 다른 채팅에서 온 메시지와 명령이 아닌 메시지는 무시합니다
+type CommandListener struct {
+	// Bot은 현재 텔레그램 봇을 반환합니다 (nil이면 설정될 때까지 대기)
+	Bot func() *TelegramBot
+
+	Commands map[string]Command
+	// OnCallback은 인라인 키보드 버튼의 콜백 데이터를 처리하고 잠깐 표시할 알림을 반환합니다 (nil이면 무시)
+	OnCallback  func(data string) (string, error)
+	PollTimeout time.Duration
+	RetryDelay  time.Duration
+
+	offset  int64
+	token   string // offset을 받은 봇 토큰 (바뀌면 다시 동기화)
+	quit    chan struct{}
+	running bool
+	mutex   sync.Mutex
+}
+
+// NewCommandListener는 명령 수신기를 생성합니다
+func NewCommandListener(bot func() *TelegramBot, commands map[string]Command) *CommandListener {
+	return &CommandListener{
+		Bot:         bot,
+		Commands:    commands,
+		PollTimeout: defaultPollTimeout,
+		RetryDelay:  defaultRetryDelay,
+	}
+}
+
+// Start는 명령 수신 루프를 시작합니다
+func (l *CommandListener) Start() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.running {
+		return
+	}
+	l.running = true
+	l.quit = make(chan struct{})
+	go l.loop(l.quit)
+}
+
+// Stop은 명령 수신 루프를 중지합니다 (진행 중인 롱 폴링 요청은 끝날 때까지 기다리지 않음)
+func (l *CommandListener) Stop() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if !l.running {
+		return
+	}
+	l.running = false
+	close(l.quit)
+}
+
+// loop는 봇이 설정되어 있는 동안 업데이트를 받아 처리합니다
+func (l *CommandListener) loop(quit chan struct{}) {
+	for {
+		var err error
+		bot := l.Bot()
+		if bot != nil {
+			err = l.Poll(bot)
+			if err != nil {
+				log.Printf("텔레그램 명령 수신 실패: %v", err)
+			}
+		}
+
+		select {
+		case <-quit:
+			return
+		default:
+		}
+		if bot == nil || err != nil {
+			select {
+			case <-quit:
+				return
+			case <-time.After(l.RetryDelay):
+			}
+		}
+	}
+}
+
+// Poll은 업데이트를 한 번 받아 처리합니다
+// 봇을 처음 사용하거나 토큰이 바뀌면 그동안 쌓인 명령은 실행하지 않고 건너뜁니다
+func (l *CommandListener) Poll(bot *TelegramBot) error {
+	if l.token != bot.Token {
+		if err := l.skipPending(bot); err != nil {
+			return err
+		}
+		l.token = bot.Token
+	}
+
+	updates, err := bot.GetUpdates(l.offset, l.PollTimeout)
+	if err != nil {
+		return err
+	}
+	for _, update := range updates {
+		l.offset = update.UpdateID + 1
+		switch {
+		case update.Message != nil:
+			l.handle(bot, update.Message)
+		case update.CallbackQuery != nil:
+			l.handleCallback(bot, update.CallbackQuery)
+		}
+	}
+	return nil
+}
+
+// skipPending은 프로그램이 꺼져 있던 동안 받은 업데이트를 건너뜁니다
+func (l *CommandListener) skipPending(bot *TelegramBot) error {
+	updates, err := bot.GetUpdates(0, 0)
+	if err != nil {
+		return err
+	}
+	l.offset = 0
+	if n := len(updates); n > 0 {
+		l.offset = updates[n-1].UpdateID + 1
+		log.Printf("텔레그램: 이전에 받은 메시지 %d개를 건너뜁니다", n)
+	}
+	return nil
+}
+
+// handle은 메시지 하나를 처리하고 결과를 답장합니다
+func (l *CommandListener) handle(bot *TelegramBot, message *IncomingMessage) {
+	if strconv.FormatInt(message.Chat.ID, 10) != strings.TrimSpace(bot.ChatID) {
+		log.Printf("텔레그램: 설정되지 않은 채팅(%d)의 메시지를 무시합니다", message.Chat.ID)
+		return
+	}
+
+	name, args, ok := ParseCommand(message.Text)
+	if !ok {
+		return
+	}
+	log.Printf("텔레그램 명령: /%s %s", name, strings.Join(args, " "))
+
+	var reply string
+	command, found := l.Commands[name]
+	switch {
+	case name == "help" || !found:
+		reply = l.help()
+	default:
+		var err error
+		reply, err = command.Handle(args)
+		if err != nil {
+			reply = fmt.Sprintf("⚠️ %s", html.EscapeString(err.Error()))
+		}
+	}
+
+	if reply == "" {
+		return
+	}
+	if err := bot.reply(reply); err != nil {
+		log.Printf("텔레그램 명령 응답 전송 실패: %v", err)
+	}
+}
+
+// reply는 명령 응답을 한 번만 보냅니다
+// 늦게 도착한 응답은 의미가 없으므로 다시 시도하거나 보관함에 넣지 않습니다
+func (tb *TelegramBot) reply(text string) error {
+	jsonData, err := json.Marshal(Message{ChatID: tb.ChatID, Text: text, ParseMode: "HTML"})
+	if err != nil {
+		return fmt.Errorf("요청 인코딩 실패: %v", err)
+	}
+	return tb.request(tb.client(), "sendMessage", "application/json", jsonData, nil)
+}
+
+// handleCallback은 인라인 키보드 버튼 입력을 처리하고 결과를 알림으로 응답합니다
+func (l *CommandListener) handleCallback(bot *TelegramBot, query *CallbackQuery) {
+	if query.Message == nil || strconv.FormatInt(query.Message.Chat.ID, 10) != strings.TrimSpace(bot.ChatID) {
+		log.Printf("텔레그램: 설정되지 않은 채팅의 버튼 입력을 무시합니다")
+		return
+	}
+	log.Printf("텔레그램 버튼: %s", query.Data)
+
+	var reply string
+	if l.OnCallback != nil {
+		var err error
+		reply, err = l.OnCallback(query.Data)
+		if err != nil {
+			reply = fmt.Sprintf("⚠️ %s", err.Error())
+		}
+	}
+
+	// 응답하지 않으면 버튼이 계속 로딩 상태로 남음
+	if err := bot.AnswerCallbackQuery(query.ID, reply); err != nil {
+		log.Printf("텔레그램 버튼 응답 전송 실패: %v", err)
+	}
+}
+
+// help는 사용할 수 있는 명령 목록을 반환합니다
+func (l *CommandListener) help() string {
+	names := make([]string, 0, len(l.Commands))
+	for name := range l.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var text strings.Builder
+	text.WriteString("🤖 <b>사용할 수 있는 명령</b>\n\n")
+	for _, name := range names {
+		fmt.Fprintf(&text, "/%s - %s\n", name, html.EscapeString(l.Commands[name].Description))
+	}
+	return text.String()
+}
+
+// ParseCommand는 "/start@봇이름 인자..." 형식의 메시지를 명령 이름과 인자로 나눕니다
+// 명령이 아니면 ok가 false입니다
+func ParseCommand(text string) (name string, args []string, ok bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return "", nil, false
+	}
+	name = strings.TrimPrefix(fields[0], "/")
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		return "", nil, false
+	}
+	return strings.ToLower(name), fields[1:], true
+}
@@ -0,0 +1,199 @@
+package telegram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBotAPI는 getUpdates와 sendMessage만 처리하는 가짜 Bot API입니다
+type fakeBotAPI struct {
+	mutex      sync.Mutex
+	updates    []Update
+	offsets    []int64  // getUpdates 요청의 offset
+	sent       []string // sendMessage로 받은 메시지
+	sendStatus int      // sendMessage 응답 상태 (0이면 200)
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/getUpdates"):
+		var request struct {
+			Offset int64 `json:"offset"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		f.offsets = append(f.offsets, request.Offset)
+
+		result := []Update{}
+		for _, update := range f.updates {
+			if update.UpdateID >= request.Offset {
+				result = append(result, update)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+	case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+		var message Message
+		json.NewDecoder(r.Body).Decode(&message)
+		f.sent = append(f.sent, message.Text)
+		if f.sendStatus != 0 {
+			w.WriteHeader(f.sendStatus)
+			w.Write([]byte(`{"ok":false,"description":"Bad Gateway"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// push는 새 업데이트를 추가합니다
+func (f *fakeBotAPI) push(updates ...Update) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.updates = append(f.updates, updates...)
+}
+
+func (f *fakeBotAPI) snapshot() (offsets []int64, sent []string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]int64{}, f.offsets...), append([]string{}, f.sent...)
+}
+
+func textUpdate(id, chatID int64, text string) Update {
+	return Update{UpdateID: id, Message: &IncomingMessage{MessageID: id, Chat: Chat{ID: chatID}, Text: text}}
+}
+
+// newListenerTest는 가짜 API에 연결된 봇과 /start 명령만 있는 수신기를 생성합니다
+// /start가 받은 인자는 calls에 기록됩니다
+func newListenerTest(t *testing.T) (*fakeBotAPI, *TelegramBot, *CommandListener, *[][]string) {
+	api := &fakeBotAPI{}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	bot := &TelegramBot{Token: "1:first", ChatID: "42", APIBase: server.URL, Delivery: NewDelivery("")}
+	calls := &[][]string{}
+	listener := NewCommandListener(func() *TelegramBot { return bot }, map[string]Command{
+		"start": {Description: "작업 시작", Handle: func(args []string) (string, error) {
+			*calls = append(*calls, args)
+			return "시작했습니다", nil
+		}},
+	})
+	listener.PollTimeout = 0
+	return api, bot, listener, calls
+}
+
+func TestParseCommand(t *testing.T) {
+	cases := []struct {
+		text string
+		name string
+		args []string
+		ok   bool
+	}{
+		{"/start", "start", []string{}, true},
+		{"/start@MyBot fast 2h", "start", []string{"fast", "2h"}, true},
+		{"  /Status@MyBot  ", "status", []string{}, true},
+		{"/@MyBot", "", nil, false},
+		{"start", "", nil, false},
+		{"", "", nil, false},
+	}
+	for _, c := range cases {
+		name, args, ok := ParseCommand(c.text)
+		if name != c.name || ok != c.ok || (ok && !reflect.DeepEqual(args, c.args)) {
+			t.Errorf("%q: (%q, %q, %v), 기대 (%q, %q, %v)", c.text, name, args, ok, c.name, c.args, c.ok)
+		}
+	}
+}
+
+func TestListenerHandlesConfiguredChat(t *testing.T) {
+	api, bot, listener, calls := newListenerTest(t)
+	if err := listener.Poll(bot); err != nil {
+		t.Fatal(err)
+	}
+
+	api.push(
+		textUpdate(1, 99, "/start"),               // 다른 채팅
+		textUpdate(2, 42, "/start@MyBot fast 2h"), // 봇 이름이 붙은 명령
+		textUpdate(3, 42, "안녕하세요"),                // 명령이 아닌 메시지
+		textUpdate(4, 42, "/unknown"),             // 없는 명령은 도움말
+	)
+	if err := listener.Poll(bot); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(*calls, [][]string{{"fast", "2h"}}) {
+		t.Errorf("명령 인자 %q", *calls)
+	}
+	_, sent := api.snapshot()
+	if len(sent) != 2 || sent[0] != "시작했습니다" || !strings.Contains(sent[1], "/start - 작업 시작") {
+		t.Errorf("답장 %q", sent)
+	}
+	if listener.offset != 5 {
+		t.Errorf("offset %d, 기대 5", listener.offset)
+	}
+}
+
+func TestListenerSkipsPendingOnTokenChange(t *testing.T) {
+	api, bot, listener, calls := newListenerTest(t)
+
+	// 처음 사용할 때 쌓여 있던 명령은 건너뜀
+	api.push(textUpdate(5, 42, "/start"), textUpdate(6, 42, "/start"))
+	if err := listener.Poll(bot); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) != 0 || listener.offset != 7 {
+		t.Fatalf("명령 %d개 실행, offset %d", len(*calls), listener.offset)
+	}
+
+	api.push(textUpdate(7, 42, "/start"))
+	if err := listener.Poll(bot); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) != 1 || listener.offset != 8 {
+		t.Fatalf("명령 %d개 실행, offset %d", len(*calls), listener.offset)
+	}
+
+	// 토큰이 바뀌면 다시 동기화 (새 봇은 업데이트 번호가 이어지지 않을 수 있음)
+	api.push(textUpdate(8, 42, "/start"))
+	bot.Token = "2:second"
+	if err := listener.Poll(bot); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) != 1 || listener.offset != 9 {
+		t.Fatalf("토큰 변경 후 명령 %d개 실행, offset %d", len(*calls), listener.offset)
+	}
+
+	offsets, _ := api.snapshot()
+	if want := []int64{0, 7, 7, 0, 9}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("getUpdates offset %v, 기대 %v", offsets, want)
+	}
+}
+
+func TestListenerReplyIsNotQueued(t *testing.T) {
+	api, bot, listener, _ := newListenerTest(t)
+	waits := 0
+	bot.Delivery.sleep = func(time.Duration) { waits++ }
+	api.sendStatus = http.StatusBadGateway
+	if err := listener.Poll(bot); err != nil {
+		t.Fatal(err)
+	}
+
+	api.push(textUpdate(1, 42, "/start"))
+	if err := listener.Poll(bot); err != nil {
+		t.Fatal(err)
+	}
+	_, sent := api.snapshot()
+	if len(sent) != 1 || waits != 0 {
+		t.Errorf("답장 %d번 전송, 재시도 %d번", len(sent), waits)
+	}
+	if queued := bot.Delivery.Stats().Queued; queued != 0 {
+		t.Errorf("보관함 %d개", queued)
+	}
+}
@@ -0,0 +1,399 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 전송 기본값
+const (
+	DefaultTimeout    = 15 * time.Second // Bot API 요청 하나의 최대 대기 시간
+	DefaultMaxRetries = 3                // 429, 5xx, 네트워크 오류일 때 다시 시도할 횟수
+
+	retryBaseDelay    = time.Second    // 첫 재시도 전 대기 시간 (재시도마다 두 배)
+	retryMaxDelay     = time.Minute    // 재시도 전 최대 대기 시간 (retry_after 포함)
+	outboxSize        = 100            // 보관함 최대 메시지 수 (넘으면 오래된 것부터 버림)
+	outboxFlushPeriod = time.Minute    // 보관함 메시지를 다시 보내 보는 주기
+	outboxMaxAge      = 24 * time.Hour // 이보다 오래된 보관 메시지는 보내지 않고 버림
+)
+
+// APIError는 Bot API가 돌려준 오류입니다
+type APIError struct {
+	StatusCode  int
+	Description string
+	RetryAfter  time.Duration // 429 응답의 retry_after (없으면 0)
+}
+
+// Error는 오류 메시지를 반환합니다
+func (e *APIError) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("텔레그램 API 오류: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("텔레그램 API 오류: %d %s", e.StatusCode, e.Description)
+}
+
+// requestError는 응답을 받지 못한 요청의 오류입니다 (네트워크 오류, 제한 시간 초과)
+// url.Error는 봇 토큰이 들어 있는 주소를 포함하므로 원인 오류의 메시지만 토큰을 가려 보관합니다
+type requestError struct {
+	method  string
+	message string
+}
+
+// Error는 오류 메시지를 반환합니다
+func (e *requestError) Error() string {
+	return fmt.Sprintf("%s 요청 실패: %s", e.method, e.message)
+}
+
+// temporary는 다시 보내면 성공할 수 있는 오류인지 확인합니다 (네트워크 오류, 제한 시간 초과, 429, 5xx)
+// 2xx 응답을 받은 뒤의 오류(응답 해석 실패 등)는 이미 처리된 요청일 수 있으므로 다시 보내지 않습니다
+func temporary(err error) bool {
+	switch err := err.(type) {
+	case *requestError:
+		return true
+	case *APIError:
+		return err.StatusCode == http.StatusTooManyRequests || err.StatusCode >= 500
+	default:
+		return false
+	}
+}
+
+// DeliveryStats는 메시지 전송 결과 통계입니다
+type DeliveryStats struct {
+	Sent      int       `json:"sent"`    // 보낸 요청 수
+	Retries   int       `json:"retries"` // 다시 시도한 횟수
+	Failed    int       `json:"failed"`  // 다시 시도해도 보내지 못한 요청 수
+	Queued    int       `json:"queued"`  // 보관함에서 전송을 기다리는 메시지 수
+	LastError string    `json:"last_error,omitempty"`
+	LastFail  time.Time `json:"last_fail,omitzero"`
+}
+
+// outboxItem은 보내지 못해 보관한 메시지입니다
+type outboxItem struct {
+	ChatID   string    `json:"chat_id,omitempty"` // 받을 채팅 (비어 있으면 봇의 채팅, 이전 버전에서 보관한 메시지)
+	Text     string    `json:"text"`
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// Delivery는 텔레그램 요청의 제한 시간과 재시도, 보내지 못한 메시지 보관함을 관리합니다
+// 설정이 바뀌어 봇을 새로 만들어도 같은 Delivery를 넘겨 보관함과 통계를 유지합니다
+type Delivery struct {
+	Timeout    time.Duration // 요청 제한 시간 (0이면 DefaultTimeout)
+	MaxRetries int           // 재시도 횟수 (0이면 재시도하지 않음)
+
+	// OnChange는 전송 실패나 보관함 변경이 있을 때 호출됩니다 (nil이면 무시)
+	OnChange func(DeliveryStats)
+
+	path   string // 보관함 파일 경로 (비어 있으면 메모리에만 보관)
+	outbox []outboxItem
+	stats  DeliveryStats
+	sleep  func(time.Duration)
+	quit   chan struct{}
+	flush  sync.Mutex // 보관함 전송은 한 번에 하나만
+	mutex  sync.Mutex
+}
+
+// NewDelivery는 path 파일을 보관함으로 사용하는 전송 관리자를 생성합니다
+// 이전에 보내지 못한 메시지가 있으면 불러옵니다
+func NewDelivery(path string) *Delivery {
+	d := &Delivery{
+		Timeout:    DefaultTimeout,
+		MaxRetries: DefaultMaxRetries,
+		path:       path,
+		sleep:      time.Sleep,
+	}
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			if err := json.Unmarshal(data, &d.outbox); err != nil {
+				log.Printf("텔레그램 보관함 파싱 실패: %v", err)
+			}
+		}
+	}
+	d.stats.Queued = len(d.outbox)
+	return d
+}
+
+// Stats는 전송 통계를 반환합니다
+func (d *Delivery) Stats() DeliveryStats {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.stats
+}
+
+// Start는 보관함 메시지를 주기적으로 다시 보내기 시작합니다 (bot이 nil을 반환하면 건너뜀)
+func (d *Delivery) Start(bot func() *TelegramBot) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.quit != nil {
+		return
+	}
+	d.quit = make(chan struct{})
+	go func(quit chan struct{}) {
+		ticker := time.NewTicker(outboxFlushPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+				if b := bot(); b != nil {
+					b.FlushOutbox()
+				}
+			}
+		}
+	}(d.quit)
+}
+
+// Stop은 보관함 재전송을 중지합니다
+func (d *Delivery) Stop() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.quit != nil {
+		close(d.quit)
+		d.quit = nil
+	}
+}
+
+// enqueue는 chatID로 보낼 메시지를 보관함에 넣습니다
+func (d *Delivery) enqueue(chatID, text string) {
+	d.mutex.Lock()
+	d.outbox = append(d.outbox, outboxItem{ChatID: chatID, Text: text, QueuedAt: time.Now()})
+	if len(d.outbox) > outboxSize {
+		d.outbox = d.outbox[len(d.outbox)-outboxSize:]
+	}
+	d.saveLocked()
+	stats := d.stats
+	d.mutex.Unlock()
+	d.changed(stats)
+}
+
+// pending은 보관함에 메시지가 있는지 확인합니다
+func (d *Delivery) pending() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return len(d.outbox) > 0
+}
+
+// saveLocked는 보관함을 파일에 저장합니다 (mutex를 잡은 상태에서 호출)
+func (d *Delivery) saveLocked() {
+	d.stats.Queued = len(d.outbox)
+	if d.path == "" {
+		return
+	}
+	data, err := json.Marshal(d.outbox)
+	if err == nil {
+		err = os.WriteFile(d.path, data, 0644)
+	}
+	if err != nil {
+		log.Printf("텔레그램 보관함 저장 실패: %v", err)
+	}
+}
+
+// record는 요청 하나의 최종 결과를 통계에 반영합니다
+func (d *Delivery) record(err error, retries int) {
+	d.mutex.Lock()
+	d.stats.Retries += retries
+	if err == nil {
+		d.stats.Sent++
+		d.mutex.Unlock()
+		return
+	}
+	d.stats.Failed++
+	d.stats.LastError = err.Error()
+	d.stats.LastFail = time.Now()
+	stats := d.stats
+	d.mutex.Unlock()
+	d.changed(stats)
+}
+
+// changed는 OnChange를 호출합니다
+func (d *Delivery) changed(stats DeliveryStats) {
+	if d.OnChange != nil {
+		d.OnChange(stats)
+	}
+}
+
+// FlushOutbox는 보관함의 메시지를 순서대로 보냅니다
+// 메시지는 보관할 때의 채팅으로 보내므로 그사이 채팅 ID를 바꿔도 원래 채팅으로 전달됩니다
+// 보내지 못하면 나머지는 다음에 다시 보내도록 남겨 두고 보낸 메시지 수를 반환합니다
+func (tb *TelegramBot) FlushOutbox() (int, error) {
+	d := tb.Delivery
+	if d == nil {
+		return 0, nil
+	}
+	d.flush.Lock()
+	defer d.flush.Unlock()
+
+	sent := 0
+	for {
+		d.mutex.Lock()
+		if len(d.outbox) == 0 {
+			d.mutex.Unlock()
+			break
+		}
+		item := d.outbox[0]
+		d.mutex.Unlock()
+
+		// 주기적으로 다시 보내므로 재시도하지 않고, 실패해도 새 실패로 세지 않음
+		delivered := false
+		if time.Since(item.QueuedAt) <= outboxMaxAge {
+			chatID := item.ChatID
+			if chatID == "" {
+				chatID = tb.ChatID
+			}
+			body, _ := json.Marshal(Message{ChatID: chatID, Text: item.Text, ParseMode: "HTML"})
+			err := tb.request(tb.client(), "sendMessage", "application/json", body, nil)
+			if err != nil && temporary(err) {
+				return sent, err
+			}
+			if err != nil {
+				log.Printf("텔레그램 보관 메시지를 보낼 수 없어 버립니다: %v", err)
+			}
+			delivered = err == nil
+		}
+
+		d.mutex.Lock()
+		if delivered {
+			d.stats.Sent++
+			sent++
+		}
+		d.outbox = d.outbox[1:]
+		d.saveLocked()
+		stats := d.stats
+		d.mutex.Unlock()
+		d.changed(stats)
+	}
+	if sent > 0 {
+		log.Printf("텔레그램 보관 메시지 %d개를 보냈습니다", sent)
+	}
+	return sent, nil
+}
+
+// send는 메시지를 보냅니다
+// queue가 true이면 먼저 보관함의 메시지를 보내고, 일시적인 오류로 보내지 못하면 보관함에 넣어 나중에 다시 보냅니다
+func (tb *TelegramBot) send(text string, queue bool) error {
+	if tb.Token == "" || tb.ChatID == "" {
+		return fmt.Errorf("텔레그램 설정이 완료되지 않았습니다")
+	}
+	if !queue || tb.Delivery == nil {
+		return tb.sendText(text)
+	}
+
+	// 순서를 지키기 위해 보관함이 비어 있을 때만 바로 보냄
+	var err error
+	if tb.Delivery.pending() {
+		_, err = tb.FlushOutbox()
+	}
+	if err == nil {
+		err = tb.sendText(text)
+	}
+	if err != nil && temporary(err) {
+		tb.Delivery.enqueue(tb.ChatID, text)
+		return fmt.Errorf("%v (보관함에 저장, 연결되면 다시 보냅니다)", err)
+	}
+	return err
+}
+
+// sendText는 HTML 메시지 하나를 보냅니다
+func (tb *TelegramBot) sendText(text string) error {
+	return tb.call("sendMessage", Message{ChatID: tb.ChatID, Text: text, ParseMode: "HTML"}, nil)
+}
+
+// client는 요청 제한 시간이 적용된 HTTP 클라이언트를 반환합니다
+func (tb *TelegramBot) client() *http.Client {
+	timeout := DefaultTimeout
+	if tb.Delivery != nil && tb.Delivery.Timeout > 0 {
+		timeout = tb.Delivery.Timeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// call은 Bot API 메서드를 JSON으로 호출하고 결과를 result에 디코딩합니다 (result가 nil이면 무시)
+func (tb *TelegramBot) call(method string, payload interface{}, result interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("요청 인코딩 실패: %v", err)
+	}
+	return tb.post(tb.client(), method, "application/json", jsonData, result)
+}
+
+// post는 요청을 보내고, 429, 5xx, 네트워크 오류이면 간격을 두 배씩 늘리며 다시 시도합니다
+// 429 응답에 retry_after가 있으면 그만큼 기다립니다
+func (tb *TelegramBot) post(client *http.Client, method, contentType string, body []byte, result interface{}) error {
+	maxRetries, sleep := 0, time.Sleep
+	if tb.Delivery != nil {
+		maxRetries = tb.Delivery.MaxRetries
+		if tb.Delivery.sleep != nil {
+			sleep = tb.Delivery.sleep
+		}
+	}
+
+	delay := retryBaseDelay
+	attempt := 0
+	for ; ; attempt++ {
+		err := tb.request(client, method, contentType, body, result)
+		if err == nil || !temporary(err) || attempt >= maxRetries {
+			if tb.Delivery != nil {
+				tb.Delivery.record(err, attempt)
+			}
+			return err
+		}
+
+		wait := delay
+		if apiErr, ok := err.(*APIError); ok && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		wait = min(wait, retryMaxDelay)
+		log.Printf("텔레그램 %s 실패, %v 후 다시 시도합니다 (%d/%d): %v", method, wait, attempt+1, maxRetries, err)
+		sleep(wait)
+		delay = min(delay*2, retryMaxDelay)
+	}
+}
+
+// request는 요청을 한 번 보내고 결과를 result에 디코딩합니다
+func (tb *TelegramBot) request(client *http.Client, method, contentType string, body []byte, result interface{}) error {
+	if tb.Token == "" {
+		return fmt.Errorf("텔레그램 설정이 완료되지 않았습니다")
+	}
+
+	resp, err := client.Post(tb.methodURL(method), contentType, bytes.NewReader(body))
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return &requestError{method: method, message: strings.ReplaceAll(err.Error(), tb.Token, "<redacted>")}
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		OK          bool            `json:"ok"`
+		Result      json.RawMessage `json:"result"`
+		Description string          `json:"description"`
+		Parameters  struct {
+			RetryAfter int `json:"retry_after"`
+		} `json:"parameters"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return &APIError{StatusCode: resp.StatusCode}
+	}
+	if !response.OK {
+		return &APIError{
+			StatusCode:  resp.StatusCode,
+			Description: response.Description,
+			RetryAfter:  time.Duration(response.Parameters.RetryAfter) * time.Second,
+		}
+	}
+	if result != nil {
+		if err := json.Unmarshal(response.Result, result); err != nil {
+			return fmt.Errorf("%s 응답 해석 실패: %v", method, err)
+		}
+	}
+	return nil
+}
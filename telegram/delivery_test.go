@@ -0,0 +1,292 @@
+package telegram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testToken = "123456:SECRET-token"
+
+// replayServer는 정해진 응답을 차례로 돌려주는 가짜 Bot API입니다 (응답이 떨어지면 마지막 응답 반복)
+type replayServer struct {
+	mutex     sync.Mutex
+	responses []replayResponse
+	calls     int
+}
+
+type replayResponse struct {
+	status int
+	body   string
+}
+
+func (s *replayServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	response := s.responses[min(s.calls, len(s.responses)-1)]
+	s.calls++
+	w.WriteHeader(response.status)
+	w.Write([]byte(response.body))
+}
+
+func (s *replayServer) count() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+// newDeliveryTestBot은 재시도 대기 시간을 기록만 하는 봇을 생성합니다
+func newDeliveryTestBot(apiBase string) (*TelegramBot, *[]time.Duration) {
+	waits := &[]time.Duration{}
+	d := NewDelivery("")
+	d.MaxRetries = 2
+	d.sleep = func(wait time.Duration) { *waits = append(*waits, wait) }
+	return &TelegramBot{Token: testToken, ChatID: "1", APIBase: apiBase, Delivery: d}, waits
+}
+
+func TestRequestErrorHidesToken(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close() // 연결이 거부되는 주소
+
+	bot, waits := newDeliveryTestBot(server.URL)
+	err := bot.call("getMe", nil, nil)
+	if err == nil {
+		t.Fatal("닫힌 서버로 요청이 성공했습니다")
+	}
+	if strings.Contains(err.Error(), testToken) || !strings.Contains(err.Error(), "getMe 요청 실패") {
+		t.Errorf("오류 메시지: %q", err)
+	}
+	if stats := bot.Delivery.Stats(); strings.Contains(stats.LastError, testToken) || stats.Failed != 1 {
+		t.Errorf("통계: %+v", stats)
+	}
+	// 네트워크 오류는 다시 시도
+	if len(*waits) != 2 {
+		t.Errorf("재시도 %d번, 기대 2번", len(*waits))
+	}
+}
+
+func TestRetryOnlyTemporaryErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		response replayResponse
+		calls    int
+		queued   int
+	}{
+		{"서버 오류", replayResponse{http.StatusBadGateway, `{"ok":false,"description":"Bad Gateway"}`}, 3, 1},
+		{"요청 제한", replayResponse{http.StatusTooManyRequests, `{"ok":false,"parameters":{"retry_after":1}}`}, 3, 1},
+		{"잘못된 요청", replayResponse{http.StatusBadRequest, `{"ok":false,"description":"Bad Request"}`}, 1, 0},
+		{"성공 응답 해석 실패", replayResponse{http.StatusOK, `<html>`}, 1, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &replayServer{responses: []replayResponse{tc.response}}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			bot, _ := newDeliveryTestBot(server.URL)
+			if err := bot.SendMessage("테스트"); err == nil {
+				t.Fatal("오류가 나야 합니다")
+			}
+			if api.count() != tc.calls {
+				t.Errorf("요청 %d번, 기대 %d번", api.count(), tc.calls)
+			}
+			if queued := bot.Delivery.Stats().Queued; queued != tc.queued {
+				t.Errorf("보관함 %d개, 기대 %d개", queued, tc.queued)
+			}
+		})
+	}
+}
+
+func TestNoRetryAfterSuccessfulResponse(t *testing.T) {
+	api := &replayServer{responses: []replayResponse{{http.StatusOK, `{"ok":true,"result":"문자열"}`}}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	bot, waits := newDeliveryTestBot(server.URL)
+	var result struct{ MessageID int64 }
+	err := bot.call("sendMessage", Message{ChatID: "1", Text: "테스트"}, &result)
+	if err == nil || !strings.Contains(err.Error(), "응답 해석 실패") {
+		t.Fatalf("오류: %v", err)
+	}
+	if api.count() != 1 || len(*waits) != 0 {
+		t.Errorf("요청 %d번, 재시도 %d번 (처리된 요청을 다시 보냄)", api.count(), len(*waits))
+	}
+}
+
+// chatServer는 받은 sendMessage 요청을 기록하는 가짜 Bot API입니다
+// fail이 오류 응답을 돌려주면 그 응답으로, 아니면 성공으로 응답합니다
+type chatServer struct {
+	mutex    sync.Mutex
+	fail     func(message Message) *replayResponse
+	messages []Message // 성공으로 응답한 메시지
+	calls    int
+}
+
+func (s *chatServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var message Message
+	json.NewDecoder(r.Body).Decode(&message)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls++
+	if s.fail != nil {
+		if response := s.fail(message); response != nil {
+			w.WriteHeader(response.status)
+			w.Write([]byte(response.body))
+			return
+		}
+	}
+	s.messages = append(s.messages, message)
+	w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+}
+
+func (s *chatServer) delivered() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var delivered []string
+	for _, message := range s.messages {
+		delivered = append(delivered, message.ChatID+":"+message.Text)
+	}
+	return delivered
+}
+
+var badGateway = &replayResponse{http.StatusBadGateway, `{"ok":false,"description":"Bad Gateway"}`}
+
+// writeOutbox는 보관함 파일을 만듭니다
+func writeOutbox(t *testing.T, items []outboxItem) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	data, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOutboxQueuesTemporaryErrors(t *testing.T) {
+	down := true
+	api := &chatServer{fail: func(Message) *replayResponse {
+		if down {
+			return badGateway
+		}
+		return nil
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	bot, _ := newDeliveryTestBot(server.URL)
+	err := bot.SendMessage("첫 번째")
+	if err == nil || !strings.Contains(err.Error(), "보관함에 저장") {
+		t.Fatalf("오류: %v", err)
+	}
+	if stats := bot.Delivery.Stats(); stats.Queued != 1 || stats.Failed != 1 {
+		t.Errorf("통계: %+v", stats)
+	}
+
+	// 연결이 돌아오면 보관한 메시지를 먼저 보내고 새 메시지를 보냄
+	down = false
+	if err := bot.SendMessage("두 번째"); err != nil {
+		t.Fatal(err)
+	}
+	if got := api.delivered(); strings.Join(got, ",") != "1:첫 번째,1:두 번째" {
+		t.Errorf("보낸 메시지 %v", got)
+	}
+	if stats := bot.Delivery.Stats(); stats.Queued != 0 || stats.Sent != 2 {
+		t.Errorf("통계: %+v", stats)
+	}
+}
+
+func TestOutboxPersistsAndFlushesInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	down := &chatServer{fail: func(Message) *replayResponse { return badGateway }}
+	downServer := httptest.NewServer(down)
+	defer downServer.Close()
+
+	bot, _ := newDeliveryTestBot(downServer.URL)
+	bot.Delivery = NewDelivery(path)
+	bot.Delivery.MaxRetries = 0
+	for _, text := range []string{"a", "b"} {
+		if err := bot.SendMessage(text); err == nil {
+			t.Fatalf("%s: 오류가 나야 합니다", text)
+		}
+	}
+	// 보관한 뒤 채팅을 바꿔도 이미 보관한 메시지는 원래 채팅으로 보냄
+	bot.ChatID = "2"
+	if err := bot.SendMessage("c"); err == nil {
+		t.Fatal("오류가 나야 합니다")
+	}
+
+	// 다시 시작한 것처럼 파일에서 보관함을 불러옴
+	delivery := NewDelivery(path)
+	if queued := delivery.Stats().Queued; queued != 3 {
+		t.Fatalf("불러온 보관함 %d개, 기대 3개", queued)
+	}
+	api := &chatServer{}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	restarted := &TelegramBot{Token: testToken, ChatID: "3", APIBase: server.URL, Delivery: delivery}
+
+	sent, err := restarted.FlushOutbox()
+	if err != nil || sent != 3 {
+		t.Fatalf("보낸 메시지 %d개, 오류 %v", sent, err)
+	}
+	if got := api.delivered(); strings.Join(got, ",") != "1:a,1:b,2:c" {
+		t.Errorf("보낸 메시지 %v", got)
+	}
+	if queued := NewDelivery(path).Stats().Queued; queued != 0 {
+		t.Errorf("보낸 뒤 파일의 보관함 %d개", queued)
+	}
+}
+
+func TestOutboxDropsExpiredAndRejected(t *testing.T) {
+	now := time.Now()
+	path := writeOutbox(t, []outboxItem{
+		{ChatID: "1", Text: "오래된 메시지", QueuedAt: now.Add(-outboxMaxAge - time.Minute)},
+		{ChatID: "1", Text: "거절된 메시지", QueuedAt: now},
+		{Text: "이전 버전 메시지", QueuedAt: now}, // 채팅이 없으면 봇의 채팅
+		{ChatID: "1", Text: "서버 오류", QueuedAt: now},
+		{ChatID: "1", Text: "남은 메시지", QueuedAt: now},
+	})
+	api := &chatServer{fail: func(message Message) *replayResponse {
+		switch message.Text {
+		case "거절된 메시지":
+			return &replayResponse{http.StatusBadRequest, `{"ok":false,"description":"Bad Request: chat not found"}`}
+		case "서버 오류":
+			return badGateway
+		}
+		return nil
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+	bot := &TelegramBot{Token: testToken, ChatID: "9", APIBase: server.URL, Delivery: NewDelivery(path)}
+
+	// 일시적인 오류가 나면 그 메시지부터 남겨 두고 멈춤
+	sent, err := bot.FlushOutbox()
+	if err == nil || sent != 1 {
+		t.Fatalf("보낸 메시지 %d개, 오류 %v", sent, err)
+	}
+	// 오래된 메시지는 보내지 않고, 거절된 메시지는 다시 보내지 않고 버림
+	if got := api.delivered(); strings.Join(got, ",") != "9:이전 버전 메시지" || api.calls != 3 {
+		t.Errorf("보낸 메시지 %v, 요청 %d번", got, api.calls)
+	}
+	if stats := bot.Delivery.Stats(); stats.Queued != 2 || stats.Sent != 1 || stats.Failed != 0 {
+		t.Errorf("통계: %+v", stats)
+	}
+
+	api.fail = nil
+	if sent, err := bot.FlushOutbox(); err != nil || sent != 2 {
+		t.Fatalf("보낸 메시지 %d개, 오류 %v", sent, err)
+	}
+	if got := api.delivered(); strings.Join(got, ",") != "9:이전 버전 메시지,1:서버 오류,1:남은 메시지" {
+		t.Errorf("보낸 메시지 %v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package telegram
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// 사진 전송 설정
+const (
+	photoQuality    = 85               // JPEG 품질
+	maxCaptionRunes = 1024             // 텔레그램 사진 설명 길이 제한
+	photoTimeout    = 60 * time.Second // 큰 화면 캡처도 올릴 수 있도록 여유 있게
+)
+
+// SendPhoto는 이미지를 JPEG로 인코딩해 사진으로 전송합니다 (caption은 HTML, 비어 있으면 설명 없음)
+func (tb *TelegramBot) SendPhoto(img image.Image, caption string) error {
+	if tb.ChatID == "" {
+		return fmt.Errorf("텔레그램 설정이 완료되지 않았습니다")
+	}
+	if img == nil {
+		return fmt.Errorf("전송할 이미지가 없습니다")
+	}
+	if runes := []rune(caption); len(runes) > maxCaptionRunes {
+		caption = string(runes[:maxCaptionRunes])
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("chat_id", tb.ChatID)
+	if caption != "" {
+		form.WriteField("caption", caption)
+		form.WriteField("parse_mode", "HTML")
+	}
+	part, err := form.CreateFormFile("photo", "screenshot.jpg")
+	if err != nil {
+		return fmt.Errorf("사진 인코딩 실패: %v", err)
+	}
+	if err := jpeg.Encode(part, img, &jpeg.Options{Quality: photoQuality}); err != nil {
+		return fmt.Errorf("사진 인코딩 실패: %v", err)
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("사진 인코딩 실패: %v", err)
+	}
+
+	client := &http.Client{Timeout: photoTimeout}
+	return tb.post(client, "sendPhoto", form.FormDataContentType(), body.Bytes(), nil)
+}
@@ -1,17 +1,24 @@
 package telegram
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
 	"time"
+
+	"example.com/m/notify"
 )
 
+// 텔레그램 Bot API 기본 주소
+const DefaultAPIBase = "https://api.telegram.org"
+
 // TelegramBot은 텔레그램 봇 설정을 관리합니다
 type TelegramBot struct {
-	Token  string
-	ChatID string
+	Token   string
+	ChatID  string
+	APIBase string // Bot API 주소 (비어 있으면 DefaultAPIBase, 테스트에서는 가짜 서버 주소)
+
+	Delivery  *Delivery  // 제한 시간, 재시도, 보관함 (nil이면 기본 제한 시간만 적용)
+	Templates *Templates // 메시지 언어, 시간대, 템플릿 (nil이면 한국어 기본 템플릿)
 }
 
 // Message는 텔레그램 메시지 구조체입니다
@@ -30,127 +37,69 @@ func NewTelegramBot(token, chatID string) *TelegramBot {
 }
 
 // SendMessage는 텔레그램으로 메시지를 전송합니다
+// 일시적인 오류로 보내지 못하면 보관함에 넣어 두었다가 연결되면 다시 보냅니다 (Delivery가 있을 때)
 func (tb *TelegramBot) SendMessage(text string) error {
-	if tb.Token == "" || tb.ChatID == "" {
-		return fmt.Errorf("텔레그램 설정이 완료되지 않았습니다")
-	}
-
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", tb.Token)
-
-	message := Message{
-		ChatID:    tb.ChatID,
-		Text:      text,
-		ParseMode: "HTML",
-	}
-
-	jsonData, err := json.Marshal(message)
-	if err != nil {
-		return fmt.Errorf("메시지 인코딩 실패: %v", err)
-	}
-
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("메시지 전송 실패: %v", err)
-	}
-	defer resp.Body.Close()
+	return tb.send(text, true)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("텔레그램 API 오류: %d", resp.StatusCode)
+// methodURL은 Bot API 메서드의 주소를 반환합니다
+func (tb *TelegramBot) methodURL(method string) string {
+	base := tb.APIBase
+	if base == "" {
+		base = DefaultAPIBase
 	}
-
-	return nil
+	return fmt.Sprintf("%s/bot%s/%s", strings.TrimSuffix(base, "/"), tb.Token, method)
 }
 
 // SendStartNotification은 작업 시작 알림을 전송합니다
-func (tb *TelegramBot) SendStartNotification(modeName string, duration time.Duration) error {
-	now := time.Now()
-	endTime := now.Add(duration)
-
-	// 한국 시간으로 변환 (KST)
-	loc, _ := time.LoadLocation("Asia/Seoul")
-	startTimeKST := now.In(loc)
-	endTimeKST := endTime.In(loc)
-
-	message := fmt.Sprintf(`🚀 <b>매크로 시작 알림</b> 🚀
-
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
-🎮 <b>모드:</b> %s
-⏱️ <b>예상 실행 시간:</b> %s
-🕐 <b>시작 시간:</b> %s
-🕕 <b>종료 예상 시간:</b> %s
-▶️ <b>상태:</b> <code>실행 시작</code>
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
-
-💪 도우미가 열심히 작업을 시작했습니다!
-📱 완료되면 자동으로 알림을 보내드릴게요.
-
-<i>⏰ %s 기준</i>`,
-		modeName,
-		formatDuration(duration),
-		startTimeKST.Format("2006년 01월 02일 15:04:05"),
-		endTimeKST.Format("2006년 01월 02일 15:04:05"),
-		startTimeKST.Format("2006-01-02 15:04:05"))
+func (tb *TelegramBot) SendStartNotification(details notify.Details) error {
+	return tb.sendTemplate(TemplateStart, MessageData{Details: details})
+}
 
-	return tb.SendMessage(message)
+// SendProgressNotification은 실행 중인 작업의 진행 상황 알림을 전송합니다
+func (tb *TelegramBot) SendProgressNotification(details notify.Details) error {
+	return tb.sendTemplate(TemplateProgress, MessageData{Details: details})
 }
 
 // SendCompletionNotification은 작업 완료 알림을 전송합니다
-func (tb *TelegramBot) SendCompletionNotification(modeName string, duration time.Duration) error {
-	now := time.Now()
-	startTime := now.Add(-duration)
-
-	// 한국 시간으로 변환 (KST)
-	loc, _ := time.LoadLocation("Asia/Seoul")
-	startTimeKST := startTime.In(loc)
-	endTimeKST := now.In(loc)
-
-	message := fmt.Sprintf(`🎉 <b>매크로 완료 알림</b> 🎉
-
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
-🎮 <b>모드:</b> %s
-⏱️ <b>총 실행 시간:</b> %s
-🕐 <b>시작 시간:</b> %s
-🕕 <b>완료 시간:</b> %s
-✅ <b>상태:</b> <code>정상 완료</code>
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
-
-🎊 축하합니다! 설정된 시간동안 성공적으로 작업을 완료했습니다!
-💎 이제 게임에서 확인해보세요!
-
-<i>⏰ %s 기준</i>`,
-		modeName,
-		formatDuration(duration),
-		startTimeKST.Format("2006년 01월 02일 15:04:05"),
-		endTimeKST.Format("2006년 01월 02일 15:04:05"),
-		endTimeKST.Format("2006-01-02 15:04:05"))
-
-	return tb.SendMessage(message)
+func (tb *TelegramBot) SendCompletionNotification(details notify.Details) error {
+	return tb.sendTemplate(TemplateCompletion, MessageData{Details: details})
 }
 
 // SendErrorNotification은 오류 알림을 전송합니다
-func (tb *TelegramBot) SendErrorNotification(modeName string, errorMsg string) error {
-	now := time.Now()
-	loc, _ := time.LoadLocation("Asia/Seoul")
-	nowKST := now.In(loc)
-
-	message := fmt.Sprintf(`❌ <b>매크로 오류 알림</b> ❌
-
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
-🎮 <b>모드:</b> %s
-⚠️ <b>오류 내용:</b> %s
-🛑 <b>상태:</b> <code>오류로 인한 중단</code>
-🕐 <b>발생 시간:</b> %s
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
+func (tb *TelegramBot) SendErrorNotification(details notify.Details) error {
+	return tb.sendTemplate(TemplateError, MessageData{Details: details})
+}
 
-🔧 문제를 확인하고 다시 시도해주세요.
-📋 자세한 로그는 프로그램의 로그 탭에서 확인할 수 있습니다.
+// PlaylistStepReport는 재생 목록 요약 알림에 표시할 단계 하나입니다
+type PlaylistStepReport struct {
+	Name       string
+	Result     string // 표시할 결과 ("정상 완료", "오류" 등)
+	Status     string // 결과 코드 (automation.RunStatus, 시작하지 못했으면 빈 문자열)
+	Duration   time.Duration
+	Iterations int
+}
 
-<i>⏰ %s 기준</i>`,
-		modeName,
-		errorMsg,
-		nowKST.Format("2006년 01월 02일 15:04:05"),
-		nowKST.Format("2006-01-02 15:04:05"))
+// SendPlaylistSummary는 재생 목록이 끝났을 때 전체 단계의 요약 알림을 전송합니다
+// status는 표시할 상태 이름, statusCode는 상태 코드(automation.PlaylistState)입니다
+func (tb *TelegramBot) SendPlaylistSummary(status, statusCode string, steps []PlaylistStepReport, total int, reason string) error {
+	data := MessageData{State: status, StateCode: statusCode, Steps: steps, Reason: reason}
+	for _, step := range steps {
+		data.Duration += step.Duration
+		data.Iterations += step.Iterations
+	}
+	if skipped := total - len(steps); skipped > 0 {
+		data.Skipped = skipped
+	}
+	return tb.sendTemplate(TemplatePlaylist, data)
+}
 
+// sendTemplate은 템플릿으로 만든 메시지를 전송합니다
+func (tb *TelegramBot) sendTemplate(name string, data MessageData) error {
+	message, err := tb.render(name, data)
+	if err != nil {
+		return err
+	}
 	return tb.SendMessage(message)
 }
 
@@ -171,28 +120,11 @@ func formatDuration(d time.Duration) string {
 
 // TestConnection은 텔레그램 봇 연결을 테스트합니다
 func (tb *TelegramBot) TestConnection() error {
-	now := time.Now()
-	loc, _ := time.LoadLocation("Asia/Seoul")
-	nowKST := now.In(loc)
-
-	message := fmt.Sprintf(`🤖 <b>도우미 봇 연결 테스트</b> 🤖
-
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
-✅ <b>상태:</b> <code>연결 성공</code>
-📡 <b>테스트 시간:</b> %s
-🔔 <b>알림 설정:</b> <code>정상 작동</code>
-━━━━━━━━━━━━━━━━━━━━━━━━━━━
-
-🎉 텔레그램 알림이 성공적으로 설정되었습니다!
-
-이제 다음과 같은 알림을 받으실 수 있습니다:
-🚀 매크로 시작 알림
-🎉 매크로 완료 알림
-❌ 오류 발생 알림
-
-<i>⏰ %s 기준</i>`,
-		nowKST.Format("2006년 01월 02일 15:04:05"),
-		nowKST.Format("2006-01-02 15:04:05"))
+	message, err := tb.render(TemplateTest, MessageData{})
+	if err != nil {
+		return err
+	}
 
-	return tb.SendMessage(message)
+	// 연결 확인이 목적이므로 보내지 못해도 보관함에 넣지 않음
+	return tb.send(message, false)
 }
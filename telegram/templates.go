@@ -0,0 +1,341 @@
+package telegram
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	_ "time/tzdata" // 시간대 데이터가 없는 Windows에서도 시간대를 불러올 수 있도록 포함
+
+	"example.com/m/notify"
+)
+
+// 메시지 언어 (다른 알림 대상과 같은 언어 코드)
+const (
+	LanguageKorean  = notify.LanguageKorean
+	LanguageEnglish = notify.LanguageEnglish
+)
+
+// Languages는 기본 템플릿을 제공하는 언어 목록입니다
+var Languages = []string{LanguageKorean, LanguageEnglish}
+
+// 기본 시간대 (설정하지 않았을 때)
+const DefaultTimeZone = "Asia/Seoul"
+
+// 메시지 템플릿 이름 (사용자 템플릿 파일은 <이름>.tmpl)
+const (
+	TemplateStart      = "start"      // 작업 시작 알림
+	TemplateProgress   = "progress"   // 실행 중 진행 상황 알림
+	TemplateCompletion = "completion" // 작업 완료 알림
+	TemplateError      = "error"      // 오류 알림
+	TemplateTest       = "test"       // 연결 테스트
+	TemplatePlaylist   = "playlist"   // 재생 목록 요약
+	TemplateStatus     = "status"     // 실시간 상태 카드
+)
+
+// TemplateNames는 메시지 템플릿 이름 목록입니다
+var TemplateNames = []string{TemplateStart, TemplateProgress, TemplateCompletion, TemplateError, TemplateTest, TemplatePlaylist, TemplateStatus}
+
+//go:embed templates
+var defaultTemplateFiles embed.FS
+
+// MessageData는 메시지 템플릿에 넘기는 값입니다
+//
+// 모든 템플릿: Mode, Duration, Remaining, Start, End, Iterations, Error (notify.Details), Now
+// 재생 목록 요약: Duration은 전체 실행 시간, State/StateCode, Steps, Skipped, Reason
+// 상태 카드: Duration은 경과 시간, State/StateCode, MaxIterations, Paused, Finished, Reason
+//
+// 템플릿 함수: html (HTML 이스케이프), duration (언어에 맞는 시간 길이), datetime ("2006-01-02 15:04:05"),
+// clock ("15:04:05"), local (설정한 시간대의 time.Time), zone (시간대 이름), label (상태 코드의 표시 이름), add
+type MessageData struct {
+	notify.Details
+	Now time.Time // 메시지를 만든 시각
+
+	State     string // 표시할 상태 이름 (한국어)
+	StateCode string // 상태 코드 ("completed", "stopped", "running" 등)
+	Reason    string // 끝난 이유
+
+	Steps   []PlaylistStepReport // 실행한 재생 목록 단계
+	Skipped int                  // 실행하지 않은 재생 목록 단계 수
+
+	MaxIterations int // 최대 반복 횟수 (0이면 제한 없음)
+	Paused        bool
+	Finished      bool
+}
+
+// stateLabels는 언어별 상태 코드 표시 이름입니다 (실행 결과, 재생 목록 상태, 작업 상태)
+var stateLabels = map[string]map[string]string{
+	LanguageKorean: {
+		"":                 "시작 실패",
+		"idle":             "대기",
+		"starting":         "시작 중",
+		"running":          "실행 중",
+		"waiting":          "다음 단계 대기",
+		"paused":           "일시정지",
+		"stopping":         "중지 중",
+		"completed":        "완료",
+		"timed_out":        "시간 종료",
+		"stopped":          "사용자 중지",
+		"stopped_by_user":  "사용자 중지",
+		"stopped_by_error": "오류",
+		"failed":           "실패",
+	},
+	LanguageEnglish: {
+		"":                 "Failed to start",
+		"idle":             "Idle",
+		"starting":         "Starting",
+		"running":          "Running",
+		"waiting":          "Waiting for next step",
+		"paused":           "Paused",
+		"stopping":         "Stopping",
+		"completed":        "Completed",
+		"timed_out":        "Time limit reached",
+		"stopped":          "Stopped by user",
+		"stopped_by_user":  "Stopped by user",
+		"stopped_by_error": "Error",
+		"failed":           "Failed",
+	},
+}
+
+// buttonLabels는 언어별 상태 카드 버튼 이름입니다
+var buttonLabels = map[string]map[string]string{
+	LanguageKorean:  {CallbackPause: "⏸️ 일시정지", CallbackResume: "▶️ 재개", CallbackStop: "⏹️ 중지"},
+	LanguageEnglish: {CallbackPause: "⏸️ Pause", CallbackResume: "▶️ Resume", CallbackStop: "⏹️ Stop"},
+}
+
+// Templates는 언어와 시간대에 맞춰 텔레그램 메시지를 만듭니다
+// Dir에 <이름>.tmpl 파일이 있으면 기본 템플릿 대신 사용합니다
+type Templates struct {
+	Dir string // 사용자 템플릿 디렉토리 (비어 있으면 기본 템플릿만 사용)
+
+	language string
+	location *time.Location
+	defaults map[string]*template.Template // 선택한 언어의 기본 템플릿
+	custom   map[string]*template.Template // 사용자 템플릿
+	mutex    sync.RWMutex
+}
+
+// NewTemplates는 한국어와 기본 시간대로 템플릿을 생성합니다
+func NewTemplates(dir string) *Templates {
+	t := &Templates{Dir: dir}
+	if err := t.Configure(LanguageKorean, nil); err != nil {
+		log.Printf("경고: 메시지 템플릿 로드 실패: %v", err)
+	}
+	return t
+}
+
+// defaultTemplates는 TelegramBot에 템플릿을 지정하지 않았을 때 사용합니다
+var defaultTemplates = sync.OnceValue(func() *Templates { return NewTemplates("") })
+
+// LoadLocation은 시간대 이름을 불러옵니다 (비어 있으면 DefaultTimeZone)
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("알 수 없는 시간대입니다: %s", name)
+	}
+	return loc, nil
+}
+
+// ValidLanguage는 기본 템플릿을 제공하는 언어인지 확인합니다
+func ValidLanguage(language string) bool {
+	_, ok := stateLabels[language]
+	return ok
+}
+
+// Configure는 언어와 시간대를 바꾸고 템플릿을 다시 읽습니다 (location이 nil이면 DefaultTimeZone)
+// 사용자 템플릿에 문법 오류가 있으면 그 템플릿은 기본 템플릿으로 대신하고 오류를 모아 반환합니다
+func (t *Templates) Configure(language string, location *time.Location) error {
+	if !ValidLanguage(language) {
+		return fmt.Errorf("지원하지 않는 언어입니다: %s", language)
+	}
+	if location == nil {
+		loc, err := LoadLocation("")
+		if err != nil {
+			loc = time.Local
+		}
+		location = loc
+	}
+
+	defaults := make(map[string]*template.Template, len(TemplateNames))
+	for _, name := range TemplateNames {
+		data, err := defaultTemplateFiles.ReadFile("templates/" + language + "/" + name + ".tmpl")
+		if err != nil {
+			return fmt.Errorf("기본 템플릿을 찾을 수 없습니다 (%s/%s): %v", language, name, err)
+		}
+		tmpl, err := t.parse(name, string(data), language, location)
+		if err != nil {
+			return fmt.Errorf("기본 템플릿 오류 (%s/%s): %v", language, name, err)
+		}
+		defaults[name] = tmpl
+	}
+
+	custom := make(map[string]*template.Template)
+	var problems []string
+	if t.Dir != "" {
+		for _, name := range TemplateNames {
+			data, err := os.ReadFile(filepath.Join(t.Dir, name+".tmpl"))
+			if err != nil {
+				if !os.IsNotExist(err) {
+					problems = append(problems, fmt.Sprintf("%s.tmpl: %v", name, err))
+				}
+				continue
+			}
+			tmpl, err := t.parse(name, string(data), language, location)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s.tmpl: %v", name, err))
+				continue
+			}
+			custom[name] = tmpl
+		}
+	}
+
+	t.mutex.Lock()
+	t.language = language
+	t.location = location
+	t.defaults = defaults
+	t.custom = custom
+	t.mutex.Unlock()
+
+	if len(problems) > 0 {
+		return fmt.Errorf("사용자 템플릿 오류 (기본 템플릿 사용): %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// parse는 언어와 시간대에 맞는 함수를 붙여 템플릿을 읽습니다
+func (t *Templates) parse(name, text, language string, location *time.Location) (*template.Template, error) {
+	funcs := template.FuncMap{
+		"duration": func(d time.Duration) string { return formatDurationIn(language, d) },
+		"datetime": func(at time.Time) string { return at.In(location).Format("2006-01-02 15:04:05") },
+		"clock":    func(at time.Time) string { return at.In(location).Format("15:04:05") },
+		"local":    func(at time.Time) time.Time { return at.In(location) },
+		"zone":     func() string { return location.String() },
+		"label":    func(code string) string { return stateLabel(language, code) },
+		"add":      func(a, b int) int { return a + b },
+	}
+	return template.New(name).Funcs(funcs).Parse(text)
+}
+
+// Render는 name 템플릿으로 메시지를 만듭니다 (data.Now가 비어 있으면 현재 시각)
+// 사용자 템플릿 실행이 실패하면 로그를 남기고 기본 템플릿으로 만듭니다
+func (t *Templates) Render(name string, data MessageData) (string, error) {
+	if data.Now.IsZero() {
+		data.Now = time.Now()
+	}
+
+	t.mutex.RLock()
+	custom, defaults := t.custom[name], t.defaults[name]
+	t.mutex.RUnlock()
+
+	var buf bytes.Buffer
+	if custom != nil {
+		err := custom.Execute(&buf, data)
+		if err == nil {
+			return strings.TrimSpace(buf.String()), nil
+		}
+		log.Printf("사용자 템플릿 %s.tmpl 실행 실패 (기본 템플릿 사용): %v", name, err)
+		buf.Reset()
+	}
+	if defaults == nil {
+		return "", fmt.Errorf("알 수 없는 메시지 템플릿입니다: %s", name)
+	}
+	if err := defaults.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("메시지 템플릿 실행 실패 (%s): %v", name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Language는 현재 메시지 언어를 반환합니다
+func (t *Templates) Language() string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.language
+}
+
+// Location은 메시지에 표시할 시간대를 반환합니다
+func (t *Templates) Location() *time.Location {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.location
+}
+
+// InstallExamples는 기본 템플릿을 Dir/examples/<언어>/에 복사합니다
+// 사용자 템플릿을 만들 때 참고하도록 항상 현재 버전으로 덮어씁니다
+func (t *Templates) InstallExamples() error {
+	if t.Dir == "" {
+		return nil
+	}
+	for _, language := range Languages {
+		dir := filepath.Join(t.Dir, "examples", language)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("템플릿 예제 디렉토리 생성 실패: %v", err)
+		}
+		for _, name := range TemplateNames {
+			data, err := defaultTemplateFiles.ReadFile("templates/" + language + "/" + name + ".tmpl")
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(dir, name+".tmpl"), data, 0644); err != nil {
+				return fmt.Errorf("템플릿 예제 저장 실패 (%s/%s): %v", language, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// stateLabel은 상태 코드의 표시 이름을 반환합니다 (모르는 코드는 그대로)
+func stateLabel(language, code string) string {
+	if label, ok := stateLabels[language][code]; ok {
+		return label
+	}
+	return code
+}
+
+// buttonLabel은 상태 카드 버튼 이름을 반환합니다
+func buttonLabel(language, callback string) string {
+	if label, ok := buttonLabels[language][callback]; ok {
+		return label
+	}
+	return buttonLabels[LanguageKorean][callback]
+}
+
+// formatDurationIn은 언어에 맞게 시간 길이를 표시합니다
+func formatDurationIn(language string, d time.Duration) string {
+	if language != LanguageEnglish {
+		return formatDuration(d)
+	}
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// templates는 봇에 지정된 템플릿을 반환합니다 (없으면 한국어 기본 템플릿)
+func (tb *TelegramBot) templates() *Templates {
+	if tb.Templates != nil {
+		return tb.Templates
+	}
+	return defaultTemplates()
+}
+
+// render는 봇의 템플릿으로 메시지를 만듭니다
+func (tb *TelegramBot) render(name string, data MessageData) (string, error) {
+	return tb.templates().Render(name, data)
+}
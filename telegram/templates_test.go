@@ -0,0 +1,184 @@
+package telegram
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/m/notify"
+)
+
+// templateNow는 템플릿 테스트의 메시지 시각입니다 (UTC)
+var templateNow = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// newTestTemplates는 dir의 사용자 템플릿 파일을 만들고 언어와 시간대를 설정한 템플릿을 반환합니다
+func newTestTemplates(t *testing.T, language, zone string, files map[string]string) (*Templates, error) {
+	t.Helper()
+	dir := ""
+	if files != nil {
+		dir = t.TempDir()
+		for name, text := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	location, err := LoadLocation(zone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates := &Templates{Dir: dir}
+	return templates, templates.Configure(language, location)
+}
+
+func mustRender(t *testing.T, templates *Templates, name string, data MessageData) string {
+	t.Helper()
+	text, err := templates.Render(name, data)
+	if err != nil {
+		t.Fatalf("%s 템플릿 실행 실패: %v", name, err)
+	}
+	return text
+}
+
+func TestTemplatesUserOverride(t *testing.T) {
+	templates, err := newTestTemplates(t, LanguageEnglish, "UTC", map[string]string{
+		"completion.tmpl": "done {{html .Mode}} {{label .StateCode}} {{clock .Now}} {{zone}}",
+		"notes.txt":       "템플릿이 아닌 파일",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := MessageData{Details: notify.Details{Mode: "대야"}, StateCode: "timed_out", Now: templateNow}
+	if got := mustRender(t, templates, TemplateCompletion, data); got != "done 대야 Time limit reached 03:04:05 UTC" {
+		t.Errorf("사용자 템플릿 결과 %q", got)
+	}
+
+	// 사용자 템플릿이 없는 메시지는 기본 템플릿
+	defaults, _ := newTestTemplates(t, LanguageEnglish, "UTC", nil)
+	if got, want := mustRender(t, templates, TemplateStart, data), mustRender(t, defaults, TemplateStart, data); got != want {
+		t.Errorf("기본 템플릿 결과 %q, 기대 %q", got, want)
+	}
+}
+
+func TestTemplatesUserFallback(t *testing.T) {
+	templates, err := newTestTemplates(t, LanguageKorean, "Asia/Seoul", map[string]string{
+		"error.tmpl": "{{.Mode",           // 문법 오류
+		"test.tmpl":  "{{.Unknown}}",      // 실행 오류 (없는 필드)
+		"start.tmpl": "{{duration .Now}}", // 실행 오류 (잘못된 인자 형식)
+	})
+	if err == nil || !strings.Contains(err.Error(), "error.tmpl") {
+		t.Fatalf("문법 오류가 보고되지 않았습니다: %v", err)
+	}
+	if strings.Contains(err.Error(), "test.tmpl") || strings.Contains(err.Error(), "start.tmpl") {
+		t.Errorf("문법이 올바른 템플릿이 오류로 보고되었습니다: %v", err)
+	}
+	if templates.Language() != LanguageKorean {
+		t.Errorf("사용자 템플릿 오류 뒤 언어 %q", templates.Language())
+	}
+
+	defaults, _ := newTestTemplates(t, LanguageKorean, "Asia/Seoul", nil)
+	data := MessageData{Details: notify.Details{Mode: "대야", Error: "입력 실패", Start: templateNow, End: templateNow}, Now: templateNow}
+	for _, name := range []string{TemplateError, TemplateTest, TemplateStart} {
+		if got, want := mustRender(t, templates, name, data), mustRender(t, defaults, name, data); got != want {
+			t.Errorf("%s: 기본 템플릿 대신 %q", name, got)
+		}
+	}
+
+	if _, err := templates.Render("unknown", data); err == nil {
+		t.Error("알 수 없는 템플릿 이름이 허용되었습니다")
+	}
+}
+
+func TestTemplatesLanguage(t *testing.T) {
+	data := MessageData{
+		Details: notify.Details{Mode: "대야", Duration: 3725 * time.Second, Start: templateNow, End: templateNow.Add(3725 * time.Second), Iterations: 7},
+		Now:     templateNow,
+	}
+	cases := []struct {
+		language string
+		want     []string
+	}{
+		{LanguageKorean, []string{"매크로 완료 알림", "<b>모드:</b> 대야", "<b>총 실행 시간:</b> 1시간 2분 5초", "<b>반복:</b> 7회"}},
+		{LanguageEnglish, []string{"Macro completed", "<b>Mode:</b> 대야", "<b>Total run time:</b> 1h 2m 5s", "<b>Iterations:</b> 7"}},
+	}
+	for _, tc := range cases {
+		templates, err := newTestTemplates(t, tc.language, "UTC", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := mustRender(t, templates, TemplateCompletion, data)
+		for _, want := range tc.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s 완료 메시지에 %q가 없습니다:\n%s", tc.language, want, got)
+			}
+		}
+	}
+
+	templates, _ := newTestTemplates(t, LanguageEnglish, "UTC", nil)
+	if err := templates.Configure("fr", nil); err == nil {
+		t.Error("지원하지 않는 언어가 허용되었습니다")
+	}
+	if templates.Language() != LanguageEnglish {
+		t.Errorf("설정 실패 뒤 언어 %q", templates.Language())
+	}
+}
+
+func TestTemplatesTimeZone(t *testing.T) {
+	data := MessageData{Details: notify.Details{Mode: "대야", Start: templateNow, End: templateNow.Add(time.Hour)}, Now: templateNow}
+	cases := []struct {
+		language string
+		want     []string
+	}{
+		{LanguageKorean, []string{"2026년 01월 01일 22:04:05", "2026년 01월 01일 23:04:05", "2026-01-01 22:04:05 (America/New_York)"}},
+		{LanguageEnglish, []string{"<b>Started:</b> 2026-01-01 22:04:05", "<b>Finished:</b> 2026-01-01 23:04:05", "2026-01-01 22:04:05 (America/New_York)"}},
+	}
+	for _, tc := range cases {
+		templates, err := newTestTemplates(t, tc.language, "America/New_York", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := mustRender(t, templates, TemplateCompletion, data)
+		for _, want := range tc.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s 완료 메시지에 %q가 없습니다:\n%s", tc.language, want, got)
+			}
+		}
+		if location := templates.Location().String(); location != "America/New_York" {
+			t.Errorf("시간대 %s", location)
+		}
+	}
+
+	templates, _ := newTestTemplates(t, LanguageEnglish, "Asia/Tokyo", map[string]string{"test.tmpl": "{{clock .Now}} {{datetime .Now}} {{(local .Now).Hour}}"})
+	if got := mustRender(t, templates, TemplateTest, data); got != "12:04:05 2026-01-02 12:04:05 12" {
+		t.Errorf("시간 함수 결과 %q", got)
+	}
+
+	if _, err := LoadLocation("Mars/Base"); err == nil {
+		t.Error("알 수 없는 시간대가 허용되었습니다")
+	}
+}
+
+func TestTemplatesEscapeHTML(t *testing.T) {
+	data := MessageData{
+		Details: notify.Details{Mode: "<b>대야</b>&", Error: "a < b", Start: templateNow, End: templateNow},
+		Now:     templateNow,
+	}
+	for _, language := range Languages {
+		templates, err := newTestTemplates(t, language, "UTC", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{TemplateStart, TemplateCompletion, TemplateError} {
+			got := mustRender(t, templates, name, data)
+			if strings.Contains(got, "<b>대야</b>") || !strings.Contains(got, "&lt;b&gt;대야&lt;/b&gt;&amp;") {
+				t.Errorf("%s/%s 모드가 이스케이프되지 않았습니다:\n%s", language, name, got)
+			}
+			if name == TemplateError && !strings.Contains(got, "a &lt; b") {
+				t.Errorf("%s 오류 내용이 이스케이프되지 않았습니다:\n%s", language, got)
+			}
+		}
+	}
+}
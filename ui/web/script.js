@@ -7,13 +7,14 @@ const ModeDaeyaParty = 2;
 const ModeKanchenEnter = 3;
 const ModeKanchenParty = 4;
 
-const TimeOption1Hour = 0;
-const TimeOption2Hour = 1;
-const TimeOption3Hour = 2;
-const TimeOption4Hour = 3;
+// 기본 실행 시간 (3시간 10분)
+const DefaultRunTime = '3h10m';
 
 // DOM 요소 참조
 const timerDisplay = document.getElementById('timer-display');
+const iterationInfo = document.getElementById('iteration-info');
+const maxIterationsInput = document.getElementById('max-iterations-input');
+const playlistInfo = document.getElementById('playlist-info');
 const statusIndicator = document.getElementById('status-indicator');
 const statusText = document.getElementById('status-text');
 const miniLog = document.getElementById('mini-log');
@@ -24,6 +25,8 @@ const exitBtn = document.getElementById('exit-btn');
 const navButtons = document.querySelectorAll('.nav-button');
 const modeOptions = document.querySelectorAll('input[name="mode"]');
 const timeOptions = document.querySelectorAll('input[name="time"]');
+const customTimeInput = document.getElementById('custom-time-input');
+const customTimeBtn = document.getElementById('custom-time-btn');
 const darkModeToggle = document.getElementById('dark-mode-toggle');
 const soundToggle = document.getElementById('sound-toggle');
 const startupToggle = document.getElementById('startup-toggle');
@@ -38,11 +41,37 @@ const autoRefreshToggle = document.getElementById('auto-refresh-toggle');
 const showDebugToggle = document.getElementById('show-debug-toggle');
 const logFilterInput = document.getElementById('log-filter-input');
 
+// 실행 기록 관련 DOM 요소
+const historyTableBody = document.getElementById('history-table-body');
+const refreshHistoryBtn = document.getElementById('refresh-history-btn');
+const historyModeFilter = document.getElementById('history-mode-filter');
+const historyStatusFilter = document.getElementById('history-status-filter');
+const historySinceFilter = document.getElementById('history-since-filter');
+const historyUntilFilter = document.getElementById('history-until-filter');
+const historyPrevBtn = document.getElementById('history-prev-btn');
+const historyNextBtn = document.getElementById('history-next-btn');
+const historyPageInfo = document.getElementById('history-page-info');
+
+// 통계 관련 DOM 요소
+const statsRange = document.getElementById('stats-range');
+const refreshStatsBtn = document.getElementById('refresh-stats-btn');
+
 // 텔레그램 관련 DOM 요소
 const telegramToggle = document.getElementById('telegram-toggle');
 const telegramConfig = document.getElementById('telegram-config');
 const botTokenInput = document.getElementById('bot-token');
 const chatIdInput = document.getElementById('chat-id');
+const telegramTimeoutInput = document.getElementById('telegram-timeout');
+const progressMinutesInput = document.getElementById('progress-minutes');
+const telegramDeliveryStatus = document.getElementById('telegram-delivery');
+const screenshotToggle = document.getElementById('screenshot-toggle');
+const screenshotMaxWidthInput = document.getElementById('screenshot-max-width');
+const saveScreenshotBtn = document.getElementById('save-screenshot-btn');
+const screenshotRegionInputs = ['x', 'y', 'width', 'height'].map(name => document.getElementById(`screenshot-${name}`));
+const messageLanguageSelect = document.getElementById('message-language');
+const messageTimezoneInput = document.getElementById('message-timezone');
+const messageTemplatesDir = document.getElementById('message-templates-dir');
+const saveMessageBtn = document.getElementById('save-message-btn');
 const saveTelegramBtn = document.getElementById('save-telegram-btn');
 const testTelegramBtn = document.getElementById('test-telegram-btn');
 
@@ -66,7 +95,7 @@ const experiencePoints = document.getElementById('experience-points');
 // 상태 변수
 let isRunning = false;            // 매크로 실행 중 여부
 let currentMode = ModeDaeyaEnter; // 현재 선택된 모드
-let currentTimeOption = TimeOption3Hour; // 현재 선택된 시간 옵션
+let currentRunTime = DefaultRunTime; // 현재 실행 시간 설정 ("3h10m", "04:30")
 let darkMode = true;              // 다크 모드 활성화 여부
 let soundEnabled = true;          // 소리 알림 활성화 여부
 let autoStartup = false;          // 시작 시 자동 실행 여부
@@ -85,6 +114,10 @@ let logFilterText = '';
 let logRefreshInterval = null;
 let lastLogLength = 0;
 
+// 실행 기록 관련 변수
+const historyPageSize = 20;
+let historyOffset = 0;
+
 // 퀘스트 관련 변수
 let quests = [];
 let currentQuestFilter = 'all';
@@ -113,6 +146,12 @@ document.addEventListener('DOMContentLoaded', () => {
     // 텔레그램 관련 리스너 설정
     setupTelegramListeners();
 
+    // 실행 기록 관련 리스너 설정
+    setupHistoryListeners();
+
+    // 통계 관련 리스너 설정
+    setupStatsListeners();
+
     // 퀘스트 관련 리스너 설정
     setupQuestListeners();
 
@@ -120,7 +159,7 @@ document.addEventListener('DOMContentLoaded', () => {
     loadSavedSettings();
 
     // 초기 타이머 표시 설정
-    updateCountdownDisplay(getHoursFromOption(currentTimeOption) * 60 * 60);
+    updateCountdownDisplay(getRunTimeSeconds(currentRunTime));
 
     // 초기 로그 메시지
     addLogMessage('프로그램이 시작되었습니다.');
@@ -169,6 +208,21 @@ function loadSavedSettings() {
                 }
             }
 
+            // 실행 시간 적용
+            if (settings.run_time) {
+                resetRunTimeSelection(settings.run_time);
+            }
+
+            // 반복 횟수 제한 적용
+            if (settings.max_iterations !== undefined && maxIterationsInput) {
+                maxIterationsInput.value = settings.max_iterations;
+            }
+
+            // 진행 상황 알림 주기 적용
+            if (settings.progress_minutes !== undefined && progressMinutesInput) {
+                progressMinutesInput.value = settings.progress_minutes;
+            }
+
             // 텔레그램 설정 적용
             if (settings.telegram_enabled !== undefined) {
                 telegramEnabled = settings.telegram_enabled;
@@ -205,38 +259,64 @@ function checkApiStatus() {
     fetch('/api/status')
         .then(response => response.json())
         .then(data => {
-            // 서버 상태가 변경됐을 때만 처리
-            if (data.running !== isRunning) {
-                // 서버가 중지되었는데 타이머가 일시정지 상태가 아니면 완전 중지 처리
-                if (!data.running && !timerPaused) {
-                    isRunning = false;
-                    statusText.textContent = '준비됨';
-                    statusIndicator.classList.remove('running');
-                    startBtn.classList.remove('active');
-                    stopBtn.classList.remove('active');
-                    resetCountdown(); // 이 경우에만 타이머 리셋
-                }
-                // 서버가 시작되었지만 클라이언트는 실행 중이 아니면 시작 처리
-                else if (data.running && !isRunning && !timerPaused) {
-                    isRunning = true;
-                    serverTimerStarted = true;
-                    statusText.textContent = '실행 중';
-                    statusIndicator.classList.add('running');
-                    startBtn.classList.add('active');
-                    stopBtn.classList.remove('active');
-
-                    // 타이머 시작 (일시정지 상태가 아닐 때만)
-                    if (!countdownInterval && !timerPaused) {
-                        startCountdown(getHoursFromOption(currentTimeOption) * 60 * 60);
-                    }
-                }
+            // 서버 상태가 변경됐을 때만 화면에 반영
+            applyOperationState(data.running, data.paused);
+            applyStateIndicator(data.state);
+            if (data.iteration) {
+                updateIterationInfo(data.iteration);
             }
+            updatePlaylistInfo(data.playlist);
         })
         .catch(() => {
             // 오류 발생시 무시
         });
 }
 
+// 서버의 작업 상태를 화면에 반영 (일시정지도 서버에서는 실행 중인 상태)
+function applyOperationState(running, paused) {
+    if (running && paused) {
+        // 서버에서 일시정지됨 - 중지 버튼과 같이 타이머 값 유지
+        if (isRunning) {
+            isRunning = false;
+            timerPaused = true;
+            statusText.textContent = '일시정지';
+            statusIndicator.classList.remove('running');
+            startBtn.classList.remove('active');
+            stopBtn.classList.add('active');
+            stopCountdown();
+        }
+    } else if (running) {
+        // 서버에서 시작 또는 재개됨
+        if (!isRunning) {
+            isRunning = true;
+            serverTimerStarted = true;
+            statusText.textContent = '실행 중';
+            statusIndicator.classList.add('running');
+            startBtn.classList.add('active');
+            stopBtn.classList.remove('active');
+
+            // 타이머 시작 (일시정지 상태였다면 남은 시간부터 재개)
+            if (!countdownInterval) {
+                startCountdown(getRunTimeSeconds(currentRunTime));
+            }
+            timerPaused = false;
+        }
+    } else if (isRunning || timerPaused) {
+        // 서버에서 완전히 중지됨 - 타이머 리셋
+        isRunning = false;
+        statusText.textContent = '준비됨';
+        statusIndicator.classList.remove('running');
+        startBtn.classList.remove('active');
+        stopBtn.classList.remove('active');
+        resetCountdown();
+    }
+}
+
+// 작업 상태 표시 - 시작 중, 중지 중, 오류 같은 전환 상태를 표시등 색으로 구분
+function applyStateIndicator(state) {
+    statusIndicator.dataset.state = state || 'idle';
+}
+
 // 네비게이션 기능 설정 - 수정됨
 function setupNavigation() {
     navButtons.forEach(button => {
@@ -249,6 +329,16 @@ function setupNavigation() {
                 refreshLogs();
             }
 
+            // 실행 기록 섹션으로 이동할 때 기록 새로고침
+            if (section === 'history') {
+                refreshHistory();
+            }
+
+            // 통계 섹션으로 이동할 때 통계 새로고침
+            if (section === 'stats') {
+                refreshStats();
+            }
+
             // 퀘스트 섹션으로 이동할 때 퀘스트 렌더링
             if (section === 'todo') {
                 renderQuests();
@@ -304,19 +394,21 @@ function setupInitialSelections() {
     // 시간 옵션 이벤트 리스너
     timeOptions.forEach(option => {
         option.addEventListener('change', (e) => {
-            currentTimeOption = parseInt(e.target.value);
-            setTimeOptionApi(currentTimeOption);
+            applyRunTime(e.target.value);
+        });
+    });
 
-            // 타이머가 실행 중이 아니라면 새 시간으로 표시 업데이트
-            if (!isRunning && !timerPaused) {
-                let hours = getHoursFromOption(currentTimeOption);
-                countdownTime = hours * 60 * 60;
-                updateCountdownDisplay(countdownTime);
+    // 실행 시간 직접 입력
+    if (customTimeBtn && customTimeInput) {
+        customTimeBtn.addEventListener('click', () => {
+            applyRunTime(customTimeInput.value.trim());
+        });
+        customTimeInput.addEventListener('keydown', (e) => {
+            if (e.key === 'Enter') {
+                applyRunTime(customTimeInput.value.trim());
             }
-
-            addLogMessage(`${formatTimeOption(currentTimeOption)} 실행 설정됨`);
         });
-    });
+    }
 }
 
 // 버튼 이벤트 리스너 설정
@@ -335,8 +427,12 @@ function setupButtonListeners() {
                 // 중지 버튼 활성화 상태 해제
                 stopBtn.classList.remove('active');
 
-                // 서버에 매크로 시작 요청
-                startOperation(wasTimerPaused);
+                // 서버에 매크로 시작 요청 (일시정지 상태였다면 멈춘 단계부터 재개)
+                if (wasTimerPaused) {
+                    resumeOperation();
+                } else {
+                    startOperation();
+                }
 
                 // 상태 업데이트
                 isRunning = true;
@@ -359,13 +455,13 @@ function setupButtonListeners() {
                 // 중지 버튼 시각적 피드백
                 stopBtn.classList.add('active');
 
-                // 서버에 매크로 중지 요청
-                stopOperation();
+                // 서버에 매크로 일시정지 요청
+                pauseOperation();
 
                 // 상태 업데이트 (타이머는 일시정지)
                 isRunning = false;
                 timerPaused = true;
-                statusText.textContent = '준비됨';
+                statusText.textContent = '일시정지';
                 statusIndicator.classList.remove('running');
                 startBtn.classList.remove('active');
 
@@ -399,8 +495,7 @@ function setupButtonListeners() {
                 stopBtn.classList.remove('active');
 
                 // 타이머 초기화
-                const hours = getHoursFromOption(currentTimeOption);
-                countdownTime = hours * 60 * 60;
+                countdownTime = getRunTimeSeconds(currentRunTime);
                 updateCountdownDisplay(countdownTime);
 
                 // 잠시 후 버튼 활성화 해제
@@ -524,8 +619,166 @@ function setupTelegramListeners() {
         testTelegramConnection();
     });
 
+    // 요청 제한 시간
+    if (telegramTimeoutInput) {
+        telegramTimeoutInput.addEventListener('change', () => {
+            const seconds = parseInt(telegramTimeoutInput.value, 10);
+            if (!(seconds >= 1 && seconds <= 120)) {
+                showNotification('요청 제한 시간은 1초에서 120초 사이여야 합니다', 'error');
+                return;
+            }
+            saveSetting('telegram_timeout', seconds);
+            addLogMessage(`텔레그램 요청 제한 시간: ${seconds}초`);
+        });
+    }
+
+    // 진행 상황 알림 주기
+    if (progressMinutesInput) {
+        progressMinutesInput.addEventListener('change', () => {
+            const minutes = parseInt(progressMinutesInput.value, 10) || 0;
+            if (minutes < 0 || minutes > 1440) {
+                showNotification('진행 상황 알림 주기는 0분에서 1440분 사이여야 합니다', 'error');
+                return;
+            }
+            saveSetting('progress_minutes', minutes);
+            addLogMessage(minutes > 0 ? `진행 상황 알림: ${minutes}분마다` : '진행 상황 알림: 끔');
+        });
+    }
+
+    // 화면 캡처 설정 저장
+    if (saveScreenshotBtn) {
+        saveScreenshotBtn.addEventListener('click', () => {
+            saveScreenshotSettings();
+        });
+    }
+
+    // 메시지 언어와 시간대 저장
+    if (saveMessageBtn) {
+        saveMessageBtn.addEventListener('click', () => {
+            saveMessageSettings();
+        });
+    }
+
     // 초기 텔레그램 설정 로드
     loadTelegramSettings();
+    loadScreenshotSettings();
+    loadMessageSettings();
+}
+
+// 메시지 언어와 시간대 로드
+function loadMessageSettings() {
+    if (!messageLanguageSelect || !messageTimezoneInput) {
+        return;
+    }
+
+    fetch('/api/telegram/messages')
+        .then(response => response.json())
+        .then(applyMessageSettings)
+        .catch(() => {
+            // 오류 무시
+        });
+}
+
+// 메시지 설정을 입력란에 표시
+function applyMessageSettings(settings) {
+    messageLanguageSelect.value = settings.language;
+    messageTimezoneInput.value = settings.time_zone;
+    if (messageTemplatesDir && settings.templates_dir) {
+        messageTemplatesDir.textContent = settings.templates_dir;
+    }
+}
+
+// 메시지 언어와 시간대 저장 (사용자 템플릿도 다시 읽음)
+function saveMessageSettings() {
+    const settings = {
+        language: messageLanguageSelect.value,
+        time_zone: messageTimezoneInput.value.trim() || 'Asia/Seoul'
+    };
+
+    fetch('/api/telegram/messages', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json',
+        },
+        body: JSON.stringify(settings)
+    })
+        .then(response => {
+            if (!response.ok) {
+                return response.text().then(text => { throw new Error(text.trim()); });
+            }
+            return response.json();
+        })
+        .then(saved => {
+            applyMessageSettings(saved);
+            if (saved.warning) {
+                showNotification(saved.warning, 'error');
+                addLogMessage(`메시지 템플릿 경고: ${saved.warning}`);
+                return;
+            }
+            showNotification('메시지 설정이 저장되었습니다', 'success');
+            addLogMessage(`텔레그램 메시지: ${saved.language}, ${saved.time_zone}`);
+        })
+        .catch(error => {
+            showNotification(`메시지 설정 저장 실패: ${error.message}`, 'error');
+        });
+}
+
+// 화면 캡처 설정 로드
+function loadScreenshotSettings() {
+    if (!screenshotToggle || !screenshotMaxWidthInput) {
+        return;
+    }
+
+    fetch('/api/telegram/screenshot')
+        .then(response => response.json())
+        .then(applyScreenshotSettings)
+        .catch(() => {
+            // 오류 무시
+        });
+}
+
+// 화면 캡처 설정을 입력란에 표시 (영역이 없으면 비워 둠)
+function applyScreenshotSettings(settings) {
+    screenshotToggle.checked = settings.enabled;
+    const hasRegion = settings.width > 0 && settings.height > 0;
+    ['x', 'y', 'width', 'height'].forEach((name, i) => {
+        if (screenshotRegionInputs[i]) {
+            screenshotRegionInputs[i].value = hasRegion ? settings[name] : '';
+        }
+    });
+    screenshotMaxWidthInput.value = settings.max_width || 0;
+}
+
+// 화면 캡처 설정 저장
+function saveScreenshotSettings() {
+    const [x, y, width, height] = screenshotRegionInputs.map(input => parseInt(input && input.value, 10) || 0);
+    const settings = {
+        enabled: screenshotToggle.checked,
+        x, y, width, height,
+        max_width: parseInt(screenshotMaxWidthInput.value, 10) || 0
+    };
+
+    fetch('/api/telegram/screenshot', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json',
+        },
+        body: JSON.stringify(settings)
+    })
+        .then(response => {
+            if (!response.ok) {
+                return response.text().then(text => { throw new Error(text.trim()); });
+            }
+            return response.json();
+        })
+        .then(saved => {
+            applyScreenshotSettings(saved);
+            showNotification('화면 캡처 설정이 저장되었습니다', 'success');
+            addLogMessage(`화면 캡처 첨부: ${saved.enabled ? '켜짐' : '꺼짐'}`);
+        })
+        .catch(error => {
+            showNotification(`화면 캡처 설정 저장 실패: ${error.message}`, 'error');
+        });
 }
 
 // 텔레그램 설정 저장
@@ -622,12 +875,38 @@ function loadTelegramSettings() {
                 telegramConfig.style.display = 'block';
                 testTelegramBtn.disabled = false;
             }
+            if (telegramTimeoutInput && data.timeout) {
+                telegramTimeoutInput.value = data.timeout;
+            }
+            if (data.delivery) {
+                renderTelegramDelivery(data.delivery);
+            }
         })
         .catch(() => {
             // 오류 무시
         });
 }
 
+// 텔레그램 전송 실패 횟수와 보관함에서 대기 중인 메시지 수 표시 (문제가 없으면 숨김)
+function renderTelegramDelivery(stats) {
+    if (!telegramDeliveryStatus) {
+        return;
+    }
+
+    if (!stats.failed && !stats.queued) {
+        telegramDeliveryStatus.hidden = true;
+        return;
+    }
+
+    let text = `⚠️ 전송 실패 ${stats.failed || 0}회`;
+    if (stats.queued) {
+        text += ` · 다시 보낼 메시지 ${stats.queued}개`;
+    }
+    telegramDeliveryStatus.textContent = text;
+    telegramDeliveryStatus.title = stats.last_error ? `마지막 오류: ${stats.last_error}` : '';
+    telegramDeliveryStatus.hidden = false;
+}
+
 // 텔레그램 활성화 상태 API 전송
 function setTelegramEnabledApi(enabled) {
     fetch('/api/settings', {
@@ -795,6 +1074,259 @@ function clearLogs() {
         });
 }
 
+// 실행 기록 리스너 설정
+function setupHistoryListeners() {
+    if (!historyTableBody) {
+        return;
+    }
+
+    refreshHistoryBtn.addEventListener('click', () => refreshHistory());
+
+    // 필터가 바뀌면 첫 페이지부터 다시 조회
+    [historyModeFilter, historyStatusFilter, historySinceFilter, historyUntilFilter].forEach(filter => {
+        filter.addEventListener('change', () => {
+            historyOffset = 0;
+            refreshHistory();
+        });
+    });
+
+    historyPrevBtn.addEventListener('click', () => {
+        historyOffset = Math.max(0, historyOffset - historyPageSize);
+        refreshHistory();
+    });
+
+    historyNextBtn.addEventListener('click', () => {
+        historyOffset += historyPageSize;
+        refreshHistory();
+    });
+
+    // 모드 필터 목록 채우기
+    fetch('/api/sequences')
+        .then(response => response.json())
+        .then(data => {
+            data.sequences.forEach(sequence => {
+                const option = document.createElement('option');
+                option.value = sequence.id;
+                option.textContent = sequence.name;
+                historyModeFilter.appendChild(option);
+            });
+        })
+        .catch(() => {});
+}
+
+// 실행 기록 조회
+function refreshHistory() {
+    if (!historyTableBody) {
+        return;
+    }
+
+    const params = new URLSearchParams({
+        offset: historyOffset,
+        limit: historyPageSize
+    });
+    if (historyModeFilter.value) params.set('mode', historyModeFilter.value);
+    if (historyStatusFilter.value) params.set('status', historyStatusFilter.value);
+    if (historySinceFilter.value) params.set('since', historySinceFilter.value);
+    if (historyUntilFilter.value) params.set('until', historyUntilFilter.value);
+
+    fetch('/api/history?' + params.toString())
+        .then(response => response.json())
+        .then(page => displayHistory(page))
+        .catch(() => {
+            historyTableBody.innerHTML = '<tr><td colspan="7" class="log-placeholder">기록을 불러올 수 없습니다.</td></tr>';
+        });
+}
+
+function displayHistory(page) {
+    historyTableBody.innerHTML = '';
+
+    if (page.sessions.length === 0) {
+        historyTableBody.innerHTML = '<tr><td colspan="7" class="log-placeholder">기록이 없습니다.</td></tr>';
+    }
+
+    page.sessions.forEach(session => {
+        const row = document.createElement('tr');
+        const reason = session.errors ? session.errors.join(', ') : (session.stop_reason || '');
+        const cells = [
+            new Date(session.started_at).toLocaleString(),
+            session.mode_name || session.mode,
+            session.planned_seconds > 0 ? formatSeconds(session.planned_seconds) : '제한 없음',
+            formatSeconds(session.actual_seconds),
+            session.iterations,
+            getHistoryStatusName(session.status),
+            reason
+        ];
+        cells.forEach((value, index) => {
+            const cell = document.createElement('td');
+            cell.textContent = value;
+            if (index === 5) {
+                cell.className = session.status;
+            }
+            row.appendChild(cell);
+        });
+        historyTableBody.appendChild(row);
+    });
+
+    // 페이지 정보
+    const first = page.total === 0 ? 0 : page.offset + 1;
+    const last = page.offset + page.sessions.length;
+    historyPageInfo.textContent = `${first}-${last} / ${page.total}`;
+    historyPrevBtn.disabled = page.offset === 0;
+    historyNextBtn.disabled = last >= page.total;
+}
+
+// 통계 리스너 설정
+function setupStatsListeners() {
+    if (!statsRange) {
+        return;
+    }
+
+    statsRange.addEventListener('change', () => refreshStats());
+    refreshStatsBtn.addEventListener('click', () => refreshStats());
+}
+
+// 통계 조회
+function refreshStats() {
+    if (!statsRange) {
+        return;
+    }
+
+    fetch('/api/stats?days=' + statsRange.value)
+        .then(response => response.json())
+        .then(stats => displayStats(stats))
+        .catch(() => {
+            addLogMessage('통계를 불러올 수 없습니다.');
+        });
+}
+
+function displayStats(stats) {
+    const total = stats.total;
+
+    // 요약
+    document.getElementById('stats-total-hours').textContent = formatHours(total.active_seconds);
+    document.getElementById('stats-total-runs').textContent = total.runs;
+    document.getElementById('stats-success-rate').textContent = Math.round(total.success_rate * 100) + '%';
+    document.getElementById('stats-avg-loop').textContent = total.avg_loop_seconds > 0 ? total.avg_loop_seconds.toFixed(1) + '초' : '-';
+    document.getElementById('stats-keys-sent').textContent = total.keys_sent.toLocaleString();
+
+    // 정상 종료 / 사용자 중지 / 오류 비율
+    const resultBar = document.getElementById('stats-result-bar');
+    resultBar.innerHTML = '';
+    if (total.runs > 0) {
+        [['succeeded', '정상 종료'], ['aborted', '사용자 중지'], ['failed', '오류']].forEach(([key, label]) => {
+            const part = document.createElement('div');
+            part.className = key;
+            part.style.width = (total[key] / total.runs * 100) + '%';
+            part.title = `${label} ${total[key]}회`;
+            resultBar.appendChild(part);
+        });
+    }
+
+    // 모드 이름
+    const modeNames = {};
+    stats.modes.forEach(mode => {
+        modeNames[mode.mode] = mode.mode_name || mode.mode;
+    });
+
+    // 이번 주 모드별 실행 시간 (마지막 주가 이번 주)
+    const thisWeek = stats.weekly[stats.weekly.length - 1];
+    const weekRows = thisWeek ? Object.entries(thisWeek.mode_seconds)
+        .map(([mode, seconds]) => ({ label: modeNames[mode] || mode, seconds }))
+        .sort((a, b) => b.seconds - a.seconds) : [];
+    renderBarChart(document.getElementById('stats-week-chart'), weekRows);
+
+    // 모드별 합계
+    renderBarChart(document.getElementById('stats-mode-chart'), stats.modes.map(mode => ({
+        label: mode.mode_name || mode.mode,
+        seconds: mode.active_seconds,
+        title: `${mode.runs}회 실행, 정상 종료 ${Math.round(mode.success_rate * 100)}%`
+    })));
+
+    // 일별 실행 시간
+    const dailyChart = document.getElementById('stats-daily-chart');
+    const maxDaily = Math.max(1, ...stats.daily.map(day => day.active_seconds));
+    dailyChart.innerHTML = '';
+    stats.daily.forEach(day => {
+        const column = document.createElement('div');
+        column.className = 'stats-column';
+        column.title = `${day.start}: ${formatHours(day.active_seconds)} (${day.runs}회)`;
+
+        const fill = document.createElement('div');
+        fill.className = 'stats-column-fill';
+        fill.style.height = (day.active_seconds / maxDaily * 100) + '%';
+
+        const label = document.createElement('div');
+        label.className = 'stats-column-label';
+        label.textContent = day.start.slice(5);
+
+        column.appendChild(fill);
+        column.appendChild(label);
+        dailyChart.appendChild(column);
+    });
+}
+
+// 가로 막대 차트
+function renderBarChart(container, rows) {
+    container.innerHTML = '';
+
+    if (rows.length === 0) {
+        container.innerHTML = '<p class="log-placeholder">기록이 없습니다.</p>';
+        return;
+    }
+
+    const max = Math.max(1, ...rows.map(row => row.seconds));
+    rows.forEach(row => {
+        const item = document.createElement('div');
+        item.className = 'stats-bar-row';
+        if (row.title) {
+            item.title = row.title;
+        }
+
+        const label = document.createElement('span');
+        label.textContent = row.label;
+
+        const track = document.createElement('div');
+        track.className = 'stats-bar-track';
+        const fill = document.createElement('div');
+        fill.className = 'stats-bar-fill';
+        fill.style.width = (row.seconds / max * 100) + '%';
+        track.appendChild(fill);
+
+        const value = document.createElement('span');
+        value.className = 'stats-bar-value';
+        value.textContent = formatHours(row.seconds);
+
+        item.appendChild(label);
+        item.appendChild(track);
+        item.appendChild(value);
+        container.appendChild(item);
+    });
+}
+
+// 초 단위 시간을 시간 단위로 표시
+function formatHours(seconds) {
+    return (seconds / 3600).toFixed(1) + '시간';
+}
+
+// 실행 결과 이름
+function getHistoryStatusName(status) {
+    switch (status) {
+        case 'completed': return '완료';
+        case 'timed_out': return '시간 종료';
+        case 'stopped_by_user': return '사용자 중지';
+        case 'stopped_by_error': return '오류';
+        default: return status;
+    }
+}
+
+// 초 단위 시간을 HH:MM:SS로 표시
+function formatSeconds(seconds) {
+    const hours = Math.floor(seconds / 3600);
+    const minutes = Math.floor((seconds % 3600) / 60);
+    const secs = seconds % 60;
+    return [hours, minutes, secs].map(value => String(value).padStart(2, '0')).join(':');
+}
+
 // 로그 레벨 판단
 function getLogLevel(log) {
     const lowerLog = log.toLowerCase();
@@ -877,35 +1409,70 @@ function getApiModeName(mode) {
     }
 }
 
-// 시간 옵션별 시간 가져오기
-function getHoursFromOption(option) {
-    switch (option) {
-        case TimeOption1Hour:
-            return 1 + (10 / 60); // 1시간 10분
-        case TimeOption2Hour:
-            return 2 + (10 / 60); // 2시간 10분
-        case TimeOption3Hour:
-            return 3 + (10 / 60); // 3시간 10분
-        case TimeOption4Hour:
-            return 4 + (10 / 60); // 4시간 10분
-        default:
-            return 3 + (10 / 60); // 기본값: 3시간 10분
+// 실행 시간 설정의 초 단위 값 ("3h10m", "90m", 시간 단위 숫자, "04:30"은 지금부터 그 시각까지)
+// 서버의 utils.ParseRunTime과 같은 형식이며, 실제 종료 시간은 서버가 계산
+function getRunTimeSeconds(value) {
+    if (!value) return 0;
+
+    const endAt = /^(\d{1,2}):(\d{2})$/.exec(value);
+    if (endAt) {
+        const now = new Date();
+        const end = new Date(now);
+        end.setHours(parseInt(endAt[1]), parseInt(endAt[2]), 0, 0);
+        if (end <= now) {
+            end.setDate(end.getDate() + 1);
+        }
+        return Math.round((end - now) / 1000);
     }
+
+    if (/^\d+(\.\d+)?$/.test(value)) {
+        return Math.round(parseFloat(value) * 3600);
+    }
+
+    const units = { h: 3600, m: 60, s: 1 };
+    let seconds = 0;
+    const pattern = /(\d+(?:\.\d+)?)([hms])/g;
+    let match;
+    while ((match = pattern.exec(value)) !== null) {
+        seconds += parseFloat(match[1]) * units[match[2]];
+    }
+    return Math.round(seconds);
 }
 
-function formatTimeOption(option) {
-    switch (option) {
-        case TimeOption1Hour:
-            return '1시간 10분';
-        case TimeOption2Hour:
-            return '2시간 10분';
-        case TimeOption3Hour:
-            return '3시간 10분';
-        case TimeOption4Hour:
-            return '4시간 10분';
-        default:
-            return '3시간 10분';
+// 실행 시간 설정 표시 ("3시간 10분", "04:30까지")
+function formatRunTime(value) {
+    if (/^\d{1,2}:\d{2}$/.test(value)) {
+        return `${value}까지`;
     }
+
+    const seconds = getRunTimeSeconds(value);
+    const hours = Math.floor(seconds / 3600);
+    const minutes = Math.floor((seconds % 3600) / 60);
+    const secs = seconds % 60;
+
+    const parts = [];
+    if (hours > 0) parts.push(`${hours}시간`);
+    if (minutes > 0) parts.push(`${minutes}분`);
+    if (secs > 0 || parts.length === 0) parts.push(`${secs}초`);
+    return parts.join(' ');
+}
+
+// 실행 시간 변경 - 서버에서 확인한 뒤 화면에 반영
+function applyRunTime(value) {
+    if (!value) return;
+
+    setRunTimeApi(value)
+        .then(() => {
+            resetRunTimeSelection(value);
+            addLogMessage(`${formatRunTime(value)} 실행 설정됨`);
+        })
+        .catch(error => {
+            if (customTimeInput) {
+                customTimeInput.parentElement.classList.add('invalid');
+            }
+            addLogMessage(`오류: ${error.message}`);
+            resetRunTimeSelection(currentRunTime);
+        });
 }
 
 // 카운트다운 표시 업데이트
@@ -972,8 +1539,7 @@ function resetCountdown() {
     }
 
     // 타이머 값 초기화
-    const hours = getHoursFromOption(currentTimeOption);
-    countdownTime = hours * 60 * 60;
+    countdownTime = getRunTimeSeconds(currentRunTime);
     updateCountdownDisplay(countdownTime);
 
     // 타이머 스타일 업데이트
@@ -995,42 +1561,40 @@ window.dispatchAppEvent = function (event) {
             addLogMessage(payload.message);
             break;
         case 'operationStatus':
-            // 서버에서 상태 변경 이벤트 받음 - 타이머는 건드리지 않음
-            if (payload.running !== isRunning) {
-                if (payload.running) {
-                    // 서버에서 시작 신호가 왔지만 클라이언트는 중지됐다면
-                    if (!isRunning && !timerPaused) {
-                        isRunning = true;
-                        serverTimerStarted = true;
-                        statusText.textContent = '실행 중';
-                        statusIndicator.classList.add('running');
-                        startBtn.classList.add('active');
-
-                        // 타이머 시작 (일시정지 상태가 아니면)
-                        if (!countdownInterval && !timerPaused) {
-                            startCountdown(getHoursFromOption(currentTimeOption) * 60 * 60);
-                        }
-                    }
-                } else {
-                    // 서버에서 중지 신호가 왔고 일시정지 상태가 아니라면
-                    if (isRunning && !timerPaused) {
-                        isRunning = false;
-                        statusText.textContent = '준비됨';
-                        statusIndicator.classList.remove('running');
-                        startBtn.classList.remove('active');
-                        stopBtn.classList.remove('active');
-
-                        // 타이머 리셋 (일시정지가 아닐 때만)
-                        resetCountdown();
-                    }
-                }
+            // 단축키 등 서버 쪽에서 상태가 바뀐 이유 기록
+            if (payload.reason) {
+                addLogMessage(payload.reason);
+            }
+
+            // 서버에서 상태 변경 이벤트 받음
+            applyOperationState(payload.running, payload.paused);
+
+            // 작업이 끝나면 새 기록이 추가됨
+            if (payload.result && currentContentSection === 'history') {
+                refreshHistory();
+            }
+            if (payload.result && currentContentSection === 'stats') {
+                refreshStats();
             }
             break;
+        case 'operationError':
+            // 키 입력 실패 등으로 작업이 중지됨 (자세한 이유는 operationStatus로 로그에 기록)
+            showNotification(`작업이 오류로 중지되었습니다: ${payload.message}`, 'error');
+            break;
+        case 'operationState':
+            applyStateIndicator(payload.state);
+            break;
+        case 'telegramDelivery':
+            renderTelegramDelivery(payload);
+            break;
+        case 'iteration':
+            updateIterationInfo(payload);
+            break;
         case 'resetMode':
             resetModeSelection(payload.mode);
             break;
-        case 'resetTimeOption':
-            resetTimeOptionSelection(payload.option);
+        case 'resetRunTime':
+            resetRunTimeSelection(payload.value);
             break;
         case 'resetTimer':
             // 타이머 값 초기화
@@ -1051,22 +1615,66 @@ function resetModeSelection(mode) {
     });
 }
 
-// 시간 옵션 초기화
-function resetTimeOptionSelection(option) {
-    currentTimeOption = option;
+// 실행 시간 선택 표시 - 기본 옵션에 없는 값이면 직접 입력란에 표시
+function resetRunTimeSelection(value) {
+    currentRunTime = value;
 
+    let preset = false;
     timeOptions.forEach(opt => {
-        opt.checked = parseInt(opt.value) === option;
+        opt.checked = opt.value === value;
+        preset = preset || opt.checked;
     });
+    if (customTimeInput) {
+        customTimeInput.value = preset ? '' : value;
+        customTimeInput.parentElement.classList.remove('invalid');
+    }
 
     // 타이머 표시 업데이트 (실행 중이 아닐 때만)
     if (!isRunning && !timerPaused) {
-        const hours = getHoursFromOption(option);
-        countdownTime = hours * 60 * 60;
+        countdownTime = getRunTimeSeconds(value);
         updateCountdownDisplay(countdownTime);
     }
 }
 
+// 반복 정보 표시 - 횟수와 한 바퀴 시간
+function updateIterationInfo(info) {
+    if (!iterationInfo) return;
+
+    if (!info || !info.count) {
+        iterationInfo.textContent = '';
+        return;
+    }
+
+    const count = info.maxIterations ? `${info.count} / ${info.maxIterations}회` : `${info.count}회`;
+    iterationInfo.textContent =
+        `반복 ${count} · 최근 ${formatLoopTime(info.lastMs)} · 평균 ${formatLoopTime(info.averageMs)}`;
+}
+
+// 재생 목록 진행 표시 - 실행 중인 재생 목록이 없으면 비움
+function updatePlaylistInfo(playlist) {
+    if (!playlistInfo) return;
+
+    if (!playlist) {
+        playlistInfo.textContent = '';
+        return;
+    }
+
+    const state = playlist.state === 'waiting' ? '다음 단계 대기 중' : '실행 중';
+    playlistInfo.textContent = `재생 목록 ${playlist.step} / ${playlist.total}단계 · ${playlist.sequence} (${state})`;
+}
+
+// 한 바퀴 시간 표시 (초 단위, 소수점 한 자리)
+function formatLoopTime(ms) {
+    return `${(ms / 1000).toFixed(1)}초`;
+}
+
+// 반복 횟수 제한 값 (0이면 제한 없음)
+function getMaxIterations() {
+    if (!maxIterationsInput) return 0;
+    const value = parseInt(maxIterationsInput.value);
+    return isNaN(value) || value < 0 ? 0 : value;
+}
+
 // 앱 버전 정보 업데이트
 function updateAppVersion(version, date) {
     if (appVersion) appVersion.textContent = version;
@@ -1076,7 +1684,7 @@ function updateAppVersion(version, date) {
 // API 호출 관련 함수
 
 // 작업 시작 함수
-function startOperation(wasTimerPaused) {
+function startOperation() {
     // 모드 선택 확인
     if (currentMode === ModeNone) {
         addLogMessage("오류: 모드를 선택해야 합니다.");
@@ -1095,10 +1703,11 @@ function startOperation(wasTimerPaused) {
     }
 
     // 실행 시간 설정 확인
-    const hours = getHoursFromOption(currentTimeOption);
+    const seconds = getRunTimeSeconds(currentRunTime);
 
-    // 서버에 시작 요청 - 재시작 여부를 파라미터로 전달
-    const requestBody = `mode=${apiMode}&auto_stop=${hours}${wasTimerPaused ? '&resume=true' : ''}`;
+    // 서버에 시작 요청
+    const maxIterations = getMaxIterations();
+    const requestBody = `mode=${apiMode}&auto_stop=${encodeURIComponent(currentRunTime)}&max_iterations=${maxIterations}`;
 
     fetch('/api/start', {
         method: 'POST',
@@ -1114,20 +1723,12 @@ function startOperation(wasTimerPaused) {
 
                 // 클라이언트 타이머 시작
                 if (!countdownInterval) {
-                    // 일시정지 상태였다면 그 값 유지, 아니면 새로 시작
-                    if (wasTimerPaused) {
-                        startCountdown(countdownTime);
-                    } else {
-                        startCountdown(hours * 60 * 60);
-                    }
+                    startCountdown(seconds);
                 }
 
-                // 로그 메시지 - 재시작 여부에 따라 다른 메시지
-                if (wasTimerPaused) {
-                    addLogMessage(`${getModeName(currentMode)} 모드 작업을 재개합니다...`);
-                } else {
-                    addLogMessage(`${getModeName(currentMode)} 모드로 작업을 시작합니다... (${formatTimeOption(currentTimeOption)})`);
-                }
+                updateIterationInfo(null);
+                const limit = maxIterations > 0 ? `, ${maxIterations}회 반복` : '';
+                addLogMessage(`${getModeName(currentMode)} 모드로 작업을 시작합니다... (${formatRunTime(currentRunTime)}${limit})`);
             } else {
                 throw new Error('작업 시작 실패');
             }
@@ -1140,31 +1741,63 @@ function startOperation(wasTimerPaused) {
             isRunning = false;
             statusText.textContent = '준비됨';
             statusIndicator.classList.remove('running');
+        });
+}
 
-            // 일시정지 상태였다면 복원
-            if (wasTimerPaused) {
-                timerPaused = true;
-                stopBtn.classList.add('active');
+// 작업 재개 함수 - 일시정지된 단계부터 이어서 실행
+function resumeOperation() {
+    fetch('/api/resume', {
+        method: 'POST'
+    })
+        .then(response => {
+            if (!response.ok) {
+                throw new Error('작업 재개 실패');
+            }
+
+            // 남은 시간부터 클라이언트 타이머 재개
+            if (!countdownInterval) {
+                startCountdown(countdownTime);
             }
+
+            addLogMessage(`${getModeName(currentMode)} 모드 작업을 재개합니다...`);
+        })
+        .catch(error => {
+            addLogMessage("오류: 작업을 재개할 수 없습니다.");
+            startBtn.classList.remove('active');
+
+            // 일시정지 상태 복원
+            isRunning = false;
+            timerPaused = true;
+            statusText.textContent = '일시정지';
+            statusIndicator.classList.remove('running');
+            stopBtn.classList.add('active');
         });
 }
 
-// 시간 옵션 변경시 로그 메시지도 수정
-timeOptions.forEach(option => {
-    option.addEventListener('change', (e) => {
-        currentTimeOption = parseInt(e.target.value);
-        setTimeOptionApi(currentTimeOption);
-
-        // 타이머가 실행 중이 아니라면 새 시간으로 표시 업데이트
-        if (!isRunning && !timerPaused) {
-            let hours = getHoursFromOption(currentTimeOption);
-            countdownTime = hours * 60 * 60;
-            updateCountdownDisplay(countdownTime);
-        }
+// 작업 일시정지 함수
+function pauseOperation() {
+    fetch('/api/pause', {
+        method: 'POST'
+    })
+        .then(response => {
+            if (!response.ok) {
+                throw new Error('작업 일시정지 실패');
+            }
+        })
+        .catch(error => {
+            addLogMessage("오류: 작업을 일시정지할 수 없습니다.");
+        });
+}
 
-        addLogMessage(`${formatTimeOption(currentTimeOption)} 실행 설정됨`);
+// 반복 횟수 제한 변경
+if (maxIterationsInput) {
+    maxIterationsInput.addEventListener('change', () => {
+        const count = getMaxIterations();
+        maxIterationsInput.value = count;
+        saveSetting('max_iterations', count);
+        addLogMessage(count > 0 ? `${count}회 반복 후 자동 종료 설정됨` : '반복 횟수 제한 해제됨');
     });
-});
+}
 
 // 작업 중지 함수
 function stopOperation() {
@@ -1190,7 +1823,7 @@ function resetSettingsApi() {
     resetModeSelection(ModeDaeyaEnter);
 
     // 시간 설정 초기화 - 3시간으로 설정
-    resetTimeOptionSelection(TimeOption3Hour);
+    resetRunTimeSelection(DefaultRunTime);
 
     // 일시정지 상태 해제
     timerPaused = false;
@@ -1228,18 +1861,21 @@ function setModeApi(mode) {
     }).catch(() => { });
 }
 
-// 시간 옵션 설정 API
-function setTimeOptionApi(option) {
-    const hours = getHoursFromOption(option);
-
-    // API 호출하여 시간 설정 저장
-    fetch('/api/settings', {
+// 실행 시간 설정 API - 잘못된 값이면 서버의 오류 메시지로 실패
+function setRunTimeApi(value) {
+    return fetch('/api/settings', {
         method: 'POST',
         headers: {
             'Content-Type': 'application/x-www-form-urlencoded',
         },
-        body: `type=time&value=${hours}`
-    }).catch(() => { });
+        body: `type=time&value=${encodeURIComponent(value)}`
+    }).then(response => {
+        if (!response.ok) {
+            return response.text().then(text => {
+                throw new Error(text.trim() || '실행 시간을 저장할 수 없습니다');
+            });
+        }
+    });
 }
 
 // 자동 시작 설정 API
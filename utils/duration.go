@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 실행 시간 범위
+const (
+	MinRunDuration = time.Minute
+	MaxRunDuration = 24 * time.Hour
+)
+
+// DefaultRunTime은 기본 실행 시간입니다 (3시간 10분)
+var DefaultRunTime = RunTime{Duration: 3*time.Hour + 10*time.Minute}
+
+// RunTime은 작업 실행 시간 설정입니다
+// 실행 시간(Duration)이나 종료 시각(EndAt, "15:04") 중 하나를 사용하며, 둘 다 비어 있으면 제한이 없습니다
+type RunTime struct {
+	Duration time.Duration
+	EndAt    string
+}
+
+// ParseRunTime은 실행 시간 설정을 해석합니다
+//
+//	"3h10m", "90m"  실행 시간
+//	"3.5"           실행 시간 (시간 단위 숫자)
+//	"04:30"         그 시각까지 실행 (지났으면 다음 날)
+//	"", "0"         제한 없음
+func ParseRunTime(value string) (RunTime, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return RunTime{}, nil
+	}
+
+	// 종료 시각
+	if strings.Contains(value, ":") {
+		t, err := time.Parse("15:04", value)
+		if err != nil {
+			return RunTime{}, fmt.Errorf("잘못된 종료 시각 '%s' (HH:MM 형식)", value)
+		}
+		return RunTime{EndAt: t.Format("15:04")}, nil
+	}
+
+	// 단위 없는 숫자는 시간 단위
+	if hours, err := strconv.ParseFloat(value, 64); err == nil {
+		return checkRunDuration(time.Duration(hours * float64(time.Hour)))
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return RunTime{}, fmt.Errorf("잘못된 실행 시간 '%s' (예: 2h30m, 90m)", value)
+	}
+	return checkRunDuration(d)
+}
+
+// checkRunDuration은 실행 시간이 허용 범위인지 확인합니다 (0은 제한 없음)
+func checkRunDuration(d time.Duration) (RunTime, error) {
+	d = d.Round(time.Second)
+	if d == 0 {
+		return RunTime{}, nil
+	}
+	if d < MinRunDuration || d > MaxRunDuration {
+		return RunTime{}, fmt.Errorf("실행 시간은 %s 이상 %s 이하여야 합니다", formatKorean(MinRunDuration), formatKorean(MaxRunDuration))
+	}
+	return RunTime{Duration: d}, nil
+}
+
+// IsZero는 제한이 없는 설정인지 확인합니다
+func (t RunTime) IsZero() bool {
+	return t.Duration == 0 && t.EndAt == ""
+}
+
+// Resolve는 now에 시작했을 때의 실행 시간을 계산합니다 (0이면 제한 없음)
+// 종료 시각이 이미 지났으면 다음 날 그 시각까지 실행합니다
+func (t RunTime) Resolve(now time.Time) (time.Duration, error) {
+	if t.EndAt == "" {
+		return t.Duration, nil
+	}
+
+	clock, err := time.Parse("15:04", t.EndAt)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 종료 시각 '%s'", t.EndAt)
+	}
+	end := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	d := end.Sub(now).Round(time.Second)
+	if d < MinRunDuration {
+		return 0, fmt.Errorf("종료 시각 %s까지 %s도 남지 않았습니다", t.EndAt, formatKorean(MinRunDuration))
+	}
+	return d, nil
+}
+
+// String은 ParseRunTime으로 다시 읽을 수 있는 형식으로 반환합니다 ("3h10m", "04:30")
+func (t RunTime) String() string {
+	if t.EndAt != "" {
+		return t.EndAt
+	}
+	if t.Duration == 0 {
+		return ""
+	}
+	// time.Duration.String()의 "3h10m0s"에서 0인 단위 제거
+	s := t.Duration.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// Label은 화면과 알림에 표시할 설명을 반환합니다 ("3시간 10분", "04:30까지")
+func (t RunTime) Label() string {
+	switch {
+	case t.EndAt != "":
+		return t.EndAt + "까지"
+	case t.Duration == 0:
+		return "제한 없음"
+	default:
+		return formatKorean(t.Duration)
+	}
+}
+
+// formatKorean은 시간을 "3시간 10분" 형식으로 반환합니다
+func formatKorean(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+
+	var parts []string
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%d시간", hours))
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%d분", minutes))
+	}
+	if seconds > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%d초", seconds))
+	}
+	return strings.Join(parts, " ")
+}
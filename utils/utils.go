@@ -6,9 +6,12 @@ import (
 )
 
 // TimerManager는 타이머 기능을 관리합니다
+// 일시정지 중에도 Running은 true이며, 경과 시간은 늘어나지 않습니다
 type TimerManager struct {
 	Running     bool
+	Paused      bool
 	StartTime   time.Time
+	PausedAt    time.Time
 	ElapsedTime time.Duration
 	Mutex       sync.Mutex
 }
@@ -28,6 +31,7 @@ func (tm *TimerManager) Start() {
 	defer tm.Mutex.Unlock()
 	tm.StartTime = time.Now()
 	tm.Running = true
+	tm.Paused = false
 }
 
 // Stop은 타이머를 중지하고 경과 시간을 저장합니다
@@ -35,17 +39,54 @@ func (tm *TimerManager) Stop() {
 	tm.Mutex.Lock()
 	defer tm.Mutex.Unlock()
 	if tm.Running {
-		tm.ElapsedTime += time.Since(tm.StartTime)
+		if !tm.Paused {
+			tm.ElapsedTime += time.Since(tm.StartTime)
+		}
 		tm.Running = false
+		tm.Paused = false
 	}
 }
 
+// Pause는 타이머를 일시정지하고 경과 시간을 고정합니다
+// 실행 중이 아니거나 이미 일시정지 상태면 false를 반환합니다
+func (tm *TimerManager) Pause() bool {
+	tm.Mutex.Lock()
+	defer tm.Mutex.Unlock()
+	if !tm.Running || tm.Paused {
+		return false
+	}
+	tm.PausedAt = time.Now()
+	tm.ElapsedTime += tm.PausedAt.Sub(tm.StartTime)
+	tm.Paused = true
+	return true
+}
+
+// Resume은 일시정지된 타이머를 재개하고, 일시정지되어 있던 시간을 반환합니다
+func (tm *TimerManager) Resume() (time.Duration, bool) {
+	tm.Mutex.Lock()
+	defer tm.Mutex.Unlock()
+	if !tm.Running || !tm.Paused {
+		return 0, false
+	}
+	tm.StartTime = time.Now()
+	tm.Paused = false
+	return tm.StartTime.Sub(tm.PausedAt), true
+}
+
+// IsPaused는 타이머가 일시정지 상태인지 확인합니다
+func (tm *TimerManager) IsPaused() bool {
+	tm.Mutex.Lock()
+	defer tm.Mutex.Unlock()
+	return tm.Paused
+}
+
 // Reset은 타이머를 초기화합니다
 func (tm *TimerManager) Reset() {
 	tm.Mutex.Lock()
 	defer tm.Mutex.Unlock()
 	tm.ElapsedTime = 0
 	tm.Running = false
+	tm.Paused = false
 }
 
 // IsRunning은 타이머가 실행 중인지 확인합니다
@@ -66,7 +107,7 @@ func (tm *TimerManager) GetStartTime() time.Time {
 func (tm *TimerManager) GetElapsedTime() time.Duration {
 	tm.Mutex.Lock()
 	defer tm.Mutex.Unlock()
-	if tm.Running {
+	if tm.Running && !tm.Paused {
 		return tm.ElapsedTime + time.Since(tm.StartTime)
 	}
 	return tm.ElapsedTime